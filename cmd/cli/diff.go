@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sql-genius/internal/schema"
+	"strings"
+)
+
+// runDiff 현재 스키마(DB 또는 -schema/-ddl/-schema-dir)와 -target 스키마를 비교해
+// 차이와 마이그레이션 DDL을 출력
+func runDiff(ctx context.Context) {
+	if *diffTarget == "" {
+		fmt.Fprintln(os.Stderr, "❌ 비교 대상이 필요합니다: sql-genius diff -db mysql -database mydb -target schema.sql")
+		os.Exit(1)
+	}
+
+	from, connector, err := loadSchema(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 스키마 로드 실패: %v\n", err)
+		os.Exit(1)
+	}
+	if connector != nil {
+		connector.Close()
+	}
+	if from == nil {
+		fmt.Fprintln(os.Stderr, "❌ 비교 기준 스키마가 필요합니다 (-db 연결 또는 -schema, -ddl, -schema-dir)")
+		os.Exit(1)
+	}
+
+	to, err := loadSchemaPath(*diffTarget)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 대상 스키마 로드 실패: %v\n", err)
+		os.Exit(1)
+	}
+
+	dialect := from.DBType
+	if dialect == "" {
+		dialect = ddlDialect()
+	}
+
+	parser := schema.NewParser()
+	diff := parser.Diff(from, to)
+	migration, err := parser.GenerateMigration(diff, dialect)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 마이그레이션 생성 실패: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *diffJSON {
+		output, _ := json.MarshalIndent(map[string]interface{}{
+			"diff":      diff,
+			"migration": migration,
+		}, "", "  ")
+		fmt.Println(string(output))
+		return
+	}
+
+	if diff.IsEmpty() {
+		fmt.Println("✅ 스키마 차이가 없습니다")
+		return
+	}
+
+	printDiff(diff)
+	fmt.Println("\n📝 마이그레이션 (정방향):")
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Print(migration.Forward)
+	fmt.Println("\n↩️  롤백:")
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Print(migration.Rollback)
+}
+
+func printDiff(d *schema.SchemaDiff) {
+	fmt.Println("\n🔍 스키마 차이")
+	fmt.Println(strings.Repeat("─", 60))
+	for _, t := range d.AddedTables {
+		fmt.Printf("+ 테이블 %s (%d 컬럼)\n", t.QualifiedName(), len(t.Columns))
+	}
+	for _, t := range d.DroppedTables {
+		fmt.Printf("- 테이블 %s\n", t.QualifiedName())
+	}
+	for _, td := range d.ChangedTables {
+		fmt.Printf("~ 테이블 %s\n", td.QualifiedName())
+		for _, col := range td.AddedColumns {
+			fmt.Printf("   + 컬럼 %s %s\n", col.Name, col.Type)
+		}
+		for _, col := range td.DroppedColumns {
+			fmt.Printf("   - 컬럼 %s\n", col.Name)
+		}
+		for _, cc := range td.ChangedColumns {
+			fmt.Printf("   ~ 컬럼 %s (%s)\n", cc.Name, strings.Join(cc.Changes, ", "))
+		}
+		if td.PrimaryKey != nil {
+			fmt.Printf("   ~ 기본키 (%s) → (%s)\n", strings.Join(td.PrimaryKey.From, ", "), strings.Join(td.PrimaryKey.To, ", "))
+		}
+		for _, fk := range td.AddedForeignKeys {
+			fmt.Printf("   + 외래키 %s → %s\n", fk.Name, fk.RefTable)
+		}
+		for _, fk := range td.DroppedForeignKeys {
+			fmt.Printf("   - 외래키 %s → %s\n", fk.Name, fk.RefTable)
+		}
+		for _, idx := range td.AddedIndexes {
+			fmt.Printf("   + 인덱스 %s (%s)\n", idx.Name, strings.Join(idx.Columns, ", "))
+		}
+		for _, idx := range td.DroppedIndexes {
+			fmt.Printf("   - 인덱스 %s (%s)\n", idx.Name, strings.Join(idx.Columns, ", "))
+		}
+	}
+}
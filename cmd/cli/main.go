@@ -3,37 +3,98 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"sql-genius/internal/ai"
 	"sql-genius/internal/db"
+	"sql-genius/internal/export"
 	"sql-genius/internal/query"
 	"sql-genius/internal/schema"
 	"sql-genius/pkg/models"
+	"strconv"
 	"strings"
 	"time"
 )
 
 var (
 	// DB 연결 옵션
-	dbType   = flag.String("db", "", "데이터베이스 타입 (mysql, postgresql, oracle, sqlserver)")
+	dbType   = flag.String("db", "", "데이터베이스 타입 (mysql, postgresql, oracle, sqlserver, sqlite)")
 	dbHost   = flag.String("host", "localhost", "데이터베이스 호스트")
 	dbPort   = flag.Int("port", 0, "데이터베이스 포트")
 	dbUser   = flag.String("user", "", "데이터베이스 사용자")
 	dbPass   = flag.String("password", "", "데이터베이스 비밀번호")
-	dbName   = flag.String("database", "", "데이터베이스 이름")
+	dbName   = flag.String("database", "", "데이터베이스 이름 (SQLite: 파일 경로)")
+
+	// 고급 연결 옵션
+	dbTLS            = flag.String("tls", "", "TLS 모드 (disable, prefer, require, verify-ca, verify-full, 생략 시 DB별 기본값)")
+	dbCACert         = flag.String("ca-cert", "", "CA 인증서 파일 (PEM, Oracle: wallet 디렉터리)")
+	dbClientCert     = flag.String("client-cert", "", "클라이언트 인증서 파일 (PEM)")
+	dbClientKey      = flag.String("client-key", "", "클라이언트 개인 키 파일 (PEM)")
+	dbAppName        = flag.String("app-name", "", "DB 서버에 표시할 애플리케이션 이름")
+	dbConnectTimeout = flag.Int("connect-timeout", 0, "연결 타임아웃 (초, 0이면 기본값)")
+	dbReadTimeout    = flag.Int("read-timeout", 0, "읽기 타임아웃 (초, 0이면 기본값)")
+	dbMaxConns       = flag.Int("max-conns", 0, "최대 연결 수 (0이면 기본값)")
+	dbMaxIdleConns   = flag.Int("max-idle-conns", 0, "최대 유휴 연결 수 (0이면 기본값)")
+	dbServiceName    = flag.String("service-name", "", "Oracle 서비스 이름 (생략 시 -database)")
+	dbSID            = flag.String("sid", "", "Oracle SID (서비스 이름 대신 사용)")
+	dbParams         = paramFlags{}
+
+	// SSH 터널 옵션 (-ssh-host를 지정하면 배스천 호스트를 거쳐 연결)
+	sshHost       = flag.String("ssh-host", "", "SSH 배스천 호스트 (-host, -port는 배스천에서 본 DB 주소)")
+	sshPort       = flag.Int("ssh-port", 22, "SSH 포트")
+	sshUser       = flag.String("ssh-user", "", "SSH 사용자 (기본: 현재 사용자)")
+	sshPassword   = flag.String("ssh-password", "", "SSH 비밀번호")
+	sshKey        = flag.String("ssh-key", "", "SSH 개인 키 파일")
+	sshPassphrase = flag.String("ssh-key-passphrase", "", "암호화된 SSH 개인 키의 암호")
+	sshAgent      = flag.Bool("ssh-agent", false, "SSH_AUTH_SOCK의 ssh-agent로 인증")
+	sshKnownHosts = flag.String("ssh-known-hosts", "", "호스트 키를 검증할 known_hosts 파일 (기본: ~/.ssh/known_hosts)")
+	sshInsecure   = flag.Bool("ssh-insecure", false, "SSH 호스트 키 검증 생략 (테스트용)")
+
+	// 스키마 추출 범위 (쉼표로 구분한 glob 패턴)
+	extractSchemas        = flag.String("schemas", "", "추출할 스키마 패턴 (예: \"sales,hr_*\", 생략 시 기본 스키마만)")
+	extractExcludeSchemas = flag.String("exclude-schemas", "", "제외할 스키마 패턴")
+	extractTables         = flag.String("tables", "", "추출할 테이블 패턴 (예: \"orders,sales.*\", 생략 시 전체)")
+	extractExcludeTables  = flag.String("exclude-tables", "", "제외할 테이블 패턴")
+
+	// 연결 프로필 (sql-genius profile ...로 관리)
+	profileName  = flag.String("profile", "", "저장된 연결 프로필 이름 (명령줄에 지정한 옵션이 우선)")
+	profilesPath = flag.String("profiles", "", "프로필 파일 경로 (기본: 사용자 설정 디렉터리의 sql-genius/profiles.json)")
 
 	// 스키마 입력 옵션
 	schemaFile = flag.String("schema", "", "스키마 파일 경로 (JSON 또는 DDL)")
 	schemaDDL  = flag.String("ddl", "", "DDL 문자열")
+	schemaDir  = flag.String("schema-dir", "", "마이그레이션 디렉터리 (*.sql 파일을 이름 순서대로 적용)")
 
 	// AI 옵션
-	aiProvider  = flag.String("ai", "ollama", "AI 제공자 (ollama, groq)")
-	aiModel     = flag.String("model", "", "AI 모델 이름")
-	aiEndpoint  = flag.String("endpoint", "", "AI 엔드포인트")
-	groqAPIKey  = flag.String("groq-key", "", "Groq API 키 (환경변수 GROQ_API_KEY도 가능)")
+	aiProvider    = flag.String("ai", "ollama", "AI 제공자 (ollama, groq, openai-compatible)")
+	aiModel       = flag.String("model", "", "AI 모델 이름")
+	aiEndpoint    = flag.String("endpoint", "", "AI 엔드포인트")
+	groqAPIKey    = flag.String("groq-key", "", "Groq API 키 (환경변수 GROQ_API_KEY도 가능)")
+	aiAPIKey      = flag.String("api-key", "", "OpenAI 호환 API 키 (환경변수 OPENAI_API_KEY도 가능)")
+	aiTemperature = flag.Float64("temperature", -1, "AI temperature (미지정 시 기본값)")
+	aiMaxTokens   = flag.Int("max-tokens", 0, "AI 최대 생성 토큰 수 (미지정 시 기본값)")
+	aiHeaders     = headerFlags{}
+
+	// diff 옵션 (sql-genius diff ...)
+	diffTarget = flag.String("target", "", "diff 대상 스키마 (DDL/JSON 파일 또는 마이그레이션 디렉터리)")
+	diffJSON   = flag.Bool("json", false, "diff 결과를 JSON으로 출력")
+
+	// 생성 쿼리 검증 옵션
+	verifyExplain = flag.Bool("explain", true, "DB 연결 시 생성된 쿼리를 EXPLAIN으로 검증 (쿼리는 실행하지 않음)")
+	maxRetries    = flag.Int("max-retries", query.DefaultMaxRetries, "검증 실패 시 오류를 전달해 다시 생성할 최대 횟수")
+
+	// 실행 옵션 (대화형 /run)
+	execPolicy = flag.String("exec-policy", "read-only", "쿼리 실행 정책 (read-only, confirm-dml, unrestricted)")
+	maxRows    = flag.Int("max-rows", 1000, "/run 결과 집합당 읽을 최대 행 수 (0이면 제한 없음)")
+
+	// 결과 내보내기 (/run, -prompt)
+	outFile   = flag.String("out", "", "쿼리 결과를 저장할 파일 (지정하면 /run 결과를 행 수 제한 없이 저장)")
+	outFormat = flag.String("format", "", "내보내기 형식 (csv, tsv, ndjson, markdown, xlsx, sql, 생략 시 -out 확장자)")
+	outTable  = flag.String("out-table", "result", "sql 형식 INSERT 대상 테이블")
 
 	// 기타
 	interactive = flag.Bool("i", false, "대화형 모드")
@@ -48,25 +109,51 @@ const banner = `
 ╚═══════════════════════════════════════════════════════════╝
 `
 
+func init() {
+	flag.Var(aiHeaders, "ai-header", "AI 요청에 추가할 HTTP 헤더 \"Name: value\" (반복 가능)")
+	flag.Var(dbParams, "dsn-param", "드라이버 DSN 추가 파라미터 \"key=value\" (반복 가능)")
+}
+
 func main() {
+	// 서브커맨드: sql-genius diff [옵션]
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		flag.CommandLine.Parse(os.Args[2:])
+		applyProfileOrExit()
+		runDiff(context.Background())
+		return
+	}
+	// 서브커맨드: sql-genius profile list|save|delete
+	if len(os.Args) > 1 && os.Args[1] == "profile" {
+		runProfile(os.Args[2:])
+		return
+	}
+
 	flag.Parse()
+	applyProfileOrExit()
 
 	fmt.Print(banner)
 
 	ctx := context.Background()
 
 	// 스키마 로드
-	dbSchema, err := loadSchema(ctx)
+	dbSchema, connector, err := loadSchema(ctx)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ 스키마 로드 실패: %v\n", err)
 		os.Exit(1)
 	}
+	if connector != nil {
+		defer connector.Close()
+	}
 
 	if dbSchema == nil {
 		fmt.Println("💡 사용법:")
 		fmt.Println("  1. DB 직접 연결: sql-genius -db mysql -host localhost -port 3306 -user root -password xxx -database mydb")
+		fmt.Println("     SQLite 파일: sql-genius -db sqlite -database ./app.db")
 		fmt.Println("  2. 스키마 파일: sql-genius -schema schema.json")
 		fmt.Println("  3. DDL 입력: sql-genius -ddl \"CREATE TABLE ...\"")
+		fmt.Println("  4. 마이그레이션 폴더: sql-genius -db postgresql -schema-dir ./migrations")
+		fmt.Println("  5. 스키마 비교: sql-genius diff -db mysql -database mydb ... -target schema.sql")
+		fmt.Println("  6. 저장된 프로필: sql-genius profile save mydb -db mysql ... 후 sql-genius -profile mydb")
 		os.Exit(0)
 	}
 
@@ -76,6 +163,10 @@ func main() {
 		Model:    *aiModel,
 		Endpoint: *aiEndpoint,
 		APIKey:   getAPIKey(),
+
+		Headers:     aiHeaders,
+		Temperature: aiTemperatureValue(),
+		MaxTokens:   *aiMaxTokens,
 	}
 
 	provider, err := ai.NewProvider(aiConfig)
@@ -95,68 +186,193 @@ func main() {
 
 	// 쿼리 생성기 초기화
 	gen := query.NewGenerator(provider, dbSchema)
+	gen.SetMaxRetries(*maxRetries)
+	if connector != nil && *verifyExplain {
+		gen.SetConnector(connector)
+	}
 
 	fmt.Printf("📊 로드된 테이블: %d개\n", len(dbSchema.Tables))
 	for _, t := range dbSchema.Tables {
-		fmt.Printf("   - %s (%d 컬럼)\n", t.Name, len(t.Columns))
+		fmt.Printf("   - %s (%d 컬럼)\n", t.QualifiedName(), len(t.Columns))
+	}
+	if len(dbSchema.Views)+len(dbSchema.Sequences)+len(dbSchema.Routines)+len(dbSchema.Triggers) > 0 {
+		fmt.Printf("📊 뷰 %d개, 시퀀스 %d개, 함수/프로시저 %d개, 트리거 %d개\n",
+			len(dbSchema.Views), len(dbSchema.Sequences), len(dbSchema.Routines), len(dbSchema.Triggers))
 	}
 	fmt.Println()
 
 	if *interactive || *promptText == "" {
-		runInteractive(ctx, gen)
+		runInteractive(ctx, gen, connector)
 	} else {
-		runSingle(ctx, gen)
+		runSingle(ctx, gen, connector)
 	}
 }
 
-func loadSchema(ctx context.Context) (*models.Schema, error) {
+// loadSchema 옵션에 따라 스키마 로드
+// DB에 직접 연결한 경우 연결된 connector를 함께 반환하며, 호출자가 닫아야 합니다.
+func loadSchema(ctx context.Context) (*models.Schema, db.Connector, error) {
 	parser := schema.NewParser()
 
-	// 1. DB 직접 연결
-	if *dbType != "" {
-		config := models.DBConfig{
-			Type:     models.DBType(*dbType),
-			Host:     *dbHost,
-			Port:     getPort(),
-			User:     *dbUser,
-			Password: *dbPass,
-			Database: *dbName,
+	// 1. DB 직접 연결 (스키마 입력이 있으면 -db는 DDL 문법 지정으로만 사용)
+	if *dbType != "" && *schemaFile == "" && *schemaDDL == "" && *schemaDir == "" {
+		config, err := dbConfig()
+		if err != nil {
+			return nil, nil, err
 		}
 
 		connector, err := db.NewConnector(config)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if err := connector.Connect(ctx); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		defer connector.Close()
 
 		fmt.Println("✅ 데이터베이스 연결됨")
-		return connector.ExtractSchema(ctx)
+		opts := extractOptions()
+		opts.Progress = printExtractProgress
+		s, err := connector.ExtractSchema(ctx, opts)
+		if err != nil {
+			fmt.Println()
+			connector.Close()
+			return nil, nil, err
+		}
+		// 통계는 프롬프트 보조 정보이므로 실패해도 계속
+		if err := connector.ExtractStats(ctx, s); err != nil {
+			fmt.Printf("⚠️  통계 조회 실패 (통계 없이 계속 진행...): %v\n", err)
+		}
+		return s, connector, nil
 	}
 
-	// 2. 스키마 파일
-	if *schemaFile != "" {
-		data, err := os.ReadFile(*schemaFile)
-		if err != nil {
-			return nil, err
+	var (
+		s   *models.Schema
+		err error
+	)
+	switch {
+	case *schemaFile != "": // 2. 스키마 파일
+		s, err = loadSchemaPath(*schemaFile)
+	case *schemaDDL != "": // 3. DDL 문자열
+		s, err = parser.ParseDDL(*schemaDDL, ddlDialect())
+	case *schemaDir != "": // 4. 마이그레이션 디렉터리
+		s, err = parser.ParseDDLDir(*schemaDir, ddlDialect())
+	}
+	return s, nil, err
+}
+
+// dbConfig 명령줄 옵션으로 만든 DB 연결 설정
+func dbConfig() (models.DBConfig, error) {
+	policy, err := db.ParsePolicy(*execPolicy)
+	if err != nil {
+		return models.DBConfig{}, err
+	}
+	tlsMode, err := db.ParseTLSMode(*dbTLS)
+	if err != nil {
+		return models.DBConfig{}, err
+	}
+
+	config := models.DBConfig{
+		Type:            models.DBType(*dbType),
+		Host:            *dbHost,
+		Port:            getPort(),
+		User:            *dbUser,
+		Password:        *dbPass,
+		Database:        *dbName,
+		Policy:          policy,
+		TLSMode:         tlsMode,
+		CACert:          *dbCACert,
+		ClientCert:      *dbClientCert,
+		ClientKey:       *dbClientKey,
+		ApplicationName: *dbAppName,
+		ConnectTimeout:  *dbConnectTimeout,
+		ReadTimeout:     *dbReadTimeout,
+		MaxOpenConns:    *dbMaxConns,
+		MaxIdleConns:    *dbMaxIdleConns,
+		ServiceName:     *dbServiceName,
+		SID:             *dbSID,
+	}
+	if len(dbParams) > 0 {
+		config.Params = dbParams
+	}
+	if *sshHost != "" {
+		user := *sshUser
+		if user == "" {
+			user = os.Getenv("USER")
+		}
+		config.SSH = &models.SSHConfig{
+			Host:                  *sshHost,
+			Port:                  *sshPort,
+			User:                  user,
+			Password:              *sshPassword,
+			KeyFile:               *sshKey,
+			KeyPassphrase:         *sshPassphrase,
+			Agent:                 *sshAgent,
+			KnownHosts:            *sshKnownHosts,
+			InsecureIgnoreHostKey: *sshInsecure,
 		}
+	}
+	return config, nil
+}
+
+// printExtractProgress 스키마 추출 진행 상황을 한 줄에 덮어쓰며 표시 (끝나면 줄바꿈)
+func printExtractProgress(p db.ExtractProgress) {
+	fmt.Printf("\r⏳ 스키마 추출 중 (%d/%d, 테이블 %d개) %-24s", p.Done, p.Total, p.Tables, p.Step)
+	if p.Done == p.Total {
+		fmt.Println()
+	}
+}
 
-		// JSON 또는 DDL 감지
-		if strings.HasSuffix(*schemaFile, ".json") {
-			return parser.ParseJSON(data)
+// extractOptions 명령줄 옵션으로 만든 스키마 추출 범위
+func extractOptions() db.ExtractOptions {
+	return db.ExtractOptions{
+		Schemas:        splitList(*extractSchemas),
+		ExcludeSchemas: splitList(*extractExcludeSchemas),
+		Tables:         splitList(*extractTables),
+		ExcludeTables:  splitList(*extractExcludeTables),
+	}
+}
+
+// splitList 쉼표로 구분한 목록 (빈 항목 제외)
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
-		return parser.ParseDDL(string(data), models.MySQL)
 	}
+	return items
+}
 
-	// 3. DDL 문자열
-	if *schemaDDL != "" {
-		return parser.ParseDDL(*schemaDDL, models.DBType(*dbType))
+// loadSchemaPath 파일 또는 디렉터리에서 스키마 로드
+func loadSchemaPath(path string) (*models.Schema, error) {
+	parser := schema.NewParser()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return parser.ParseDDLDir(path, ddlDialect())
 	}
 
-	return nil, nil
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON 또는 DDL 감지
+	if strings.HasSuffix(path, ".json") {
+		return parser.ParseJSON(data)
+	}
+	return parser.ParseDDL(string(data), ddlDialect())
+}
+
+// ddlDialect DDL 파싱에 사용할 문법 (-db 미지정 시 MySQL)
+func ddlDialect() models.DBType {
+	if *dbType == "" {
+		return models.MySQL
+	}
+	return models.DBType(*dbType)
 }
 
 func getPort() int {
@@ -180,13 +396,67 @@ func getPort() int {
 }
 
 func getAPIKey() string {
+	if *aiAPIKey != "" {
+		return *aiAPIKey
+	}
+	if models.AIProvider(*aiProvider) == models.OpenAICompatible {
+		return os.Getenv("OPENAI_API_KEY")
+	}
 	if *groqAPIKey != "" {
 		return *groqAPIKey
 	}
 	return os.Getenv("GROQ_API_KEY")
 }
 
-func runInteractive(ctx context.Context, gen *query.Generator) {
+// headerFlags -ai-header 반복 옵션 ("Name: value")
+type headerFlags map[string]string
+
+func (h headerFlags) String() string {
+	var parts []string
+	for k, v := range h {
+		parts = append(parts, k+": "+v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (h headerFlags) Set(value string) error {
+	name, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("헤더 형식은 \"Name: value\"입니다: %s", value)
+	}
+	h[strings.TrimSpace(name)] = strings.TrimSpace(val)
+	return nil
+}
+
+// paramFlags -dsn-param 반복 옵션 ("key=value")
+type paramFlags map[string]string
+
+func (p paramFlags) String() string {
+	var parts []string
+	for k, v := range p {
+		parts = append(parts, k+"="+v)
+	}
+	return strings.Join(parts, "&")
+}
+
+func (p paramFlags) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("파라미터 형식은 \"key=value\"입니다: %s", value)
+	}
+	p[strings.TrimSpace(key)] = val
+	return nil
+}
+
+// aiTemperatureValue -temperature 미지정(음수) 시 nil
+func aiTemperatureValue() *float64 {
+	if *aiTemperature < 0 {
+		return nil
+	}
+	return aiTemperature
+}
+
+func runInteractive(ctx context.Context, gen *query.Generator, connector db.Connector) {
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Println("🎯 대화형 모드 시작 (종료: exit 또는 quit)")
@@ -195,9 +465,17 @@ func runInteractive(ctx context.Context, gen *query.Generator) {
 	fmt.Println("   /optimize <쿼리> - 쿼리 최적화")
 	fmt.Println("   /explain <쿼리> - 쿼리 설명")
 	fmt.Println("   /schema - 스키마 정보 출력")
+	if connector != nil {
+		fmt.Printf("   /run [쿼리] - 쿼리 실행, 생략하면 마지막 생성 쿼리 (정책: %s)\n", connector.Policy())
+		fmt.Println("   /dryrun [쿼리] - DML을 실행해 영향을 받는 행을 확인하고 롤백")
+		if *outFile != "" {
+			fmt.Printf("   (/run 결과는 %s에 저장됩니다)\n", *outFile)
+		}
+	}
 	fmt.Println()
 
 	currentType := "SELECT"
+	var last *models.QueryResponse // /run, /dryrun에서 쿼리를 생략하면 실행할 마지막 생성 결과
 
 	for {
 		fmt.Printf("[%s] > ", currentType)
@@ -218,16 +496,29 @@ func runInteractive(ctx context.Context, gen *query.Generator) {
 		}
 
 		// 명령어 처리
+		if strings.HasPrefix(input, "/dryrun") {
+			sqlText, described := queryOrLast(strings.TrimPrefix(input, "/dryrun"), last)
+			dryRunQuery(ctx, connector, sqlText, described, reader)
+			continue
+		}
+		if strings.HasPrefix(input, "/run") {
+			sqlText, described := queryOrLast(strings.TrimPrefix(input, "/run"), last)
+			runQuery(ctx, connector, sqlText, described, reader)
+			continue
+		}
 		if strings.HasPrefix(input, "/") {
 			handleCommand(ctx, gen, input, &currentType)
 			continue
 		}
 
-		// 쿼리 생성
+		// 쿼리 생성 (AI 응답을 받는 대로 출력)
 		fmt.Println("🔄 쿼리 생성 중...")
 		start := time.Now()
 
-		resp, err := gen.Generate(ctx, input, currentType)
+		resp, err := gen.GenerateStream(ctx, input, currentType, func(token string) {
+			fmt.Print(token)
+		})
+		fmt.Println()
 		if err != nil {
 			fmt.Printf("❌ 오류: %v\n\n", err)
 			continue
@@ -254,6 +545,17 @@ func runInteractive(ctx context.Context, gen *query.Generator) {
 			fmt.Println()
 		}
 
+		if len(resp.Params) > 0 {
+			fmt.Println("🔣 파라미터 (/run 실행 시 입력):")
+			for _, param := range resp.Params {
+				fmt.Println("   " + describeParam(param))
+			}
+			fmt.Println()
+		}
+
+		printAttempts(resp)
+		last = resp
+
 		fmt.Printf("⏱️  생성 시간: %v (AI 처리: %dms)\n", elapsed, resp.ExecuteTime)
 		fmt.Println(strings.Repeat("─", 60))
 		fmt.Println()
@@ -321,7 +623,7 @@ func handleCommand(ctx context.Context, gen *query.Generator, cmd string, curren
 	fmt.Println()
 }
 
-func runSingle(ctx context.Context, gen *query.Generator) {
+func runSingle(ctx context.Context, gen *query.Generator, connector db.Connector) {
 	resp, err := gen.Generate(ctx, *promptText, *queryType)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ 오류: %v\n", err)
@@ -331,13 +633,440 @@ func runSingle(ctx context.Context, gen *query.Generator) {
 	// JSON 출력
 	output, _ := json.MarshalIndent(resp, "", "  ")
 	fmt.Println(string(output))
+
+	// -out: 생성된 쿼리를 파라미터 기본값으로 실행해 저장 (stdout은 JSON만 유지)
+	if *outFile != "" {
+		if connector == nil {
+			fmt.Fprintln(os.Stderr, "❌ -out은 데이터베이스 연결(-db)이 필요합니다")
+			os.Exit(1)
+		}
+		n, err := exportQuery(ctx, connector, resp.Query, defaultParams(resp.Params))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 결과 저장 실패: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "💾 %d행을 %s에 저장했습니다\n", n, *outFile)
+	}
+}
+
+// exportTimeout -out 저장 전체 시간 제한
+const exportTimeout = 10 * time.Minute
+
+// exportQuery 쿼리 결과를 -out 파일에 -format 형식으로 스트리밍 (행을 메모리에 모으지 않음)
+// 결과 집합이 없는 문장이면 db.ErrNoResultSet을 반환합니다.
+func exportQuery(ctx context.Context, connector db.Connector, sqlText string, params db.Params) (int, error) {
+	format, err := exportFormat()
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	cursor, err := connector.OpenCursor(ctx, sqlText, db.ExecOptions{Params: params})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	f, err := os.Create(*outFile)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	w, err := export.NewWriter(f, format, export.Options{DBType: connector.Type(), Table: *outTable})
+	if err != nil {
+		return 0, err
+	}
+	if err := w.WriteHeader(cursor.ColumnTypes()); err != nil {
+		return 0, err
+	}
+	for cursor.Next() {
+		if err := w.WriteRow(cursor.Row()); err != nil {
+			return cursor.Count(), err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return cursor.Count(), err
+	}
+	if err := w.Close(); err != nil {
+		return cursor.Count(), err
+	}
+	return cursor.Count(), f.Close()
+}
+
+// exportFormat -format, 없으면 -out 확장자, 그래도 모르면 CSV
+func exportFormat() (export.Format, error) {
+	if *outFormat != "" {
+		return export.ParseFormat(*outFormat)
+	}
+	if format, err := export.FormatFromPath(*outFile); err == nil {
+		return format, nil
+	}
+	return export.CSV, nil
+}
+
+// defaultParams 생성 결과의 파라미터 값(요청에 나온 값)으로 실행 파라미터 구성
+func defaultParams(described []models.QueryParam) db.Params {
+	var params db.Params
+	if len(described) == 0 {
+		return params
+	}
+	params.Named = make(map[string]interface{}, len(described))
+	for _, p := range described {
+		params.Named[p.Name] = paramValue(p.Value, p.Type)
+	}
+	return params
+}
+
+// runQuery 실행 정책에 따라 쿼리 실행 (confirm-dml 정책의 DML은 확인 후 실행)
+func runQuery(ctx context.Context, connector db.Connector, sqlText string, described []models.QueryParam, reader *bufio.Reader) {
+	if connector == nil {
+		fmt.Println("❌ 데이터베이스에 연결되어 있지 않습니다 (-db 옵션으로 연결)")
+		fmt.Println()
+		return
+	}
+	if sqlText == "" {
+		fmt.Println("❌ 사용법: /run <쿼리> (생략하면 마지막 생성 쿼리)")
+		fmt.Println()
+		return
+	}
+
+	params, err := readParams(connector.Type(), sqlText, described, reader)
+	if err != nil {
+		fmt.Printf("❌ %v\n\n", err)
+		return
+	}
+
+	// -out: 결과 집합이 있으면 화면 대신 파일로 저장
+	if *outFile != "" {
+		n, err := exportQuery(ctx, connector, sqlText, params)
+		var policyErr *db.PolicyError
+		switch {
+		case err == nil:
+			fmt.Printf("💾 %d행을 %s에 저장했습니다\n\n", n, *outFile)
+			return
+		case errors.Is(err, db.ErrNoResultSet), errors.As(err, &policyErr) && policyErr.NeedsConfirmation:
+			// 결과 집합이 없거나 확인이 필요한 문장은 아래에서 평소처럼 실행
+		default:
+			fmt.Printf("❌ 결과 저장 실패: %v\n\n", err)
+			return
+		}
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	limits := db.Limits{MaxRows: *maxRows}
+	result, err := connector.Execute(execCtx, sqlText, db.ExecOptions{Params: params, Limits: limits})
+	var policyErr *db.PolicyError
+	if errors.As(err, &policyErr) && policyErr.NeedsConfirmation {
+		// 확인 전에 영향을 받는 행을 미리 보여줌
+		if dry, dryErr := connector.DryRun(execCtx, sqlText, params); dryErr == nil {
+			printDryRun(dry)
+		}
+		fmt.Printf("⚠️  %s\n", policyErr.Error())
+		fmt.Print("   실행하시겠습니까? (y/N): ")
+		answer, _ := reader.ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("취소했습니다")
+			fmt.Println()
+			return
+		}
+		result, err = connector.Execute(execCtx, sqlText, db.ExecOptions{Confirmed: true, Params: params, Limits: limits})
+	}
+	if err != nil {
+		if errors.As(err, &policyErr) {
+			fmt.Printf("🔒 %v\n\n", err)
+			return
+		}
+		fmt.Printf("❌ 쿼리 실행 실패: %v\n\n", err)
+		return
+	}
+
+	printResult(result)
+}
+
+// queryOrLast 명령 인자가 없으면 마지막으로 생성한 쿼리와 파라미터 설명 사용
+func queryOrLast(arg string, last *models.QueryResponse) (string, []models.QueryParam) {
+	if sqlText := strings.TrimSpace(arg); sqlText != "" || last == nil {
+		return sqlText, nil
+	}
+	return last.Query, last.Params
+}
+
+// describeParam 파라미터 한 줄 설명 (:name (타입) - 설명 [기본값])
+func describeParam(param models.QueryParam) string {
+	line := ":" + param.Name
+	if param.Type != "" {
+		line += " (" + param.Type + ")"
+	}
+	if param.Description != "" {
+		line += " - " + param.Description
+	}
+	if param.Value != "" {
+		line += " [" + param.Value + "]"
+	}
+	return line
+}
+
+// readParams 쿼리의 바인드 파라미터 값을 입력받음 (빈 입력은 기본값, NULL은 NULL)
+func readParams(dbType models.DBType, sqlText string, described []models.QueryParam, reader *bufio.Reader) (db.Params, error) {
+	var params db.Params
+	placeholders, err := schema.NewParser().Placeholders(sqlText, dbType)
+	if err != nil || len(placeholders) == 0 {
+		return params, nil // 분석 오류는 실행 단계에서 보고
+	}
+
+	fmt.Println("🔣 파라미터 값을 입력하세요 (빈 값은 기본값, NULL 입력 시 NULL):")
+	if names := schema.ParamNames(placeholders); len(names) > 0 {
+		params.Named = make(map[string]interface{}, len(names))
+		for _, name := range names {
+			param := models.QueryParam{Name: name}
+			for _, d := range described {
+				if strings.EqualFold(d.Name, name) {
+					param = d
+					param.Name = name
+					break
+				}
+			}
+			input, err := promptLine(reader, "   "+describeParam(param)+": ")
+			if err != nil {
+				return params, err
+			}
+			if input == "" {
+				input = param.Value
+			}
+			params.Named[name] = paramValue(input, param.Type)
+		}
+		return params, nil
+	}
+
+	// 위치 파라미터: 가장 큰 번호까지 차례로 입력
+	count := 0
+	texts := make(map[int]string)
+	for _, ph := range placeholders {
+		count = max(count, ph.Index)
+		if _, ok := texts[ph.Index]; !ok {
+			texts[ph.Index] = ph.Text
+		}
+	}
+	for i := 1; i <= count; i++ {
+		label := texts[i]
+		if label == "" || label == "?" {
+			label = fmt.Sprintf("#%d", i)
+		}
+		input, err := promptLine(reader, "   "+label+": ")
+		if err != nil {
+			return params, err
+		}
+		params.Args = append(params.Args, paramValue(input, ""))
+	}
+	return params, nil
+}
+
+func promptLine(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Print(prompt)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("파라미터 입력 실패: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// paramValue 입력 문자열을 타입에 맞는 값으로 변환 (타입을 모르면 문자열)
+func paramValue(input, typ string) interface{} {
+	if strings.EqualFold(input, "NULL") {
+		return nil
+	}
+	t := strings.ToUpper(typ)
+	switch {
+	case strings.Contains(t, "INT"):
+		if n, err := strconv.ParseInt(input, 10, 64); err == nil {
+			return n
+		}
+	case strings.Contains(t, "DEC"), strings.Contains(t, "NUM"), strings.Contains(t, "FLOAT"),
+		strings.Contains(t, "REAL"), strings.Contains(t, "DOUBLE"):
+		if f, err := strconv.ParseFloat(input, 64); err == nil {
+			return f
+		}
+	case strings.Contains(t, "BOOL"):
+		if b, err := strconv.ParseBool(input); err == nil {
+			return b
+		}
+	}
+	return input
+}
+
+// dryRunQuery 트랜잭션 안에서 DML을 실행해 결과만 보여주고 롤백
+func dryRunQuery(ctx context.Context, connector db.Connector, sqlText string, described []models.QueryParam, reader *bufio.Reader) {
+	if connector == nil {
+		fmt.Println("❌ 데이터베이스에 연결되어 있지 않습니다 (-db 옵션으로 연결)")
+		fmt.Println()
+		return
+	}
+	if sqlText == "" {
+		fmt.Println("❌ 사용법: /dryrun <쿼리> (생략하면 마지막 생성 쿼리)")
+		fmt.Println()
+		return
+	}
+
+	params, err := readParams(connector.Type(), sqlText, described, reader)
+	if err != nil {
+		fmt.Printf("❌ %v\n\n", err)
+		return
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	result, err := connector.DryRun(execCtx, sqlText, params)
+	if err != nil {
+		var policyErr *db.PolicyError
+		if errors.As(err, &policyErr) {
+			fmt.Printf("🔒 %v\n\n", err)
+			return
+		}
+		fmt.Printf("❌ 미리 실행 실패: %v\n\n", err)
+		return
+	}
+
+	printDryRun(result)
+}
+
+// printDryRun 미리 실행 결과 출력 (영향 행 수, 변경 전/후 미리보기)
+func printDryRun(result *db.DryRunResult) {
+	target := result.Statement
+	if result.Table != "" {
+		target += " " + result.Table
+	}
+	if result.RowsAffected < 0 {
+		fmt.Printf("🧪 미리 실행: %s - 영향을 받는 행 수를 알 수 없음 (롤백됨, %dms)\n", target, result.Duration)
+	} else {
+		fmt.Printf("🧪 미리 실행: %s - %d행 영향 (롤백됨, %dms)\n", target, result.RowsAffected, result.Duration)
+	}
+
+	if result.Before != nil {
+		label := "변경 전"
+		if result.Statement == "INSERT" {
+			label = "삽입될 행"
+		}
+		fmt.Printf("\n📋 %s (최대 %d행):\n", label, result.PreviewLimit)
+		printResult(result.Before)
+	}
+	if result.After != nil {
+		fmt.Printf("📋 변경 후 (최대 %d행):\n", result.PreviewLimit)
+		printResult(result.After)
+	}
+	if result.PreviewError != "" {
+		fmt.Printf("⚠️  미리보기 조회 실패: %s\n", result.PreviewError)
+	}
+	fmt.Println()
+}
+
+// printResult 실행 결과를 표 형태로 출력 (결과 집합마다 최대 50행)
+func printResult(result *db.QueryResult) {
+	if len(result.Columns) == 0 {
+		summary := fmt.Sprintf("%d행 영향", result.RowsAffected)
+		if result.LastInsertID != nil {
+			summary += fmt.Sprintf(", 마지막 INSERT ID: %d", *result.LastInsertID)
+		}
+		fmt.Printf("✅ 실행 완료 (%s, %dms)\n\n", summary, result.Duration)
+		return
+	}
+
+	printRows(result)
+	fmt.Printf("\n✅ %d행%s (%dms)\n\n", len(result.Rows), truncatedNote(result), result.Duration)
+	for i, set := range result.More {
+		fmt.Printf("📄 결과 집합 %d\n", i+2)
+		printRows(set)
+		fmt.Printf("\n✅ %d행%s\n\n", len(set.Rows), truncatedNote(set))
+	}
+}
+
+// truncatedNote 행 수 제한으로 결과가 잘렸을 때 붙이는 안내
+func truncatedNote(result *db.QueryResult) string {
+	if !result.Truncated {
+		return ""
+	}
+	return " (이후 행 생략, -max-rows로 조정)"
+}
+
+func printRows(result *db.QueryResult) {
+	const maxRows = 50
+
+	fmt.Println(strings.Join(result.Columns, "\t"))
+	fmt.Println(strings.Repeat("─", 60))
+	for i, row := range result.Rows {
+		if i >= maxRows {
+			fmt.Printf("... (%d행 더 있음)\n", len(result.Rows)-maxRows)
+			break
+		}
+		values := make([]string, len(row))
+		for j, v := range row {
+			values[j] = formatValue(v)
+		}
+		fmt.Println(strings.Join(values, "\t"))
+	}
+}
+
+// formatValue 결과 값을 표에 표시할 문자열로 (바이너리는 16진수)
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// printAttempts 검증 결과와 재생성 기록 출력
+func printAttempts(resp *models.QueryResponse) {
+	if len(resp.Attempts) > 1 {
+		fmt.Printf("🔁 검증 실패로 %d번 다시 생성했습니다:\n", len(resp.Attempts)-1)
+		for i, attempt := range resp.Attempts[:len(resp.Attempts)-1] {
+			fmt.Printf("   시도 %d:\n", i+1)
+			printAttemptErrors(attempt)
+		}
+		fmt.Println()
+	}
+
+	if resp.Verified {
+		fmt.Println("✅ 검증 통과")
+	} else if len(resp.Attempts) > 0 {
+		fmt.Println("⚠️  검증 실패 (확인 후 사용하세요):")
+		printAttemptErrors(resp.Attempts[len(resp.Attempts)-1])
+	}
+	fmt.Println()
+}
+
+func printAttemptErrors(attempt models.QueryAttempt) {
+	for _, issue := range attempt.Issues {
+		line := "     - " + issue.Message
+		if issue.Suggestion != "" {
+			line += " (" + issue.Suggestion + ")"
+		}
+		fmt.Println(line)
+	}
+	if attempt.DBError != "" {
+		fmt.Println("     - DB: " + attempt.DBError)
+	}
 }
 
 func printSchema(s *models.Schema) {
 	fmt.Printf("\n📊 데이터베이스: %s (%s)\n", s.Database, s.DBType)
 	fmt.Println(strings.Repeat("─", 50))
 	for _, table := range s.Tables {
-		fmt.Printf("\n📋 테이블: %s\n", table.Name)
+		rows := ""
+		if table.Stats != nil && table.Stats.Rows >= 0 {
+			rows = fmt.Sprintf(" (약 %d행)", table.Stats.Rows)
+		}
+		fmt.Printf("\n📋 테이블: %s%s\n", table.QualifiedName(), rows)
 		for _, col := range table.Columns {
 			flags := ""
 			if col.IsPK {
@@ -353,8 +1082,23 @@ func printSchema(s *models.Schema) {
 			if !col.Nullable {
 				nullable = "NOT NULL"
 			}
+			if col.Domain != "" {
+				flags += " (도메인 " + col.Domain + ")"
+			}
+			if col.Collation != "" {
+				flags += " COLLATE " + col.Collation
+			}
+			if col.Generated != "" {
+				flags += " = " + col.Generated
+			}
+			if len(col.EnumValues) > 0 {
+				flags += " {" + strings.Join(col.EnumValues, ", ") + "}"
+			}
 			fmt.Printf("   ├─ %s %s %s%s\n", col.Name, col.Type, nullable, flags)
 		}
+		for _, c := range table.Checks {
+			fmt.Printf("   ├─ CHECK (%s)\n", c.Expression)
+		}
 		if len(table.Indexes) > 0 {
 			fmt.Println("   └─ 인덱스:")
 			for _, idx := range table.Indexes {
@@ -366,6 +1110,45 @@ func printSchema(s *models.Schema) {
 			}
 		}
 	}
+	for _, view := range s.Views {
+		kind := "뷰"
+		if view.Materialized {
+			kind = "구체화된 뷰"
+		}
+		fmt.Printf("\n🪟 %s: %s\n", kind, view.QualifiedName())
+		for _, col := range view.Columns {
+			fmt.Printf("   ├─ %s %s\n", col.Name, col.Type)
+		}
+	}
+	if len(s.Types) > 0 {
+		fmt.Println("\n🏷️  사용자 정의 타입:")
+		for _, t := range s.Types {
+			if t.Kind == models.TypeEnum {
+				fmt.Printf("   • %s ENUM {%s}\n", t.QualifiedName(), strings.Join(t.Values, ", "))
+			} else {
+				fmt.Printf("   • %s DOMAIN %s\n", t.QualifiedName(), t.BaseType)
+			}
+		}
+	}
+	if len(s.Sequences) > 0 {
+		fmt.Println("\n🔢 시퀀스:")
+		for _, seq := range s.Sequences {
+			fmt.Printf("   • %s\n", seq.QualifiedName())
+		}
+	}
+	if len(s.Routines) > 0 {
+		fmt.Println("\n⚙️  함수/프로시저:")
+		for _, r := range s.Routines {
+			fmt.Printf("   • %s %s\n", r.Kind, r.Signature())
+		}
+	}
+	if len(s.Triggers) > 0 {
+		fmt.Println("\n⚡ 트리거:")
+		for _, t := range s.Triggers {
+			fmt.Printf("   • %s: %s %s ON %s\n", models.QualifiedName(t.Schema, t.Name),
+				t.Timing, strings.Join(t.Events, " OR "), models.QualifiedName(t.Schema, t.Table))
+		}
+	}
 }
 
 func formatSQL(sql string) string {
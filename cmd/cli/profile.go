@@ -0,0 +1,248 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sql-genius/internal/profile"
+	"sql-genius/pkg/models"
+	"strconv"
+)
+
+// profileStore -profiles 또는 기본 경로의 프로필 저장소
+func profileStore() (*profile.Store, error) {
+	path := *profilesPath
+	if path == "" {
+		var err error
+		if path, err = profile.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	return profile.Open(path), nil
+}
+
+// setFlags 명령줄에서 지정한 옵션 이름
+func setFlags() map[string]bool {
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+// applyProfileOrExit -profile이 있으면 명령줄에서 지정하지 않은 연결·AI 옵션을 프로필 값으로 채움
+func applyProfileOrExit() {
+	if *profileName == "" {
+		return
+	}
+	if err := applyProfile(*profileName); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 프로필 로드 실패: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func applyProfile(name string) error {
+	store, err := profileStore()
+	if err != nil {
+		return err
+	}
+	p, err := store.Get(name)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{
+		"db":          string(p.DB.Type),
+		"host":        p.DB.Host,
+		"user":        p.DB.User,
+		"password":    p.DB.Password,
+		"database":    p.DB.Database,
+		"exec-policy": string(p.DB.Policy),
+	}
+	if p.DB.Port != 0 {
+		values["port"] = strconv.Itoa(p.DB.Port)
+	}
+	values["tls"] = string(p.DB.TLSMode)
+	values["ca-cert"] = p.DB.CACert
+	values["client-cert"] = p.DB.ClientCert
+	values["client-key"] = p.DB.ClientKey
+	values["app-name"] = p.DB.ApplicationName
+	values["service-name"] = p.DB.ServiceName
+	values["sid"] = p.DB.SID
+	for flagName, n := range map[string]int{
+		"connect-timeout": p.DB.ConnectTimeout,
+		"read-timeout":    p.DB.ReadTimeout,
+		"max-conns":       p.DB.MaxOpenConns,
+		"max-idle-conns":  p.DB.MaxIdleConns,
+	} {
+		if n != 0 {
+			values[flagName] = strconv.Itoa(n)
+		}
+	}
+	if ssh := p.DB.SSH; ssh != nil {
+		values["ssh-host"] = ssh.Host
+		values["ssh-user"] = ssh.User
+		values["ssh-password"] = ssh.Password
+		values["ssh-key"] = ssh.KeyFile
+		values["ssh-key-passphrase"] = ssh.KeyPassphrase
+		values["ssh-known-hosts"] = ssh.KnownHosts
+		if ssh.Port != 0 {
+			values["ssh-port"] = strconv.Itoa(ssh.Port)
+		}
+		if ssh.Agent {
+			values["ssh-agent"] = "true"
+		}
+		if ssh.InsecureIgnoreHostKey {
+			values["ssh-insecure"] = "true"
+		}
+	}
+	for key, value := range p.DB.Params {
+		if _, ok := dbParams[key]; !ok {
+			dbParams[key] = value
+		}
+	}
+	if p.AI != nil {
+		values["ai"] = string(p.AI.Provider)
+		values["model"] = p.AI.Model
+		values["endpoint"] = p.AI.Endpoint
+		values["api-key"] = p.AI.APIKey
+		if p.AI.Temperature != nil {
+			values["temperature"] = strconv.FormatFloat(*p.AI.Temperature, 'f', -1, 64)
+		}
+		if p.AI.MaxTokens != 0 {
+			values["max-tokens"] = strconv.Itoa(p.AI.MaxTokens)
+		}
+		for header, value := range p.AI.Headers {
+			if _, ok := aiHeaders[header]; !ok {
+				aiHeaders[header] = value
+			}
+		}
+	}
+
+	set := setFlags()
+	for flagName, value := range values {
+		if value == "" || set[flagName] {
+			continue
+		}
+		if err := flag.Set(flagName, value); err != nil {
+			return fmt.Errorf("프로필 %s의 %s 값이 잘못되었습니다: %w", name, flagName, err)
+		}
+	}
+	return nil
+}
+
+// runProfile 프로필 관리 서브커맨드
+//
+//	sql-genius profile list
+//	sql-genius profile save <이름> -db mysql -host ... [-ai ... -model ...]
+//	sql-genius profile delete <이름>
+func runProfile(args []string) {
+	usage := func() {
+		fmt.Fprintln(os.Stderr, "사용법: sql-genius profile list | save <이름> [연결·AI 옵션] | delete <이름>")
+		os.Exit(1)
+	}
+	if len(args) == 0 {
+		usage()
+	}
+
+	action, args := args[0], args[1:]
+	var name string
+	if action == "save" || action == "delete" {
+		if len(args) == 0 || args[0] == "" || args[0][0] == '-' {
+			usage()
+		}
+		name, args = args[0], args[1:]
+	}
+	flag.CommandLine.Parse(args)
+
+	store, err := profileStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	switch action {
+	case "list":
+		err = listProfiles(store)
+	case "save":
+		err = saveProfile(store, name)
+	case "delete":
+		err = store.Delete(name)
+		if err == nil {
+			fmt.Printf("🗑️  프로필 삭제됨: %s\n", name)
+		}
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func listProfiles(store *profile.Store) error {
+	profiles, err := store.List()
+	if err != nil {
+		return err
+	}
+	if len(profiles) == 0 {
+		fmt.Printf("저장된 프로필이 없습니다 (%s)\n", store.Path())
+		return nil
+	}
+
+	fmt.Printf("📁 %s\n", store.Path())
+	for _, p := range profiles {
+		target := p.DB.Database
+		if p.DB.Type != models.SQLite {
+			target = fmt.Sprintf("%s@%s:%d/%s", p.DB.User, p.DB.Host, p.DB.Port, p.DB.Database)
+		}
+		if p.DB.SSH != nil {
+			target += fmt.Sprintf(" via ssh %s@%s", p.DB.SSH.User, p.DB.SSH.Host)
+		}
+		policy := p.DB.Policy
+		if policy == "" {
+			policy = models.PolicyReadOnly
+		}
+		fmt.Printf("   - %s: %s %s (%s)", p.Name, p.DB.Type, target, policy)
+		if p.AI != nil {
+			fmt.Printf(" AI: %s %s", p.AI.Provider, p.AI.Model)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// saveProfile 명령줄의 연결 옵션으로 프로필 저장
+// AI 옵션은 명령줄에서 지정한 경우에만 함께 저장합니다.
+func saveProfile(store *profile.Store, name string) error {
+	if *dbType == "" {
+		return errors.New("-db 옵션이 필요합니다")
+	}
+	config, err := dbConfig()
+	if err != nil {
+		return err
+	}
+
+	p := profile.Profile{Name: name, DB: config}
+
+	set := setFlags()
+	for _, aiFlag := range []string{"ai", "model", "endpoint", "api-key", "groq-key", "temperature", "max-tokens", "ai-header"} {
+		if set[aiFlag] {
+			p.AI = &models.AIConfig{
+				Provider:    models.AIProvider(*aiProvider),
+				Model:       *aiModel,
+				Endpoint:    *aiEndpoint,
+				APIKey:      getAPIKey(),
+				Headers:     aiHeaders,
+				Temperature: aiTemperatureValue(),
+				MaxTokens:   *aiMaxTokens,
+			}
+			break
+		}
+	}
+
+	if err := store.Save(p); err != nil {
+		return err
+	}
+	fmt.Printf("✅ 프로필 저장됨: %s (%s)\n", name, store.Path())
+	return nil
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sql-genius/internal/db"
+	"sql-genius/internal/export"
+	"sql-genius/internal/schema"
+	"time"
+)
+
+const (
+	// defaultPageSize page_token만 있고 page_size가 없을 때의 페이지 크기
+	defaultPageSize = 100
+	// streamFlushRows NDJSON 스트리밍에서 이 행 수마다 flush
+	streamFlushRows = 100
+	// streamTimeout NDJSON 스트리밍 전체 시간 제한
+	streamTimeout = 10 * time.Minute
+
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// ExecutePage 페이지 단위 실행 결과
+type ExecutePage struct {
+	*db.QueryResult
+	Offset        int    `json:"offset"`                    // 이 페이지 첫 행의 위치
+	NextPageToken string `json:"next_page_token,omitempty"` // 다음 페이지 요청에 사용 (마지막 페이지면 생략)
+}
+
+// pageToken 다음 페이지 위치 (같은 쿼리·파라미터에만 사용 가능)
+type pageToken struct {
+	Offset   int    `json:"o"`
+	PageSize int    `json:"n"`
+	Hash     string `json:"h"`
+}
+
+// execOptions 요청의 실행 옵션 (결과 크기는 서버 제한 이하로)
+func (req *ExecuteRequest) execOptions() db.ExecOptions {
+	limits := db.Limits{MaxRows: *maxRows, MaxBytes: *maxBytes}
+	if req.MaxRows > 0 && (limits.MaxRows <= 0 || req.MaxRows < limits.MaxRows) {
+		limits.MaxRows = req.MaxRows
+	}
+	return db.ExecOptions{Confirmed: req.Confirm, Params: req.bindParams(), Limits: limits}
+}
+
+// queryHash 쿼리와 파라미터 값의 해시 (페이지 토큰을 다른 쿼리에 쓰지 못하도록)
+func (req *ExecuteRequest) queryHash() string {
+	h := sha256.New()
+	h.Write([]byte(req.Query))
+	params, _ := json.Marshal(req.Params)
+	h.Write(params)
+	args, _ := json.Marshal(req.Args)
+	h.Write(args)
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+func encodePageToken(t pageToken) string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(s string) (pageToken, error) {
+	var t pageToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return t, err
+	}
+	if t.Offset < 0 || t.PageSize < 0 {
+		return t, errors.New("잘못된 위치")
+	}
+	return t, nil
+}
+
+// pageExecute 조회 결과를 page_size행씩 반환
+// 서버에 커서를 남기지 않고 요청마다 쿼리에 행 범위(LIMIT/OFFSET 등)를 넣어 그 페이지만 읽으므로,
+// 페이지 사이에 데이터가 바뀌면 행이 빠지거나 겹칠 수 있습니다 (ORDER BY 권장).
+func (s *Server) pageExecute(ctx context.Context, w http.ResponseWriter, conn db.Connector, req *ExecuteRequest) {
+	c, err := s.parser.Classify(req.Query, conn.Type())
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// 다시 실행해도 안전한 조회만 허용
+	if c.Kind != schema.StatementRead {
+		s.jsonError(w, "페이지 단위 실행은 한 개의 조회 쿼리만 지원합니다", http.StatusBadRequest)
+		return
+	}
+
+	hash := req.queryHash()
+	page := pageToken{PageSize: req.PageSize, Hash: hash}
+	if req.PageToken != "" {
+		token, err := decodePageToken(req.PageToken)
+		if err != nil {
+			s.jsonError(w, "잘못된 page_token: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if token.Hash != hash {
+			s.jsonError(w, "page_token이 이 쿼리와 파라미터에 해당하지 않습니다", http.StatusBadRequest)
+			return
+		}
+		page.Offset = token.Offset
+		if page.PageSize <= 0 {
+			page.PageSize = token.PageSize
+		}
+	}
+	if page.PageSize <= 0 {
+		page.PageSize = defaultPageSize
+	}
+
+	opts := req.execOptions()
+	if opts.MaxRows <= 0 || page.PageSize < opts.MaxRows {
+		opts.MaxRows = page.PageSize
+	}
+
+	cursor, err := conn.OpenPage(ctx, req.Query, page.Offset, opts)
+	if err != nil {
+		s.executeError(w, err)
+		return
+	}
+	defer cursor.Close()
+
+	result := &db.QueryResult{Columns: cursor.Columns(), ColumnTypes: cursor.ColumnTypes()}
+	for cursor.Next() {
+		result.Rows = append(result.Rows, cursor.Row())
+	}
+	if err := cursor.Err(); err != nil {
+		s.jsonError(w, "쿼리 실행 실패: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result.Truncated = cursor.Truncated()
+	result.Duration = cursor.Duration()
+
+	resp := ExecutePage{QueryResult: result, Offset: page.Offset}
+	if result.Truncated {
+		resp.NextPageToken = encodePageToken(pageToken{
+			Offset:   page.Offset + len(result.Rows),
+			PageSize: page.PageSize,
+			Hash:     hash,
+		})
+	}
+	s.jsonResponse(w, resp)
+}
+
+// streamColumns NDJSON 스트림의 첫 줄
+type streamColumns struct {
+	Type        string          `json:"type"`
+	Columns     []string        `json:"columns"`
+	ColumnTypes []db.ColumnType `json:"column_types,omitempty"`
+}
+
+// streamRow NDJSON 스트림의 행 한 줄
+type streamRow struct {
+	Type string        `json:"type"`
+	Row  []interface{} `json:"row"`
+}
+
+// streamEnd NDJSON 스트림의 마지막 줄 (end 또는 error)
+type streamEnd struct {
+	Type         string `json:"type"`
+	Rows         int    `json:"rows"`
+	RowsAffected *int64 `json:"rows_affected,omitempty"`
+	LastInsertID *int64 `json:"last_insert_id,omitempty"`
+	Truncated    bool   `json:"truncated"`
+	Duration     int64  `json:"duration"`
+	Error        string `json:"error,omitempty"`
+}
+
+// streamExecute 결과를 NDJSON으로 한 줄씩 전송 (서버 메모리에 결과를 쌓지 않음)
+// 줄 형식: {"type":"columns"} → {"type":"row"}... → {"type":"end"} 또는 {"type":"error"}
+// 서버의 -max-rows/-max-bytes는 적용하지 않고 요청의 max_rows만 적용합니다.
+func (s *Server) streamExecute(w http.ResponseWriter, r *http.Request, conn db.Connector, req *ExecuteRequest) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.jsonError(w, "스트리밍을 지원하지 않습니다", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), streamTimeout)
+	defer cancel()
+
+	opts := db.ExecOptions{Confirmed: req.Confirm, Params: req.bindParams()}
+	opts.MaxRows = req.MaxRows
+
+	cursor, err := conn.OpenCursor(ctx, req.Query, opts)
+	if errors.Is(err, db.ErrNoResultSet) {
+		// 결과 집합이 없는 문장은 실행 결과만 전달
+		result, err := conn.Execute(ctx, req.Query, opts)
+		if err != nil {
+			s.executeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", ndjsonContentType)
+		json.NewEncoder(w).Encode(streamEnd{
+			Type:         "end",
+			RowsAffected: &result.RowsAffected,
+			LastInsertID: result.LastInsertID,
+			Duration:     result.Duration,
+		})
+		return
+	}
+	if err != nil {
+		s.executeError(w, err)
+		return
+	}
+	defer cursor.Close()
+
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	enc := json.NewEncoder(w)
+	enc.Encode(streamColumns{Type: "columns", Columns: cursor.Columns(), ColumnTypes: cursor.ColumnTypes()})
+	flusher.Flush()
+
+	for cursor.Next() {
+		if err := enc.Encode(streamRow{Type: "row", Row: cursor.Row()}); err != nil {
+			return // 클라이언트 연결 끊김
+		}
+		if cursor.Count()%streamFlushRows == 0 {
+			flusher.Flush()
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		enc.Encode(streamEnd{Type: "error", Rows: cursor.Count(), Duration: cursor.Duration(), Error: "쿼리 실행 실패: " + err.Error()})
+		flusher.Flush()
+		return
+	}
+
+	enc.Encode(streamEnd{
+		Type:      "end",
+		Rows:      cursor.Count(),
+		Truncated: cursor.Truncated(),
+		Duration:  cursor.Duration(),
+	})
+	flusher.Flush()
+}
+
+// handleExecuteExport 쿼리 결과를 ?format= 형식의 파일로 스트리밍 (csv, tsv, ndjson, markdown, xlsx, sql)
+// 행을 메모리에 모으지 않으므로 서버의 -max-rows/-max-bytes 대신 요청의 max_rows만 적용합니다.
+// ?table=은 sql 형식의 INSERT 대상 테이블 이름입니다.
+func (s *Server) handleExecuteExport(w http.ResponseWriter, r *http.Request, sess *Session) {
+	if r.Method != "POST" {
+		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
+		return
+	}
+
+	conn, _ := sess.State()
+	if conn == nil {
+		s.jsonError(w, "데이터베이스에 연결되어 있지 않습니다", http.StatusBadRequest)
+		return
+	}
+
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req ExecuteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "잘못된 요청", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), streamTimeout)
+	defer cancel()
+
+	opts := db.ExecOptions{Confirmed: req.Confirm, Params: req.bindParams()}
+	opts.MaxRows = req.MaxRows
+
+	cursor, err := conn.OpenCursor(ctx, req.Query, opts)
+	if errors.Is(err, db.ErrNoResultSet) {
+		s.jsonError(w, "결과 집합을 반환하는 쿼리만 내보낼 수 있습니다", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		s.executeError(w, err)
+		return
+	}
+	defer cursor.Close()
+
+	// 응답을 보내기 시작한 뒤의 오류는 트레일러로 전달
+	w.Header().Set("Trailer", "X-Export-Error")
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="result.%s"`, format.Extension()))
+
+	ew, err := export.NewWriter(w, format, export.Options{DBType: conn.Type(), Table: r.URL.Query().Get("table")})
+	if err == nil {
+		err = ew.WriteHeader(cursor.ColumnTypes())
+	}
+	for err == nil && cursor.Next() {
+		err = ew.WriteRow(cursor.Row())
+	}
+	if err == nil {
+		err = cursor.Err()
+	}
+	if err == nil {
+		err = ew.Close()
+	}
+	if err != nil {
+		log.Printf("결과 내보내기 실패: %v", err)
+		w.Header().Set("X-Export-Error", err.Error())
+	}
+}
@@ -4,17 +4,23 @@ import (
 	"context"
 	"embed"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io/fs"
 	"log"
+	"math"
 	"net/http"
 	"os"
 	"sql-genius/internal/ai"
+	"sql-genius/internal/cache"
 	"sql-genius/internal/db"
+	"sql-genius/internal/profile"
 	"sql-genius/internal/query"
 	"sql-genius/internal/schema"
 	"sql-genius/pkg/models"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -23,33 +29,94 @@ var staticFiles embed.FS
 
 var (
 	port       = flag.Int("port", 8080, "서버 포트")
-	aiProvider = flag.String("ai", "ollama", "AI 제공자 (ollama, groq)")
+	aiProvider = flag.String("ai", "ollama", "AI 제공자 (ollama, groq, openai-compatible)")
 	aiModel    = flag.String("model", "", "AI 모델 이름")
 	aiEndpoint = flag.String("endpoint", "", "AI 엔드포인트")
 	groqAPIKey = flag.String("groq-key", "", "Groq API 키")
+
+	aiAPIKey      = flag.String("api-key", "", "OpenAI 호환 API 키 (환경변수 OPENAI_API_KEY도 가능)")
+	aiTemperature = flag.Float64("temperature", -1, "AI temperature (미지정 시 기본값)")
+	aiMaxTokens   = flag.Int("max-tokens", 0, "AI 최대 생성 토큰 수 (미지정 시 기본값)")
+	aiHeaders     = headerFlags{}
+
+	verifyExplain = flag.Bool("explain", true, "DB 연결 시 생성된 쿼리를 EXPLAIN으로 검증 (쿼리는 실행하지 않음)")
+	maxRetries    = flag.Int("max-retries", query.DefaultMaxRetries, "검증 실패 시 오류를 전달해 다시 생성할 최대 횟수")
+
+	execPolicy = flag.String("exec-policy", "read-only", "/api/execute 최대 실행 정책 (read-only, confirm-dml, unrestricted)")
+	maxRows    = flag.Int("max-rows", 10000, "/api/execute 응답에 담을 결과 집합당 최대 행 수 (0이면 제한 없음, 스트리밍 제외)")
+	maxBytes   = flag.Int64("max-bytes", 32<<20, "/api/execute 응답에 담을 결과 집합당 최대 바이트 수 (0이면 제한 없음, 스트리밍 제외)")
+
+	sessionTTL = flag.Duration("session-ttl", 30*time.Minute, "요청이 없는 세션(연결 포함)을 닫기까지의 시간")
+	adminToken = flag.String("admin-token", "", "/api/sessions와 프로필 저장/삭제용 관리자 토큰 (환경변수 SQL_GENIUS_ADMIN_TOKEN도 가능, 비우면 둘 다 비활성화)")
+
+	profilesPath = flag.String("profiles", "", "연결 프로필 파일 경로 (기본: 사용자 설정 디렉터리의 sql-genius/profiles.json)")
+	schemaCache  = flag.String("schema-cache", "", "스키마 캐시 디렉터리 (기본: 사용자 캐시 디렉터리의 sql-genius/schema, off면 사용하지 않음)")
 )
 
+func init() {
+	flag.Var(aiHeaders, "ai-header", "AI 요청에 추가할 HTTP 헤더 \"Name: value\" (반복 가능)")
+}
+
+// Server 요청 사이에 공유하는 설정 (연결과 스키마는 세션별로 sessions에 보관)
 type Server struct {
-	provider   ai.Provider
-	generator  *query.Generator
-	parser     *schema.Parser
-	dbConn     db.Connector
-	schema     *models.Schema
+	provider  ai.Provider
+	parser    *schema.Parser
+	sessions  *SessionManager
+	profiles  *profile.Store
+	cache     *cache.Store      // 연결별 스키마 스냅숏 (nil이면 매번 전체 추출)
+	maxPolicy models.ExecPolicy // 연결 요청에서 지정할 수 있는 가장 느슨한 실행 정책
 }
 
 type GenerateRequest struct {
 	Prompt    string        `json:"prompt"`
 	QueryType string        `json:"query_type"`
 	Schema    models.Schema `json:"schema,omitempty"`
+
+	models.ModelOptions // model, temperature, max_tokens (생략하면 서버의 AI 설정)
 }
 
+// ConnectRequest DB 연결 요청
+// TLS, 타임아웃, 풀 크기 등 models.DBConfig의 모든 연결 설정을 받습니다.
+// policy는 실행 정책이며 생략하면 서버의 -exec-policy를 따릅니다.
 type ConnectRequest struct {
-	Type     string `json:"type"`
-	Host     string `json:"host"`
-	Port     int    `json:"port"`
-	User     string `json:"user"`
-	Password string `json:"password"`
-	Database string `json:"database"`
+	Profile string `json:"profile,omitempty"` // 저장된 프로필로 연결 (지정하면 나머지 연결 정보는 무시)
+	models.DBConfig
+	db.ExtractOptions // 스키마 추출 범위 (프로필로 연결할 때도 적용)
+}
+
+// ExecuteRequest 쿼리 실행 요청
+type ExecuteRequest struct {
+	Query   string                 `json:"query"`
+	Confirm bool                   `json:"confirm,omitempty"` // confirm-dml 정책에서 DML 실행 확인
+	Params  map[string]interface{} `json:"params,omitempty"`  // 이름 있는 파라미터 값 (:name)
+	Args    []interface{}          `json:"args,omitempty"`    // 위치 파라미터 값 (?, $1 순서)
+
+	MaxRows   int    `json:"max_rows,omitempty"`   // 서버 제한(-max-rows)보다 적게 받을 때 지정
+	PageSize  int    `json:"page_size,omitempty"`  // 페이지 단위로 받을 때 페이지 크기
+	PageToken string `json:"page_token,omitempty"` // 이전 응답의 next_page_token
+}
+
+// bindParams 요청의 파라미터 값을 db.Params로 변환 (JSON 정수는 int64로)
+func (req *ExecuteRequest) bindParams() db.Params {
+	var params db.Params
+	for _, v := range req.Args {
+		params.Args = append(params.Args, jsonParamValue(v))
+	}
+	if len(req.Params) > 0 {
+		params.Named = make(map[string]interface{}, len(req.Params))
+		for name, v := range req.Params {
+			params.Named[name] = jsonParamValue(v)
+		}
+	}
+	return params
+}
+
+// jsonParamValue JSON 숫자는 float64로 디코딩되므로 정수면 int64로 변환
+func jsonParamValue(v interface{}) interface{} {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return int64(f)
+	}
+	return v
 }
 
 type SchemaRequest struct {
@@ -58,6 +125,18 @@ type SchemaRequest struct {
 	DBType string `json:"db_type"`
 }
 
+// DiffRequest 스키마 비교 요청 (From 생략 시 현재 연결된 DB 또는 설정된 스키마와 비교)
+type DiffRequest struct {
+	From   *SchemaRequest `json:"from,omitempty"`
+	To     SchemaRequest  `json:"to"`
+	DBType string         `json:"db_type,omitempty"` // 마이그레이션 DDL 대상 DB
+}
+
+// SchemaRefreshRequest 스키마 다시 추출 요청
+type SchemaRefreshRequest struct {
+	Full bool `json:"full,omitempty"` // 캐시를 무시하고 전체를 다시 추출
+}
+
 type APIResponse struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
@@ -78,6 +157,10 @@ func main() {
 		Model:    *aiModel,
 		Endpoint: *aiEndpoint,
 		APIKey:   getAPIKey(),
+
+		Headers:     aiHeaders,
+		Temperature: aiTemperatureValue(),
+		MaxTokens:   *aiMaxTokens,
 	}
 
 	provider, err := ai.NewProvider(aiConfig)
@@ -94,28 +177,63 @@ func main() {
 		fmt.Printf("⚠️  AI 제공자 연결 대기 중: %s\n", provider.Name())
 	}
 
+	maxPolicy, err := db.ParsePolicy(*execPolicy)
+	if err != nil {
+		log.Fatalf("실행 정책 설정 실패: %v", err)
+	}
+	fmt.Printf("🔒 쿼리 실행 정책: %s\n", maxPolicy)
+
+	if *adminToken == "" {
+		*adminToken = os.Getenv("SQL_GENIUS_ADMIN_TOKEN")
+	}
+
+	if *profilesPath == "" {
+		if *profilesPath, err = profile.DefaultPath(); err != nil {
+			log.Fatalf("프로필 경로 설정 실패: %v", err)
+		}
+	}
+
 	server := &Server{
-		provider: provider,
-		parser:   schema.NewParser(),
+		provider:  provider,
+		parser:    schema.NewParser(),
+		sessions:  NewSessionManager(*sessionTTL),
+		profiles:  profile.Open(*profilesPath),
+		maxPolicy: maxPolicy,
+	}
+	if *schemaCache != "off" {
+		if *schemaCache == "" {
+			if *schemaCache, err = cache.DefaultDir(); err != nil {
+				log.Fatalf("스키마 캐시 경로 설정 실패: %v", err)
+			}
+		}
+		server.cache = cache.Open(*schemaCache)
+		fmt.Printf("🗂️  스키마 캐시: %s\n", *schemaCache)
 	}
+	go server.sessions.run(time.Minute)
 
 	// 라우터 설정
 	mux := http.NewServeMux()
 
 	// API 라우트
-	mux.HandleFunc("/api/generate", server.handleGenerate)
-	mux.HandleFunc("/api/optimize", server.handleOptimize)
-	mux.HandleFunc("/api/explain", server.handleExplain)
-	mux.HandleFunc("/api/validate", server.handleValidate)
-	mux.HandleFunc("/api/connect", server.handleConnect)
-	mux.HandleFunc("/api/disconnect", server.handleDisconnect)
-	mux.HandleFunc("/api/schema/parse", server.handleParseDDL)
-	mux.HandleFunc("/api/schema/current", server.handleGetSchema)
-	mux.HandleFunc("/api/schema/export", server.handleExportSchema)
-	mux.HandleFunc("/api/schema/table", server.handleTableDetail)
-	mux.HandleFunc("/api/schema/sample", server.handleSampleData)
-	mux.HandleFunc("/api/execute", server.handleExecute)
-	mux.HandleFunc("/api/status", server.handleStatus)
+	mux.HandleFunc("/api/generate", server.withSession(server.handleGenerate))
+	mux.HandleFunc("/api/generate/stream", server.withSession(server.handleGenerateStream))
+	mux.HandleFunc("/api/optimize", server.withSession(server.handleOptimize))
+	mux.HandleFunc("/api/explain", server.withSession(server.handleExplain))
+	mux.HandleFunc("/api/validate", server.withSession(server.handleValidate))
+	mux.HandleFunc("/api/connect", server.withSession(server.handleConnect))
+	mux.HandleFunc("/api/disconnect", server.withSession(server.handleDisconnect))
+	mux.HandleFunc("/api/schema/parse", server.withSession(server.handleParseDDL))
+	mux.HandleFunc("/api/schema/current", server.withSession(server.handleGetSchema))
+	mux.HandleFunc("/api/schema/export", server.withSession(server.handleExportSchema))
+	mux.HandleFunc("/api/schema/table", server.withSession(server.handleTableDetail))
+	mux.HandleFunc("/api/schema/sample", server.withSession(server.handleSampleData))
+	mux.HandleFunc("/api/schema/diff", server.withSession(server.handleSchemaDiff))
+	mux.HandleFunc("/api/schema/refresh", server.withSession(server.handleSchemaRefresh))
+	mux.HandleFunc("/api/execute", server.withSession(server.handleExecute))
+	mux.HandleFunc("/api/execute/export", server.withSession(server.handleExecuteExport))
+	mux.HandleFunc("/api/status", server.withSession(server.handleStatus))
+	mux.HandleFunc("/api/sessions", server.handleSessions)
+	mux.HandleFunc("/api/profiles", server.handleProfiles)
 
 	// 정적 파일 서빙
 	staticFS, _ := fs.Sub(staticFiles, "static")
@@ -129,17 +247,52 @@ func main() {
 }
 
 func getAPIKey() string {
+	if *aiAPIKey != "" {
+		return *aiAPIKey
+	}
+	if models.AIProvider(*aiProvider) == models.OpenAICompatible {
+		return os.Getenv("OPENAI_API_KEY")
+	}
 	if *groqAPIKey != "" {
 		return *groqAPIKey
 	}
 	return os.Getenv("GROQ_API_KEY")
 }
 
+// headerFlags -ai-header 반복 옵션 ("Name: value")
+type headerFlags map[string]string
+
+func (h headerFlags) String() string {
+	var parts []string
+	for k, v := range h {
+		parts = append(parts, k+": "+v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (h headerFlags) Set(value string) error {
+	name, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("헤더 형식은 \"Name: value\"입니다: %s", value)
+	}
+	h[strings.TrimSpace(name)] = strings.TrimSpace(val)
+	return nil
+}
+
+// aiTemperatureValue -temperature 미지정(음수) 시 nil
+func aiTemperatureValue() *float64 {
+	if *aiTemperature < 0 {
+		return nil
+	}
+	return aiTemperature
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+sessionHeader)
+		w.Header().Set("Access-Control-Expose-Headers", sessionHeader)
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -150,9 +303,16 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// jsonResponse 성공 응답 (인코딩할 수 없는 값이 있으면 잘린 200 응답 대신 로그를 남기고 500)
 func (s *Server) jsonResponse(w http.ResponseWriter, data interface{}) {
+	body, err := json.Marshal(APIResponse{Success: true, Data: data})
+	if err != nil {
+		log.Printf("응답 인코딩 실패: %v", err)
+		s.jsonError(w, "응답 인코딩 실패: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: data})
+	w.Write(append(body, '\n'))
 }
 
 func (s *Server) jsonError(w http.ResponseWriter, err string, status int) {
@@ -161,7 +321,7 @@ func (s *Server) jsonError(w http.ResponseWriter, err string, status int) {
 	json.NewEncoder(w).Encode(APIResponse{Success: false, Error: err})
 }
 
-func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request, sess *Session) {
 	if r.Method != "POST" {
 		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
 		return
@@ -174,17 +334,18 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 스키마 설정
-	var targetSchema *models.Schema
-	if len(req.Schema.Tables) > 0 {
-		targetSchema = &req.Schema
-	} else if s.schema != nil {
-		targetSchema = s.schema
-	} else {
+	targetSchema := s.requestSchema(&req, sess)
+	if targetSchema == nil {
 		s.jsonError(w, "스키마가 설정되지 않았습니다", http.StatusBadRequest)
 		return
 	}
 
-	gen := query.NewGenerator(s.provider, targetSchema)
+	if err := validateModelOptions(req.ModelOptions); err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	gen := s.queryGenerator(sess, targetSchema).WithOptions(req.ModelOptions)
 
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
 	defer cancel()
@@ -198,7 +359,105 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, resp)
 }
 
-func (s *Server) handleOptimize(w http.ResponseWriter, r *http.Request) {
+// handleGenerateStream Server-Sent Events로 생성 중인 텍스트를 전달
+// 이벤트: token {"text"} → done (QueryResponse) 또는 error {"error"}
+func (s *Server) handleGenerateStream(w http.ResponseWriter, r *http.Request, sess *Session) {
+	if r.Method != "POST" {
+		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req GenerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "잘못된 요청: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	targetSchema := s.requestSchema(&req, sess)
+	if targetSchema == nil {
+		s.jsonError(w, "스키마가 설정되지 않았습니다", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateModelOptions(req.ModelOptions); err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := w.(http.Flusher); !ok {
+		s.jsonError(w, "스트리밍을 지원하지 않습니다", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	gen := s.queryGenerator(sess, targetSchema).WithOptions(req.ModelOptions)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+	defer cancel()
+
+	resp, err := gen.GenerateStream(ctx, req.Prompt, req.QueryType, func(token string) {
+		s.sseEvent(w, "token", map[string]string{"text": token})
+	})
+	if err != nil {
+		s.sseEvent(w, "error", map[string]string{"error": "쿼리 생성 실패: " + err.Error()})
+		return
+	}
+
+	s.sseEvent(w, "done", resp)
+}
+
+// validateModelOptions 요청별 모델 설정 범위 확인
+func validateModelOptions(opts models.ModelOptions) error {
+	if opts.Temperature != nil && (*opts.Temperature < 0 || *opts.Temperature > 2) {
+		return fmt.Errorf("temperature는 0에서 2 사이여야 합니다")
+	}
+	if opts.MaxTokens < 0 {
+		return fmt.Errorf("max_tokens는 0 이상이어야 합니다")
+	}
+	return nil
+}
+
+// requestSchema 요청에 포함된 스키마, 없으면 세션에 설정된 스키마
+func (s *Server) requestSchema(req *GenerateRequest, sess *Session) *models.Schema {
+	if len(req.Schema.Tables) > 0 {
+		return &req.Schema
+	}
+	_, current := sess.State()
+	return current
+}
+
+// queryGenerator targetSchema로 생성할 생성기
+// 세션 스키마면 세션의 생성기(연결된 DB로 EXPLAIN 검증), 요청에 포함된 스키마면 검증 없이 새로 만듭니다.
+func (s *Server) queryGenerator(sess *Session, targetSchema *models.Schema) *query.Generator {
+	if gen := sess.Generator(); gen != nil && gen.GetSchema() == targetSchema {
+		return gen
+	}
+	return s.newGenerator(targetSchema, nil)
+}
+
+// newGenerator 생성 쿼리 검증 옵션을 적용한 생성기 (conn이 있으면 EXPLAIN 검증)
+func (s *Server) newGenerator(targetSchema *models.Schema, conn db.Connector) *query.Generator {
+	gen := query.NewGenerator(s.provider, targetSchema)
+	gen.SetMaxRetries(*maxRetries)
+	if conn != nil && *verifyExplain {
+		gen.SetConnector(conn)
+	}
+	return gen
+}
+
+// sseEvent Server-Sent Events 형식으로 이벤트 하나를 전송
+func (s *Server) sseEvent(w http.ResponseWriter, event string, data interface{}) {
+	payload, _ := json.Marshal(data)
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *Server) handleOptimize(w http.ResponseWriter, r *http.Request, sess *Session) {
 	if r.Method != "POST" {
 		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
 		return
@@ -212,13 +471,12 @@ func (s *Server) handleOptimize(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if s.schema == nil {
+	gen := sess.Generator()
+	if gen == nil {
 		s.jsonError(w, "스키마가 설정되지 않았습니다", http.StatusBadRequest)
 		return
 	}
 
-	gen := query.NewGenerator(s.provider, s.schema)
-
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
 	defer cancel()
 
@@ -231,7 +489,7 @@ func (s *Server) handleOptimize(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, resp)
 }
 
-func (s *Server) handleExplain(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleExplain(w http.ResponseWriter, r *http.Request, sess *Session) {
 	if r.Method != "POST" {
 		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
 		return
@@ -245,7 +503,10 @@ func (s *Server) handleExplain(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gen := query.NewGenerator(s.provider, s.schema)
+	gen := sess.Generator()
+	if gen == nil {
+		gen = query.NewGenerator(s.provider, nil)
+	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
 	defer cancel()
@@ -259,7 +520,7 @@ func (s *Server) handleExplain(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, map[string]string{"explanation": explanation})
 }
 
-func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request, sess *Session) {
 	if r.Method != "POST" {
 		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
 		return
@@ -271,15 +532,50 @@ func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	config := models.DBConfig{
-		Type:     models.DBType(req.Type),
-		Host:     req.Host,
-		Port:     req.Port,
-		User:     req.User,
-		Password: req.Password,
-		Database: req.Database,
+	config := req.DBConfig
+	if req.Profile != "" {
+		p, err := s.profiles.Get(req.Profile)
+		if err != nil {
+			s.profileError(w, err)
+			return
+		}
+		config = p.DB
+	} else if names := serverSideOptions(config); len(names) > 0 {
+		// 서버의 파일과 ssh-agent를 쓰거나 호스트 키 검증을 끄는 옵션은 프로필이나 관리자만 지정
+		if *adminToken == "" {
+			s.jsonError(w, fmt.Sprintf("%s 옵션은 저장된 프로필이나 관리자 인증 요청에서만 사용할 수 있습니다", strings.Join(names, ", ")), http.StatusForbidden)
+			return
+		}
+		if !s.requireAdmin(w, r) {
+			return
+		}
 	}
 
+	// 실행 정책: 서버 설정, 프로필 정책 순으로 상한이 좁아지고 요청은 그보다 엄격한 정책만 지정
+	policy := s.maxPolicy
+	requested := req.Policy
+	if req.Profile != "" {
+		if config.Policy != "" {
+			if !s.policyAllowed(w, config.Policy) {
+				return
+			}
+			policy = config.Policy
+		}
+	} else if requested == "" {
+		requested = config.Policy
+	}
+	if requested != "" {
+		if !s.policyAllowed(w, requested) {
+			return
+		}
+		if !db.PolicyWithin(requested, policy) {
+			s.jsonError(w, fmt.Sprintf("실행 정책 %s은(는) 프로필 %s의 정책(%s)보다 느슨해 사용할 수 없습니다", requested, req.Profile, policy), http.StatusForbidden)
+			return
+		}
+		policy = requested
+	}
+	config.Policy = policy
+
 	conn, err := db.NewConnector(config)
 	if err != nil {
 		s.jsonError(w, "커넥터 생성 실패: "+err.Error(), http.StatusBadRequest)
@@ -294,35 +590,147 @@ func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 스키마 추출
-	schema, err := conn.ExtractSchema(ctx)
+	// 스키마 추출 (캐시가 있으면 바뀐 테이블만)
+	schema, refresh, err := s.refreshSchema(ctx, conn, config, req.ExtractOptions, false)
 	if err != nil {
+		conn.Close()
 		s.jsonError(w, "스키마 추출 실패: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	s.dbConn = conn
-	s.schema = schema
-	s.generator = query.NewGenerator(s.provider, schema)
+	if err := s.sessions.attach(w, sess); err != nil {
+		conn.Close()
+		s.jsonError(w, "세션 생성 실패: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// 이전 연결은 교체한 뒤 닫음 (진행 중인 다른 요청은 이전 연결로 끝까지 실행)
+	if old := sess.set(conn, config, req.ExtractOptions, schema, s.newGenerator(schema, conn)); old != nil {
+		old.Close()
+	}
 
 	s.jsonResponse(w, map[string]interface{}{
 		"connected": true,
+		"policy":    policy,
 		"schema":    schema,
+		"refresh":   refresh,
 	})
 }
 
-func (s *Server) handleDisconnect(w http.ResponseWriter, r *http.Request) {
-	if s.dbConn != nil {
-		s.dbConn.Close()
-		s.dbConn = nil
+// serverSideOptions 서버의 파일이나 ssh-agent를 사용하거나 호스트 키 검증을 끄는 연결 옵션 이름
+// 드라이버 파라미터도 인증서 경로(sslrootcert 등)나 로컬 파일 읽기를 지정할 수 있어 포함하며,
+// SQLite의 database는 서버에서 열거나 새로 만들 파일이므로 포함합니다 (:memory: 제외).
+func serverSideOptions(config models.DBConfig) []string {
+	var names []string
+	if config.Type == models.SQLite && config.Database != "" && config.Database != ":memory:" {
+		names = append(names, "database")
+	}
+	if config.CACert != "" {
+		names = append(names, "ca_cert")
+	}
+	if config.ClientCert != "" {
+		names = append(names, "client_cert")
+	}
+	if config.ClientKey != "" {
+		names = append(names, "client_key")
+	}
+	if len(config.Params) > 0 {
+		names = append(names, "params")
+	}
+	if ssh := config.SSH; ssh != nil {
+		if ssh.KeyFile != "" {
+			names = append(names, "ssh.key_file")
+		}
+		if ssh.Agent {
+			names = append(names, "ssh.agent")
+		}
+		if ssh.KnownHosts != "" {
+			names = append(names, "ssh.known_hosts")
+		}
+		if ssh.InsecureIgnoreHostKey {
+			names = append(names, "ssh.insecure_ignore_host_key")
+		}
+	}
+	return names
+}
+
+// refreshSchema 캐시한 스냅숏에서 바뀐 테이블만 다시 추출하고 통계를 채운 뒤 캐시에 저장
+// 캐시가 없거나 읽을 수 없거나 full이면 전체를 추출합니다. 캐시 저장 실패는 로그만 남깁니다.
+func (s *Server) refreshSchema(ctx context.Context, conn db.Connector, config models.DBConfig, opts db.ExtractOptions, full bool) (*models.Schema, *db.RefreshResult, error) {
+	key := cache.Key(config, opts)
+	var previous *db.Snapshot
+	if s.cache != nil && !full {
+		cached, err := s.cache.Load(key)
+		if err != nil {
+			log.Printf("스키마 캐시 무시: %v", err)
+		}
+		previous = cached
+	}
+
+	snapshot, result, err := db.RefreshSnapshot(ctx, conn, previous, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 통계는 프롬프트 보조 정보이므로 실패해도 계속 (DDL 없이도 바뀌므로 항상 새로 조회)
+	if err := conn.ExtractStats(ctx, snapshot.Schema); err != nil {
+		log.Printf("통계 조회 실패: %v", err)
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Save(key, snapshot); err != nil {
+			log.Printf("스키마 캐시 저장 실패: %v", err)
+		}
+	}
+	return snapshot.Schema, result, nil
+}
+
+// handleSchemaRefresh 연결된 DB에서 바뀐 테이블만 다시 추출해 세션 스키마와 캐시를 갱신
+func (s *Server) handleSchemaRefresh(w http.ResponseWriter, r *http.Request, sess *Session) {
+	if r.Method != "POST" {
+		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req SchemaRefreshRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.jsonError(w, "잘못된 요청", http.StatusBadRequest)
+			return
+		}
+	}
+
+	conn, config, extract := sess.connection()
+	if conn == nil {
+		s.jsonError(w, "데이터베이스에 연결되어 있지 않습니다", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	schema, refresh, err := s.refreshSchema(ctx, conn, config, extract, req.Full)
+	if err != nil {
+		s.jsonError(w, "스키마 추출 실패: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// 추출하는 동안 다른 DB로 다시 연결했으면 그 연결의 스키마를 덮어쓰지 않음
+	if !sess.replaceSchema(conn, schema, s.newGenerator(schema, conn)) {
+		s.jsonError(w, "스키마를 추출하는 동안 연결이 바뀌었습니다", http.StatusConflict)
+		return
 	}
-	s.schema = nil
-	s.generator = nil
+
+	s.jsonResponse(w, map[string]interface{}{
+		"schema":  schema,
+		"refresh": refresh,
+	})
+}
+
+func (s *Server) handleDisconnect(w http.ResponseWriter, r *http.Request, sess *Session) {
+	sess.close()
 
 	s.jsonResponse(w, map[string]bool{"disconnected": true})
 }
 
-func (s *Server) handleParseDDL(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleParseDDL(w http.ResponseWriter, r *http.Request, sess *Session) {
 	if r.Method != "POST" {
 		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
 		return
@@ -334,89 +742,247 @@ func (s *Server) handleParseDDL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var parsedSchema *models.Schema
-	var err error
+	if req.DDL == "" && req.JSON == "" {
+		s.jsonError(w, "DDL 또는 JSON이 필요합니다", http.StatusBadRequest)
+		return
+	}
 
+	parsedSchema, err := s.parseSchemaRequest(req)
+	if err != nil {
+		s.jsonError(w, "파싱 실패: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.sessions.attach(w, sess); err != nil {
+		s.jsonError(w, "세션 생성 실패: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	conn, _ := sess.State()
+	sess.setSchema(parsedSchema, s.newGenerator(parsedSchema, conn))
+
+	s.jsonResponse(w, parsedSchema)
+}
+
+// policyAllowed 정책 이름이 올바르고 서버의 -exec-policy 이하인지 확인 (아니면 오류 응답 후 false)
+func (s *Server) policyAllowed(w http.ResponseWriter, policy models.ExecPolicy) bool {
+	requested, err := db.ParsePolicy(string(policy))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	if !db.PolicyWithin(requested, s.maxPolicy) {
+		s.jsonError(w, fmt.Sprintf("실행 정책 %s은(는) 서버 설정(%s)보다 느슨해 사용할 수 없습니다", requested, s.maxPolicy), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// parseSchemaRequest DDL 또는 JSON 입력을 스키마로 변환
+func (s *Server) parseSchemaRequest(req SchemaRequest) (*models.Schema, error) {
 	if req.DDL != "" {
 		dbType := models.MySQL
 		if req.DBType != "" {
 			dbType = models.DBType(req.DBType)
 		}
-		parsedSchema, err = s.parser.ParseDDL(req.DDL, dbType)
-	} else if req.JSON != "" {
-		parsedSchema, err = s.parser.ParseJSON([]byte(req.JSON))
-	} else {
-		s.jsonError(w, "DDL 또는 JSON이 필요합니다", http.StatusBadRequest)
+		return s.parser.ParseDDL(req.DDL, dbType)
+	}
+	if req.JSON != "" {
+		return s.parser.ParseJSON([]byte(req.JSON))
+	}
+	return nil, fmt.Errorf("DDL 또는 JSON이 필요합니다")
+}
+
+func (s *Server) handleSchemaDiff(w http.ResponseWriter, r *http.Request, sess *Session) {
+	if r.Method != "POST" {
+		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req DiffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "잘못된 요청", http.StatusBadRequest)
 		return
 	}
 
+	// 비교 기준: 요청의 from, 없으면 연결된 DB의 현재 스키마
+	conn, current := sess.State()
+	var from *models.Schema
+	switch {
+	case req.From != nil:
+		parsed, err := s.parseSchemaRequest(*req.From)
+		if err != nil {
+			s.jsonError(w, "기준 스키마 파싱 실패: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		from = parsed
+	case conn != nil:
+		ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+		defer cancel()
+
+		extracted, err := conn.ExtractSchema(ctx, sess.ExtractOptions())
+		if err != nil {
+			s.jsonError(w, "스키마 추출 실패: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		from = extracted
+	case current != nil:
+		from = current
+	default:
+		s.jsonError(w, "비교 기준 스키마가 없습니다. DB에 연결하거나 from을 지정하세요", http.StatusBadRequest)
+		return
+	}
+
+	// 대상 DDL 문법은 지정하지 않으면 기준 스키마를 따름
+	if req.To.DBType == "" {
+		req.To.DBType = string(from.DBType)
+	}
+	to, err := s.parseSchemaRequest(req.To)
 	if err != nil {
-		s.jsonError(w, "파싱 실패: "+err.Error(), http.StatusBadRequest)
+		s.jsonError(w, "대상 스키마 파싱 실패: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	s.schema = parsedSchema
-	s.generator = query.NewGenerator(s.provider, parsedSchema)
+	dbType := models.DBType(req.DBType)
+	if dbType == "" {
+		dbType = from.DBType
+	}
+	if dbType == "" {
+		dbType = to.DBType
+	}
 
-	s.jsonResponse(w, parsedSchema)
+	diff := s.parser.Diff(from, to)
+	migration, err := s.parser.GenerateMigration(diff, dbType)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{
+		"diff":      diff,
+		"identical": diff.IsEmpty(),
+		"migration": migration,
+	})
 }
 
-func (s *Server) handleGetSchema(w http.ResponseWriter, r *http.Request) {
-	if s.schema == nil {
+func (s *Server) handleGetSchema(w http.ResponseWriter, r *http.Request, sess *Session) {
+	_, current := sess.State()
+	if current == nil {
 		s.jsonError(w, "스키마가 설정되지 않았습니다", http.StatusNotFound)
 		return
 	}
-	s.jsonResponse(w, s.schema)
+	s.jsonResponse(w, current)
 }
 
-func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request, sess *Session) {
 	if r.Method != "POST" {
 		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
 		return
 	}
 
-	if s.dbConn == nil {
+	conn, _ := sess.State()
+	if conn == nil {
 		s.jsonError(w, "데이터베이스에 연결되어 있지 않습니다", http.StatusBadRequest)
 		return
 	}
 
-	var req struct {
-		Query string `json:"query"`
-	}
+	var req ExecuteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		s.jsonError(w, "잘못된 요청", http.StatusBadRequest)
 		return
 	}
 
+	// ?stream=ndjson 또는 Accept: application/x-ndjson: 행을 한 줄씩 전송
+	if r.URL.Query().Get("stream") == "ndjson" || strings.Contains(r.Header.Get("Accept"), ndjsonContentType) {
+		s.streamExecute(w, r, conn, &req)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	result, err := s.dbConn.ExecuteQuery(ctx, req.Query)
+	// ?dry_run=true: 트랜잭션 안에서 실행해 영향을 받는 행만 확인하고 롤백
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run")); dryRun {
+		result, err := conn.DryRun(ctx, req.Query, req.bindParams())
+		if err != nil {
+			var policyErr *db.PolicyError
+			if errors.As(err, &policyErr) {
+				s.policyError(w, policyErr)
+				return
+			}
+			s.jsonError(w, "미리 실행 실패: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s.jsonResponse(w, result)
+		return
+	}
+
+	if req.PageSize > 0 || req.PageToken != "" {
+		s.pageExecute(ctx, w, conn, &req)
+		return
+	}
+
+	result, err := conn.Execute(ctx, req.Query, req.execOptions())
 	if err != nil {
-		s.jsonError(w, "쿼리 실행 실패: "+err.Error(), http.StatusInternalServerError)
+		s.executeError(w, err)
 		return
 	}
 
 	s.jsonResponse(w, result)
 }
 
-func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+// executeError 쿼리 실행 오류 응답 (정책 차단은 403/409)
+func (s *Server) executeError(w http.ResponseWriter, err error) {
+	var policyErr *db.PolicyError
+	if errors.As(err, &policyErr) {
+		s.policyError(w, policyErr)
+		return
+	}
+	s.jsonError(w, "쿼리 실행 실패: "+err.Error(), http.StatusInternalServerError)
+}
+
+// policyError 실행 정책으로 차단된 요청 응답
+// 확인 후 실행할 수 있으면 409 (confirm: true로 다시 요청), 아니면 403
+func (s *Server) policyError(w http.ResponseWriter, err *db.PolicyError) {
+	status := http.StatusForbidden
+	if err.NeedsConfirmation {
+		status = http.StatusConflict
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(APIResponse{
+		Success: false,
+		Error:   err.Error(),
+		Data: map[string]interface{}{
+			"policy":                err.Policy,
+			"statement":             err.Kind,
+			"requires_confirmation": err.NeedsConfirmation,
+		},
+	})
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request, sess *Session) {
+	conn, current := sess.State()
 	status := map[string]interface{}{
 		"ai_provider":   s.provider.Name(),
 		"ai_available":  s.provider.IsAvailable(r.Context()),
-		"db_connected":  s.dbConn != nil,
-		"schema_loaded": s.schema != nil,
+		"db_connected":  conn != nil,
+		"schema_loaded": current != nil,
+	}
+
+	if conn != nil {
+		status["exec_policy"] = conn.Policy()
 	}
 
-	if s.schema != nil {
-		status["tables_count"] = len(s.schema.Tables)
-		status["db_type"] = s.schema.DBType
+	if current != nil {
+		status["tables_count"] = len(current.Tables)
+		status["db_type"] = current.DBType
 	}
 
 	s.jsonResponse(w, status)
 }
 
-func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request, sess *Session) {
 	if r.Method != "POST" {
 		s.jsonError(w, "POST 요청만 허용됩니다", http.StatusMethodNotAllowed)
 		return
@@ -435,7 +1001,8 @@ func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if s.schema == nil {
+	_, current := sess.State()
+	if current == nil {
 		s.jsonError(w, "스키마가 설정되지 않았습니다", http.StatusBadRequest)
 		return
 	}
@@ -443,7 +1010,7 @@ func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
 	defer cancel()
 
-	validation, err := s.provider.ValidateQuery(ctx, req.Query, s.schema)
+	validation, err := s.provider.ValidateQuery(ctx, req.Query, current)
 	if err != nil {
 		s.jsonError(w, "쿼리 검증 실패: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -452,13 +1019,14 @@ func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, validation)
 }
 
-func (s *Server) handleExportSchema(w http.ResponseWriter, r *http.Request) {
-	if s.schema == nil {
+func (s *Server) handleExportSchema(w http.ResponseWriter, r *http.Request, sess *Session) {
+	_, current := sess.State()
+	if current == nil {
 		s.jsonError(w, "스키마가 설정되지 않았습니다", http.StatusBadRequest)
 		return
 	}
 
-	schemaJSON, err := json.MarshalIndent(s.schema, "", "  ")
+	schemaJSON, err := json.MarshalIndent(current, "", "  ")
 	if err != nil {
 		s.jsonError(w, "스키마 변환 실패", http.StatusInternalServerError)
 		return
@@ -469,27 +1037,20 @@ func (s *Server) handleExportSchema(w http.ResponseWriter, r *http.Request) {
 	w.Write(schemaJSON)
 }
 
-func (s *Server) handleTableDetail(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleTableDetail(w http.ResponseWriter, r *http.Request, sess *Session) {
 	tableName := r.URL.Query().Get("table")
 	if tableName == "" {
 		s.jsonError(w, "테이블 이름이 필요합니다", http.StatusBadRequest)
 		return
 	}
 
-	if s.schema == nil {
+	_, current := sess.State()
+	if current == nil {
 		s.jsonError(w, "스키마가 설정되지 않았습니다", http.StatusBadRequest)
 		return
 	}
 
-	// 테이블 찾기
-	var targetTable *models.Table
-	for i := range s.schema.Tables {
-		if s.schema.Tables[i].Name == tableName {
-			targetTable = &s.schema.Tables[i]
-			break
-		}
-	}
-
+	targetTable := findTable(current, tableName)
 	if targetTable == nil {
 		s.jsonError(w, "테이블을 찾을 수 없습니다: "+tableName, http.StatusNotFound)
 		return
@@ -498,7 +1059,26 @@ func (s *Server) handleTableDetail(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, targetTable)
 }
 
-func (s *Server) handleSampleData(w http.ResponseWriter, r *http.Request) {
+// findTable 스키마에서 테이블 조회 (대소문자 무시)
+func findTable(current *models.Schema, name string) *models.Table {
+	if current == nil {
+		return nil
+	}
+	// schema.table로 먼저 찾고, 없으면 이름만으로
+	for i := range current.Tables {
+		if strings.EqualFold(current.Tables[i].QualifiedName(), name) {
+			return &current.Tables[i]
+		}
+	}
+	for i := range current.Tables {
+		if strings.EqualFold(current.Tables[i].Name, name) {
+			return &current.Tables[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleSampleData(w http.ResponseWriter, r *http.Request, sess *Session) {
 	tableName := r.URL.Query().Get("table")
 	limitStr := r.URL.Query().Get("limit")
 	if tableName == "" {
@@ -506,7 +1086,8 @@ func (s *Server) handleSampleData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if s.dbConn == nil {
+	conn, current := sess.State()
+	if conn == nil {
 		s.jsonError(w, "데이터베이스에 연결되어 있지 않습니다", http.StatusBadRequest)
 		return
 	}
@@ -517,35 +1098,51 @@ func (s *Server) handleSampleData(w http.ResponseWriter, r *http.Request) {
 		if limit > 100 {
 			limit = 100
 		}
+		if limit < 1 {
+			limit = 10
+		}
+	}
+
+	// 테이블 이름은 바인딩할 수 없으므로 스키마에 있는 이름만 인용해서 사용
+	table := findTable(current, tableName)
+	if table == nil {
+		s.jsonError(w, "테이블을 찾을 수 없습니다: "+tableName, http.StatusNotFound)
+		return
+	}
+	dbType := conn.Type()
+	quoted := db.QuoteIdent(dbType, table.Name)
+	if table.Schema != "" {
+		quoted = db.QuoteIdent(dbType, table.Schema) + "." + quoted
 	}
 
 	// DB 타입에 따른 쿼리 생성
 	var query string
-	switch s.dbConn.Type() {
-	case models.MySQL, models.PostgreSQL:
-		query = fmt.Sprintf("SELECT * FROM %s LIMIT %d", tableName, limit)
+	switch dbType {
 	case models.SQLServer:
-		query = fmt.Sprintf("SELECT TOP %d * FROM %s", limit, tableName)
+		query = fmt.Sprintf("SELECT TOP (:limit) * FROM %s", quoted)
 	case models.Oracle:
-		query = fmt.Sprintf("SELECT * FROM %s WHERE ROWNUM <= %d", tableName, limit)
+		query = fmt.Sprintf("SELECT * FROM %s WHERE ROWNUM <= :limit", quoted)
 	default:
-		query = fmt.Sprintf("SELECT * FROM %s LIMIT %d", tableName, limit)
+		query = fmt.Sprintf("SELECT * FROM %s LIMIT :limit", quoted)
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	result, err := s.dbConn.ExecuteQuery(ctx, query)
+	result, err := conn.Execute(ctx, query, db.ExecOptions{
+		Params: db.Params{Named: map[string]interface{}{"limit": limit}},
+	})
 	if err != nil {
 		s.jsonError(w, "샘플 데이터 조회 실패: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	s.jsonResponse(w, map[string]interface{}{
-		"table":   tableName,
-		"columns": result.Columns,
-		"rows":    result.Rows,
-		"count":   len(result.Rows),
+		"table":        table.Name,
+		"columns":      result.Columns,
+		"column_types": result.ColumnTypes,
+		"rows":         result.Rows,
+		"count":        len(result.Rows),
 	})
 }
 
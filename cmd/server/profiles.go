@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sql-genius/internal/profile"
+	"sql-genius/pkg/models"
+)
+
+// ProfileView 응답용 프로필 (비밀번호와 API 키는 저장 여부만)
+type ProfileView struct {
+	Name        string           `json:"name"`
+	DB          models.DBConfig  `json:"db"`
+	AI          *models.AIConfig `json:"ai,omitempty"`
+	HasPassword bool             `json:"has_password"`
+	HasAPIKey   bool             `json:"has_api_key,omitempty"`
+
+	HasSSHPassword      bool `json:"has_ssh_password,omitempty"`
+	HasSSHKeyPassphrase bool `json:"has_ssh_key_passphrase,omitempty"`
+}
+
+func profileView(p profile.Profile) ProfileView {
+	view := ProfileView{Name: p.Name, DB: p.DB, HasPassword: p.DB.Password != ""}
+	view.DB.Password = ""
+	if p.DB.SSH != nil {
+		ssh := *p.DB.SSH
+		view.HasSSHPassword = ssh.Password != ""
+		view.HasSSHKeyPassphrase = ssh.KeyPassphrase != ""
+		ssh.Password, ssh.KeyPassphrase = "", ""
+		view.DB.SSH = &ssh
+	}
+	if p.AI != nil {
+		ai := *p.AI
+		view.HasAPIKey = ai.APIKey != ""
+		ai.APIKey = ""
+		view.AI = &ai
+	}
+	return view
+}
+
+// handleProfiles 연결 프로필 목록(GET), 저장(POST), 삭제(DELETE ?name=)
+// 저장과 삭제에는 관리자 인증이 필요하며 -admin-token이 없으면 목록 조회만 허용합니다.
+// 서버의 AI 설정은 서버 옵션을 따르므로 프로필의 ai는 저장만 하고 연결에는 사용하지 않습니다.
+func (s *Server) handleProfiles(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		profiles, err := s.profiles.List()
+		if err != nil {
+			s.jsonError(w, "프로필 조회 실패: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		views := make([]ProfileView, len(profiles))
+		for i, p := range profiles {
+			views[i] = profileView(p)
+		}
+		s.jsonResponse(w, views)
+		return
+	case http.MethodPost, http.MethodDelete:
+	default:
+		s.jsonError(w, "GET, POST, DELETE 요청만 허용됩니다", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 프로필에는 비밀번호가 저장되므로 관리자 토큰이 없으면 저장과 삭제를 허용하지 않음
+	if *adminToken == "" {
+		s.jsonError(w, "관리자 토큰(-admin-token)이 설정되지 않아 프로필을 저장하거나 삭제할 수 없습니다", http.StatusForbidden)
+		return
+	}
+	if !s.requireAdmin(w, r) {
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		name := r.URL.Query().Get("name")
+		if err := s.profiles.Delete(name); err != nil {
+			s.profileError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]interface{}{"deleted": name})
+		return
+	}
+
+	var p profile.Profile
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		s.jsonError(w, "잘못된 요청", http.StatusBadRequest)
+		return
+	}
+
+	// 비밀번호·SSH 비밀번호·API 키를 비워 보내면 기존 값 유지 (목록 응답에는 값이 없으므로)
+	// 저장된 비밀 정보가 다른 서버로 보내지지 않도록 연결 대상이 그대로일 때만 유지합니다.
+	if existing, err := s.profiles.Get(p.Name); err == nil {
+		if sameDBTarget(p.DB, existing.DB) {
+			if p.DB.Password == "" {
+				p.DB.Password = existing.DB.Password
+			}
+			if p.DB.SSH != nil && existing.DB.SSH != nil {
+				if p.DB.SSH.Password == "" {
+					p.DB.SSH.Password = existing.DB.SSH.Password
+				}
+				if p.DB.SSH.KeyPassphrase == "" && p.DB.SSH.KeyFile == existing.DB.SSH.KeyFile {
+					p.DB.SSH.KeyPassphrase = existing.DB.SSH.KeyPassphrase
+				}
+			}
+		}
+		if p.AI != nil && p.AI.APIKey == "" && existing.AI != nil &&
+			p.AI.Provider == existing.AI.Provider && p.AI.Endpoint == existing.AI.Endpoint {
+			p.AI.APIKey = existing.AI.APIKey
+		}
+	} else if !errors.Is(err, profile.ErrNotFound) {
+		s.profileError(w, err)
+		return
+	}
+
+	if p.DB.Policy != "" && !s.policyAllowed(w, p.DB.Policy) {
+		return
+	}
+	if err := s.profiles.Save(p); err != nil {
+		s.profileError(w, err)
+		return
+	}
+	s.jsonResponse(w, profileView(p))
+}
+
+// sameDBTarget 두 연결 설정이 같은 DB 계정과 SSH 계정을 가리키는지 (저장된 비밀번호를 유지해도 되는지)
+func sameDBTarget(a, b models.DBConfig) bool {
+	if a.Type != b.Type || a.Host != b.Host || a.Port != b.Port || a.User != b.User ||
+		a.Database != b.Database || a.ServiceName != b.ServiceName || a.SID != b.SID {
+		return false
+	}
+	if a.SSH == nil || b.SSH == nil {
+		return a.SSH == nil && b.SSH == nil
+	}
+	return a.SSH.Host == b.SSH.Host && a.SSH.Port == b.SSH.Port && a.SSH.User == b.SSH.User
+}
+
+func (s *Server) profileError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, profile.ErrNotFound) {
+		status = http.StatusNotFound
+	}
+	s.jsonError(w, err.Error(), status)
+}
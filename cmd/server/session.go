@@ -0,0 +1,304 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"sql-genius/internal/db"
+	"sql-genius/internal/query"
+	"sql-genius/pkg/models"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// sessionCookie 브라우저 세션 쿠키 이름
+	sessionCookie = "sql_genius_session"
+	// sessionHeader API 클라이언트용 세션 토큰 헤더 (응답에도 같은 헤더로 발급)
+	sessionHeader = "X-Session-Token"
+)
+
+// Session 브라우저(쿠키)나 API 클라이언트(토큰)마다 따로 갖는 연결 상태
+// 연결, 스키마, 생성기는 mu로 보호되며 State로 한 번에 읽습니다.
+type Session struct {
+	id      string
+	created time.Time
+	remote  string
+
+	mu        sync.RWMutex
+	conn      db.Connector
+	config    models.DBConfig
+	extract   db.ExtractOptions // 연결 시 지정한 스키마 추출 범위 (다시 추출할 때 사용)
+	schema    *models.Schema
+	generator *query.Generator
+
+	lastSeen atomic.Int64 // unix nano
+	active   atomic.Int32 // 처리 중인 요청 수 (0일 때만 만료)
+}
+
+// State 현재 연결과 스키마 (연결하지 않았으면 nil)
+func (sess *Session) State() (db.Connector, *models.Schema) {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+	return sess.conn, sess.schema
+}
+
+// Generator 세션 스키마로 만든 쿼리 생성기 (스키마가 없으면 nil)
+func (sess *Session) Generator() *query.Generator {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+	return sess.generator
+}
+
+// ExtractOptions 연결 시 지정한 스키마 추출 범위
+func (sess *Session) ExtractOptions() db.ExtractOptions {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+	return sess.extract
+}
+
+// connection 현재 연결과 연결 설정, 추출 범위 (스키마를 다시 추출할 때 사용)
+func (sess *Session) connection() (db.Connector, models.DBConfig, db.ExtractOptions) {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+	return sess.conn, sess.config, sess.extract
+}
+
+// set 연결·스키마·생성기를 바꾸고 이전 연결을 반환 (호출자가 닫음)
+func (sess *Session) set(conn db.Connector, config models.DBConfig, extract db.ExtractOptions, schema *models.Schema, gen *query.Generator) db.Connector {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	old := sess.conn
+	sess.conn, sess.config, sess.extract, sess.schema, sess.generator = conn, config, extract, schema, gen
+	return old
+}
+
+// setSchema 연결은 그대로 두고 스키마만 바꿈 (DDL/JSON 파싱)
+func (sess *Session) setSchema(schema *models.Schema, gen *query.Generator) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.schema, sess.generator = schema, gen
+}
+
+// replaceSchema 연결이 conn 그대로일 때만 스키마를 바꿈 (바꿨으면 true)
+func (sess *Session) replaceSchema(conn db.Connector, schema *models.Schema, gen *query.Generator) bool {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.conn != conn {
+		return false
+	}
+	sess.schema, sess.generator = schema, gen
+	return true
+}
+
+// close 연결을 닫고 상태 초기화
+func (sess *Session) close() {
+	if old := sess.set(nil, models.DBConfig{}, db.ExtractOptions{}, nil, nil); old != nil {
+		old.Close()
+	}
+}
+
+func (sess *Session) touch() {
+	sess.lastSeen.Store(time.Now().UnixNano())
+}
+
+// SessionInfo 관리자용 세션 요약 (토큰 전체와 비밀번호는 포함하지 않음)
+type SessionInfo struct {
+	ID       string            `json:"id"` // 토큰 앞 8자리
+	Remote   string            `json:"remote"`
+	Created  time.Time         `json:"created"`
+	LastSeen time.Time         `json:"last_seen"`
+	Active   int32             `json:"active_requests"`
+	DBType   models.DBType     `json:"db_type,omitempty"`
+	Host     string            `json:"host,omitempty"`
+	Database string            `json:"database,omitempty"`
+	Policy   models.ExecPolicy `json:"policy,omitempty"`
+	Tables   int               `json:"tables"`
+}
+
+func (sess *Session) info() SessionInfo {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+
+	info := SessionInfo{
+		ID:       sess.id[:8],
+		Remote:   sess.remote,
+		Created:  sess.created,
+		LastSeen: time.Unix(0, sess.lastSeen.Load()),
+		Active:   sess.active.Load(),
+	}
+	if sess.conn != nil {
+		info.DBType = sess.config.Type
+		info.Host = sess.config.Host
+		info.Database = sess.config.Database
+		info.Policy = sess.conn.Policy()
+	}
+	if sess.schema != nil {
+		info.Tables = len(sess.schema.Tables)
+		if info.DBType == "" {
+			info.DBType = sess.schema.DBType
+		}
+	}
+	return info
+}
+
+// SessionManager 토큰별 세션 보관과 유휴 세션 만료
+type SessionManager struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+	ttl      time.Duration
+}
+
+// NewSessionManager ttl 동안 요청이 없는 세션을 만료시키는 관리자 생성
+func NewSessionManager(ttl time.Duration) *SessionManager {
+	return &SessionManager{sessions: make(map[string]*Session), ttl: ttl}
+}
+
+// acquire 요청의 토큰(헤더 우선, 없으면 쿠키)에 해당하는 세션을 사용 중으로 표시
+// 세션이 없으면 아직 등록하지 않은 빈 세션을 반환합니다. 빈 세션은 연결이나 스키마를
+// 저장할 때 attach로 등록되므로 조회만 하는 요청은 세션을 만들지 않습니다.
+func (m *SessionManager) acquire(r *http.Request) *Session {
+	token := r.Header.Get(sessionHeader)
+	if token == "" {
+		if cookie, err := r.Cookie(sessionCookie); err == nil {
+			token = cookie.Value
+		}
+	}
+
+	// 만료 검사와 겹치지 않도록 잠금 안에서 사용 중 표시
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sess, ok := m.sessions[token]
+	if !ok || token == "" {
+		sess = &Session{remote: r.RemoteAddr}
+	}
+	sess.active.Add(1)
+	sess.touch()
+	return sess
+}
+
+// release acquire로 표시한 사용 중 해제
+func (m *SessionManager) release(sess *Session) {
+	sess.touch()
+	sess.active.Add(-1)
+}
+
+// attach 빈 세션이면 토큰을 발급해 등록하고 쿠키와 헤더로 알려줌
+func (m *SessionManager) attach(w http.ResponseWriter, sess *Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sess.id != "" {
+		return nil
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	sess.id = hex.EncodeToString(buf)
+	sess.created = time.Now()
+	sess.touch()
+	m.sessions[sess.id] = sess
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    sess.id,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.Header().Set(sessionHeader, sess.id)
+	return nil
+}
+
+// expire ttl 동안 요청이 없고 처리 중인 요청도 없는 세션을 닫고 제거
+func (m *SessionManager) expire(now time.Time) int {
+	m.mu.Lock()
+	var expired []*Session
+	for id, sess := range m.sessions {
+		idle := now.Sub(time.Unix(0, sess.lastSeen.Load()))
+		if idle > m.ttl && sess.active.Load() == 0 {
+			expired = append(expired, sess)
+			delete(m.sessions, id)
+		}
+	}
+	m.mu.Unlock()
+
+	// 연결 종료는 잠금 밖에서
+	for _, sess := range expired {
+		sess.close()
+	}
+	return len(expired)
+}
+
+// run interval마다 유휴 세션 만료 (서버가 끝날 때까지)
+func (m *SessionManager) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		m.expire(now)
+	}
+}
+
+// list 최근 사용 순 세션 목록
+func (m *SessionManager) list() []SessionInfo {
+	m.mu.Lock()
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, sess := range m.sessions {
+		sessions = append(sessions, sess)
+	}
+	m.mu.Unlock()
+
+	infos := make([]SessionInfo, len(sessions))
+	for i, sess := range sessions {
+		infos[i] = sess.info()
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].LastSeen.After(infos[j].LastSeen) })
+	return infos
+}
+
+// sessionHandler 세션을 받는 핸들러
+type sessionHandler func(w http.ResponseWriter, r *http.Request, sess *Session)
+
+// withSession 요청의 세션을 찾아 핸들러에 전달하고 처리 중에는 만료되지 않도록 표시
+func (s *Server) withSession(h sessionHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sess := s.sessions.acquire(r)
+		defer s.sessions.release(sess)
+
+		h(w, r, sess)
+	}
+}
+
+// handleSessions 관리자용 세션 목록 (Authorization: Bearer <-admin-token>)
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if *adminToken == "" {
+		s.jsonError(w, "관리자 토큰(-admin-token)이 설정되지 않았습니다", http.StatusForbidden)
+		return
+	}
+	if !s.requireAdmin(w, r) {
+		return
+	}
+
+	sessions := s.sessions.list()
+	s.jsonResponse(w, map[string]interface{}{
+		"sessions": sessions,
+		"count":    len(sessions),
+		"ttl":      s.sessions.ttl.String(),
+	})
+}
+
+// requireAdmin Authorization: Bearer <-admin-token> 확인 (실패하면 401 응답 후 false)
+func (s *Server) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(*adminToken)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		s.jsonError(w, "관리자 인증이 필요합니다", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
@@ -6,6 +6,7 @@ const API_BASE = '';
 let currentQueryType = 'SELECT';
 let currentSchema = null;
 let isConnected = false;
+let savedProfiles = [];
 
 // DOM Elements
 const elements = {
@@ -31,10 +32,15 @@ const elements = {
     schemaTabs: document.querySelectorAll('.schema-tab'),
     connectionForm: document.getElementById('connectionForm'),
     disconnectBtn: document.getElementById('disconnectBtn'),
+    refreshSchemaBtn: document.getElementById('refreshSchemaBtn'),
     connectionStatus: document.getElementById('connectionStatus'),
     status: document.getElementById('status'),
     dbType: document.getElementById('dbType'),
     dbPort: document.getElementById('dbPort'),
+    dbProfile: document.getElementById('dbProfile'),
+    profileName: document.getElementById('profileName'),
+    saveProfileBtn: document.getElementById('saveProfileBtn'),
+    deleteProfileBtn: document.getElementById('deleteProfileBtn'),
 };
 
 // Initialize
@@ -106,7 +112,7 @@ async function generateQuery() {
     elements.generateBtn.disabled = true;
     
     try {
-        const response = await fetch(`${API_BASE}/api/generate`, {
+        const response = await fetch(`${API_BASE}/api/generate/stream`, {
             method: 'POST',
             headers: { 'Content-Type': 'application/json' },
             body: JSON.stringify({
@@ -116,13 +122,24 @@ async function generateQuery() {
             })
         });
         
-        const result = await response.json();
-        
-        if (result.success) {
-            showQueryResult(elements.resultSection, result.data);
-        } else {
+        // 스트림 시작 전 오류는 일반 JSON 응답
+        if (!response.ok || !response.body) {
+            const result = await response.json();
             showError(elements.resultSection, result.error);
+            return;
         }
+        
+        let streamed = '';
+        await readEventStream(response, (event, data) => {
+            if (event === 'token') {
+                streamed += data.text;
+                showStreamingText(elements.resultSection, streamed);
+            } else if (event === 'done') {
+                showQueryResult(elements.resultSection, data);
+            } else if (event === 'error') {
+                showError(elements.resultSection, data.error);
+            }
+        });
     } catch (error) {
         showError(elements.resultSection, '서버 연결 실패: ' + error.message);
     } finally {
@@ -130,6 +147,39 @@ async function generateQuery() {
     }
 }
 
+// Server-Sent Events 응답을 읽어 이벤트마다 콜백 호출
+async function readEventStream(response, onEvent) {
+    const reader = response.body.getReader();
+    const decoder = new TextDecoder();
+    let buffer = '';
+    
+    while (true) {
+        const { value, done } = await reader.read();
+        if (done) break;
+        
+        buffer += decoder.decode(value, { stream: true });
+        
+        let boundary;
+        while ((boundary = buffer.indexOf('\n\n')) !== -1) {
+            const block = buffer.slice(0, boundary);
+            buffer = buffer.slice(boundary + 2);
+            
+            let event = 'message';
+            let data = '';
+            for (const line of block.split('\n')) {
+                if (line.startsWith('event:')) {
+                    event = line.slice(6).trim();
+                } else if (line.startsWith('data:')) {
+                    data += line.slice(5).trim();
+                }
+            }
+            if (data) {
+                onEvent(event, JSON.parse(data));
+            }
+        }
+    }
+}
+
 // Validate Button
 function initValidateButton() {
     elements.validateBtn.addEventListener('click', validateQuery);
@@ -474,18 +524,20 @@ function renderSchema(schema) {
         const columnCount = table.columns ? table.columns.length : 0;
         const indexCount = table.indexes ? table.indexes.length : 0;
         const fkCount = table.foreign_keys ? table.foreign_keys.length : 0;
+        const name = qualifiedName(table.schema, table.name);
+        const rowCount = table.stats && table.stats.rows >= 0 ? ` · 약 ${table.stats.rows.toLocaleString()}행` : '';
         
         html += `
-            <div class="table-card" data-table="${escapeHtml(table.name)}">
-                <div class="table-header" onclick="toggleTableDetail('${escapeHtml(table.name)}')">
+            <div class="table-card" data-table="${escapeHtml(name)}">
+                <div class="table-header" onclick="toggleTableDetail('${escapeHtml(name)}')">
                     <span class="table-icon">📋</span>
-                    <span class="table-name">${escapeHtml(table.name)}</span>
-                    <span class="table-meta">${columnCount}개 컬럼</span>
-                    <button class="sample-btn" onclick="event.stopPropagation(); loadSampleData('${escapeHtml(table.name)}')">
+                    <span class="table-name">${escapeHtml(name)}</span>
+                    <span class="table-meta">${columnCount}개 컬럼${rowCount}</span>
+                    <button class="sample-btn" onclick="event.stopPropagation(); loadSampleData('${escapeHtml(name)}')">
                         👁️ 데이터 보기
                     </button>
                 </div>
-                <div class="table-columns" id="cols-${escapeHtml(table.name)}">
+                <div class="table-columns" id="cols-${escapeHtml(name)}">
         `;
         
         if (table.columns) {
@@ -495,14 +547,20 @@ function renderSchema(schema) {
                 if (col.is_fk) badges.push('<span class="badge badge-fk">FK</span>');
                 if (col.is_unique) badges.push('<span class="badge badge-unique">UQ</span>');
                 if (col.is_auto_incr) badges.push('<span class="badge badge-auto">AUTO</span>');
+                if (col.generated) badges.push(`<span class="badge badge-gen" title="${escapeHtml(col.generated)}">${col.stored ? 'STORED' : 'GEN'}</span>`);
+                if (col.enum_values && col.enum_values.length > 0) {
+                    badges.push(`<span class="badge badge-enum" title="${escapeHtml(col.enum_values.join(', '))}">ENUM</span>`);
+                }
                 
                 const nullable = col.nullable ? 'NULL' : 'NOT NULL';
-                const defaultVal = col.default ? `= ${col.default}` : '';
+                const defaultVal = col.generated ? `AS (${col.generated})` : (col.default ? `= ${col.default}` : '');
+                const typeDetail = [col.domain ? `도메인 ${col.domain}` : '', col.collation ? `COLLATE ${col.collation}` : '']
+                    .filter(Boolean).join(', ');
                 
                 html += `
                     <div class="column-item">
-                        <span class="column-name">${escapeHtml(col.name)}</span>
-                        <span class="column-type">${escapeHtml(col.type)}</span>
+                        <span class="column-name" title="${escapeHtml(columnStatsText(col.stats))}">${escapeHtml(col.name)}</span>
+                        <span class="column-type" title="${escapeHtml(typeDetail)}">${escapeHtml(col.type)}</span>
                         <span class="column-nullable">${nullable}</span>
                         <span class="column-default">${escapeHtml(defaultVal)}</span>
                         <div class="column-badges">${badges.join('')}</div>
@@ -521,17 +579,29 @@ function renderSchema(schema) {
             html += '</div>';
         }
         
+        // CHECK 제약조건
+        if (table.checks && table.checks.length > 0) {
+            html += `<div class="table-section"><h5>✔️ CHECK (${table.checks.length})</h5>`;
+            for (const check of table.checks) {
+                const name = check.name ? `${escapeHtml(check.name)}: ` : '';
+                html += `<div class="index-item">${name}${escapeHtml(check.expression)}</div>`;
+            }
+            html += '</div>';
+        }
+        
         // 외래키 정보
         if (table.foreign_keys && table.foreign_keys.length > 0) {
             html += `<div class="table-section"><h5>🔗 외래키 (${fkCount})</h5>`;
             for (const fk of table.foreign_keys) {
-                html += `<div class="fk-item">${escapeHtml(fk.column)} → ${escapeHtml(fk.ref_table)}.${escapeHtml(fk.ref_column)}</div>`;
+                html += `<div class="fk-item">${escapeHtml(fk.column)} → ${escapeHtml(qualifiedName(fk.ref_schema, fk.ref_table))}.${escapeHtml(fk.ref_column)}</div>`;
             }
             html += '</div>';
         }
         
         html += '</div></div>';
     }
+
+    html += renderObjects(schema);
     
     // 샘플 데이터 모달
     html += `
@@ -551,6 +621,64 @@ function renderSchema(schema) {
     elements.schemaView.innerHTML = html;
 }
 
+// renderObjects 뷰, 시퀀스, 함수/프로시저, 트리거 목록
+function renderObjects(schema) {
+    let html = '';
+
+    for (const view of schema.views || []) {
+        const name = qualifiedName(view.schema, view.name);
+        const columns = (view.columns || []).map(col => `
+            <div class="column-item">
+                <span class="column-name">${escapeHtml(col.name)}</span>
+                <span class="column-type">${escapeHtml(col.type)}</span>
+            </div>`).join('');
+        html += `
+            <div class="table-card" data-table="${escapeHtml(name)}">
+                <div class="table-header" onclick="toggleTableDetail('${escapeHtml(name)}')">
+                    <span class="table-icon">${view.materialized ? '🧊' : '🪟'}</span>
+                    <span class="table-name">${escapeHtml(name)}</span>
+                    <span class="table-meta">${view.materialized ? '구체화된 뷰' : '뷰'}</span>
+                </div>
+                <div class="table-columns" id="cols-${escapeHtml(name)}">${columns}</div>
+            </div>
+        `;
+    }
+
+    const sections = [
+        ['🏷️ 사용자 정의 타입', (schema.types || []).map(t => t.kind === 'ENUM'
+            ? `ENUM ${qualifiedName(t.schema, t.name)} (${(t.values || []).join(', ')})`
+            : `DOMAIN ${qualifiedName(t.schema, t.name)} ${t.base_type}${t.check ? ' CHECK (' + t.check + ')' : ''}`)],
+        ['🔢 시퀀스', (schema.sequences || []).map(seq => qualifiedName(seq.schema, seq.name))],
+        ['⚙️ 함수/프로시저', (schema.routines || []).map(r =>
+            `${r.kind} ${qualifiedName(r.schema, r.name)}(${r.arguments || ''})${r.returns ? ' RETURNS ' + r.returns : ''}`)],
+        ['⚡ 트리거', (schema.triggers || []).map(t =>
+            `${qualifiedName(t.schema, t.name)}: ${t.timing} ${(t.events || []).join(' OR ')} ON ${t.table}`)]
+    ];
+    for (const [title, items] of sections) {
+        if (items.length === 0) continue;
+        html += `<div class="table-section"><h5>${title} (${items.length})</h5>`;
+        html += items.map(item => `<div class="index-item">${escapeHtml(item)}</div>`).join('');
+        html += '</div>';
+    }
+    return html;
+}
+
+// qualifiedName 스키마가 있으면 schema.table
+function columnStatsText(stats) {
+    if (!stats) return '';
+    const parts = [];
+    if (stats.distinct) parts.push(`고유 값 약 ${stats.distinct.toLocaleString()}`);
+    if (stats.null_fraction) parts.push(`NULL ${Math.round(stats.null_fraction * 100)}%`);
+    if (stats.histogram && stats.histogram.length >= 2) {
+        parts.push(`범위 ${stats.histogram[0]} ~ ${stats.histogram[stats.histogram.length - 1]}`);
+    }
+    return parts.join(', ');
+}
+
+function qualifiedName(schema, name) {
+    return schema ? `${schema}.${name}` : name;
+}
+
 function toggleTableDetail(tableName) {
     const cols = document.getElementById(`cols-${tableName}`);
     if (cols) {
@@ -581,18 +709,20 @@ async function loadSampleData(tableName) {
     }
 }
 
-function renderSampleData(data, container) {
+function renderSampleData(data, container, note = '최대 20개 표시') {
     if (!data.rows || data.rows.length === 0) {
         container.innerHTML = '<div class="empty-message">📭 테이블에 데이터가 없습니다</div>';
         return;
     }
     
-    let html = `<div class="sample-info">총 ${data.count}개 행 (최대 20개 표시)</div>`;
+    let html = `<div class="sample-info">총 ${data.count}개 행 (${escapeHtml(note)})</div>`;
     html += '<div class="sample-table-wrapper"><table class="sample-table"><thead><tr>';
     
-    for (const col of data.columns) {
-        html += `<th>${escapeHtml(col)}</th>`;
-    }
+    data.columns.forEach((col, i) => {
+        const type = data.column_types && data.column_types[i];
+        const title = type && type.db_type ? ` title="${escapeHtml(type.db_type)}"` : '';
+        html += `<th${title}>${escapeHtml(col)}</th>`;
+    });
     html += '</tr></thead><tbody>';
     
     for (const row of data.rows) {
@@ -627,6 +757,7 @@ function closeSampleModal() {
 function initConnectionForm() {
     elements.connectionForm.addEventListener('submit', handleConnect);
     elements.disconnectBtn.addEventListener('click', handleDisconnect);
+    elements.refreshSchemaBtn.addEventListener('click', handleRefreshSchema);
     
     // Auto-update port based on DB type
     elements.dbType.addEventListener('change', () => {
@@ -634,28 +765,221 @@ function initConnectionForm() {
             mysql: 3306,
             postgresql: 5432,
             oracle: 1521,
-            sqlserver: 1433
+            sqlserver: 1433,
+            sqlite: 0
         };
-        elements.dbPort.value = ports[elements.dbType.value] || 3306;
+        const port = ports[elements.dbType.value];
+        elements.dbPort.value = port !== undefined ? port : 3306;
+
+        // SQLite는 파일 경로만 필요 (사용자/호스트 불필요)
+        const isSQLite = elements.dbType.value === 'sqlite';
+        document.getElementById('dbUser').required = !isSQLite;
+        document.getElementById('dbName').placeholder = isSQLite ? './app.db' : '';
+    });
+
+    // 저장된 프로필
+    elements.dbProfile.addEventListener('change', selectProfile);
+    elements.saveProfileBtn.addEventListener('click', saveProfile);
+    elements.deleteProfileBtn.addEventListener('click', deleteProfile);
+    // 연결 정보를 고치면 직접 입력으로 전환
+    ['dbType', 'dbHost', 'dbPort', 'dbUser', 'dbPassword', 'dbName', ...Object.keys(advancedFields), ...Object.keys(sshFields)].forEach(id => {
+        document.getElementById(id).addEventListener('input', () => {
+            if (elements.dbProfile.value) {
+                elements.dbProfile.value = '';
+                elements.deleteProfileBtn.disabled = true;
+            }
+        });
     });
+    loadProfiles();
 }
 
-async function handleConnect(e) {
-    e.preventDefault();
-    
-    const formData = {
+// advancedFields 고급 설정 입력 id와 연결 설정(models.DBConfig) 필드
+const advancedFields = {
+    dbTLSMode: 'tls_mode',
+    dbCACert: 'ca_cert',
+    dbClientCert: 'client_cert',
+    dbClientKey: 'client_key',
+    dbAppName: 'application_name',
+    dbSID: 'sid',
+    dbConnectTimeout: 'connect_timeout',
+    dbReadTimeout: 'read_timeout',
+    dbMaxConns: 'max_open_conns'
+};
+
+// sshFields SSH 터널 입력 id와 연결 설정 ssh 필드
+const sshFields = {
+    sshHost: 'host',
+    sshPort: 'port',
+    sshUser: 'user',
+    sshKeyFile: 'key_file',
+    sshPassword: 'password',
+    sshKnownHosts: 'known_hosts'
+};
+
+// extractFields 스키마 추출 범위 입력 id와 연결 요청 필드 (쉼표로 구분한 패턴)
+const extractFields = {
+    extractSchemas: 'schemas',
+    extractExcludeSchemas: 'exclude_schemas',
+    extractTables: 'tables',
+    extractExcludeTables: 'exclude_tables'
+};
+
+// extractOptions 입력한 스키마 추출 범위 (빈 값은 생략)
+function extractOptions() {
+    const options = {};
+    Object.entries(fieldValues(extractFields)).forEach(([field, value]) => {
+        const patterns = value.split(',').map(p => p.trim()).filter(p => p);
+        if (patterns.length) options[field] = patterns;
+    });
+    return options;
+}
+
+// fieldValues 입력값을 필드 이름으로 모음 (빈 값은 생략)
+function fieldValues(fields) {
+    const values = {};
+    Object.entries(fields).forEach(([id, field]) => {
+        const input = document.getElementById(id);
+        if (!input.value) return;
+        values[field] = input.type === 'number' ? parseInt(input.value) || 0 : input.value;
+    });
+    return values;
+}
+
+// connectionConfig 폼에 입력한 연결 설정 (비어 있는 고급 설정은 생략)
+function connectionConfig() {
+    const config = {
         type: elements.dbType.value,
         host: document.getElementById('dbHost').value,
-        port: parseInt(document.getElementById('dbPort').value),
+        port: parseInt(document.getElementById('dbPort').value) || 0,
         user: document.getElementById('dbUser').value,
         password: document.getElementById('dbPassword').value,
         database: document.getElementById('dbName').value
     };
+    Object.assign(config, fieldValues(advancedFields));
+    // SSH 호스트를 입력했을 때만 터널 사용
+    const ssh = fieldValues(sshFields);
+    if (ssh.host) config.ssh = ssh;
+    return config;
+}
+
+async function loadProfiles(selected = '') {
+    try {
+        const response = await fetch(`${API_BASE}/api/profiles`);
+        const result = await response.json();
+        if (!result.success) return;
+
+        savedProfiles = result.data || [];
+        elements.dbProfile.innerHTML = '<option value="">직접 입력</option>' + savedProfiles.map(p =>
+            `<option value="${escapeHtml(p.name)}">${escapeHtml(p.name)} (${escapeHtml(p.db.type)})</option>`
+        ).join('');
+        elements.dbProfile.value = selected;
+        elements.deleteProfileBtn.disabled = !selected;
+    } catch (error) {
+        console.error('Profile load error:', error);
+    }
+}
+
+// selectProfile 선택한 프로필의 연결 정보를 폼에 표시 (비밀번호는 서버에만 저장)
+function selectProfile() {
+    const profile = savedProfiles.find(p => p.name === elements.dbProfile.value);
+    elements.deleteProfileBtn.disabled = !profile;
+    if (!profile) return;
+
+    elements.dbType.value = profile.db.type;
+    document.getElementById('dbHost').value = profile.db.host || '';
+    elements.dbPort.value = profile.db.port || 0;
+    document.getElementById('dbUser').value = profile.db.user || '';
+    document.getElementById('dbUser').required = profile.db.type !== 'sqlite';
+    document.getElementById('dbPassword').value = '';
+    document.getElementById('dbPassword').placeholder = profile.has_password ? '저장됨' : '';
+    document.getElementById('dbName').value = profile.db.database || '';
+    Object.entries(advancedFields).forEach(([id, field]) => {
+        document.getElementById(id).value = profile.db[field] || '';
+    });
+    Object.entries(sshFields).forEach(([id, field]) => {
+        document.getElementById(id).value = (profile.db.ssh && profile.db.ssh[field]) || '';
+    });
+    document.getElementById('sshPassword').placeholder = profile.has_ssh_password ? '저장됨' : '';
+    elements.profileName.value = profile.name;
+}
+
+// adminFetch 관리자 인증이 필요한 요청 (401이면 관리자 토큰을 물어 다시 요청하고, 토큰은 탭을 닫을 때까지 보관)
+async function adminFetch(url, options = {}) {
+    const send = () => {
+        const token = sessionStorage.getItem('adminToken');
+        const headers = Object.assign({}, options.headers, token ? { Authorization: `Bearer ${token}` } : {});
+        return fetch(url, Object.assign({}, options, { headers }));
+    };
+
+    let response = await send();
+    if (response.status === 401) {
+        const token = prompt('관리자 토큰을 입력하세요');
+        if (token) {
+            sessionStorage.setItem('adminToken', token);
+            response = await send();
+        }
+    }
+    return response;
+}
+
+async function saveProfile() {
+    const name = elements.profileName.value.trim();
+    if (!name) {
+        showError(elements.connectionStatus, '프로필 이름을 입력하세요');
+        return;
+    }
+
+    const body = { name, db: connectionConfig() };
+
+    try {
+        const response = await adminFetch(`${API_BASE}/api/profiles`, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(body)
+        });
+        const result = await response.json();
+        if (!result.success) {
+            showError(elements.connectionStatus, result.error);
+            return;
+        }
+        await loadProfiles(name);
+        selectProfile();
+    } catch (error) {
+        showError(elements.connectionStatus, '프로필 저장 실패: ' + error.message);
+    }
+}
+
+async function deleteProfile() {
+    const name = elements.dbProfile.value;
+    if (!name || !confirm(`프로필 ${name}을(를) 삭제할까요?`)) return;
+
+    try {
+        const response = await adminFetch(`${API_BASE}/api/profiles?name=${encodeURIComponent(name)}`, { method: 'DELETE' });
+        const result = await response.json();
+        if (!result.success) {
+            showError(elements.connectionStatus, result.error);
+            return;
+        }
+        elements.profileName.value = '';
+        document.getElementById('dbPassword').placeholder = '';
+        document.getElementById('sshPassword').placeholder = '';
+        await loadProfiles();
+    } catch (error) {
+        showError(elements.connectionStatus, '프로필 삭제 실패: ' + error.message);
+    }
+}
+
+async function handleConnect(e) {
+    e.preventDefault();
+    
+    // 프로필을 선택했으면 서버에 저장된 연결 정보 사용
+    const formData = elements.dbProfile.value ? { profile: elements.dbProfile.value } : connectionConfig();
+    Object.assign(formData, extractOptions());
     
     showLoading(elements.connectionStatus);
     
     try {
-        const response = await fetch(`${API_BASE}/api/connect`, {
+        const response = await adminFetch(`${API_BASE}/api/connect`, {
             method: 'POST',
             headers: { 'Content-Type': 'application/json' },
             body: JSON.stringify(formData)
@@ -667,8 +991,9 @@ async function handleConnect(e) {
             isConnected = true;
             currentSchema = result.data.schema;
             elements.disconnectBtn.disabled = false;
+            elements.refreshSchemaBtn.disabled = false;
             
-            showConnectionSuccess(result.data.schema);
+            showConnectionSuccess(result.data.schema, result.data.refresh);
             renderSchema(result.data.schema);
             updateStatus(true, `${result.data.schema.database} 연결됨`);
         } else {
@@ -679,6 +1004,31 @@ async function handleConnect(e) {
     }
 }
 
+// 바뀐 테이블만 다시 추출 (Shift+클릭하면 캐시를 무시하고 전체 추출)
+async function handleRefreshSchema(e) {
+    showLoading(elements.connectionStatus);
+    
+    try {
+        const response = await fetch(`${API_BASE}/api/schema/refresh`, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ full: e.shiftKey })
+        });
+        
+        const result = await response.json();
+        
+        if (result.success) {
+            currentSchema = result.data.schema;
+            showConnectionSuccess(result.data.schema, result.data.refresh);
+            renderSchema(result.data.schema);
+        } else {
+            showError(elements.connectionStatus, result.error);
+        }
+    } catch (error) {
+        showError(elements.connectionStatus, '스키마 새로 고침 실패: ' + error.message);
+    }
+}
+
 async function handleDisconnect() {
     try {
         await fetch(`${API_BASE}/api/disconnect`, { method: 'POST' });
@@ -686,6 +1036,7 @@ async function handleDisconnect() {
         isConnected = false;
         currentSchema = null;
         elements.disconnectBtn.disabled = true;
+        elements.refreshSchemaBtn.disabled = true;
         
         elements.connectionStatus.innerHTML = `
             <div class="result-placeholder">
@@ -707,7 +1058,20 @@ async function handleDisconnect() {
     }
 }
 
-function showConnectionSuccess(schema) {
+// refreshSummary 스키마 캐시 사용 결과 (전체 추출이면 추출 시각만)
+function refreshSummary(refresh) {
+    if (!refresh) return '';
+    const at = new Date(refresh.extracted_at).toLocaleString();
+    if (refresh.full) {
+        return `<br>스키마: <strong>전체 추출</strong> (${escapeHtml(at)})`;
+    }
+    const changed = refresh.changed || [];
+    const dropped = refresh.dropped || [];
+    const names = changed.length ? ` — ${escapeHtml(changed.slice(0, 10).join(', '))}${changed.length > 10 ? ' 외' : ''}` : '';
+    return `<br>스키마: 캐시 사용 (변경 <strong>${changed.length}</strong>개, 삭제 <strong>${dropped.length}</strong>개, 그대로 <strong>${refresh.unchanged}</strong>개)${names}`;
+}
+
+function showConnectionSuccess(schema, refresh) {
     elements.connectionStatus.innerHTML = `
         <div class="result-content">
             <div class="result-header">
@@ -719,6 +1083,7 @@ function showConnectionSuccess(schema) {
                     데이터베이스: <strong>${escapeHtml(schema.database)}</strong><br>
                     타입: <strong>${escapeHtml(schema.db_type)}</strong><br>
                     테이블 수: <strong>${schema.tables.length}</strong>개
+                    ${refreshSummary(refresh)}
                 </p>
             </div>
         </div>
@@ -768,6 +1133,24 @@ function showLoading(container) {
     `;
 }
 
+function showStreamingText(container, text) {
+    let pre = container.querySelector('#streamingContent');
+    if (!pre) {
+        container.innerHTML = `
+            <div class="result-content">
+                <div class="result-header">
+                    <h3>🔄 생성 중...</h3>
+                </div>
+                <div class="sql-code">
+                    <pre id="streamingContent"></pre>
+                </div>
+            </div>
+        `;
+        pre = container.querySelector('#streamingContent');
+    }
+    pre.textContent = text;
+}
+
 function showError(container, message) {
     container.innerHTML = `
         <div class="result-placeholder" style="color: var(--error);">
@@ -792,6 +1175,24 @@ function showQueryResult(container, data, title = '생성된 쿼리') {
         `;
     }
     
+    let attemptsHTML = '';
+    if (data.attempts && data.attempts.length > 0) {
+        const failed = data.attempts.filter(a => !a.valid);
+        const errors = failed.flatMap(a => [
+            ...(a.issues || []).map(i => i.message + (i.suggestion ? ` (${i.suggestion})` : '')),
+            ...(a.db_error ? ['DB: ' + a.db_error] : [])
+        ]);
+        const heading = data.verified
+            ? (failed.length > 0 ? `✅ 검증 통과 (${failed.length}번 다시 생성)` : '✅ 검증 통과')
+            : '⚠️ 검증 실패 - 확인 후 사용하세요';
+        attemptsHTML = `
+            <div class="result-tips">
+                <h4>${heading}</h4>
+                ${errors.length > 0 ? `<ul>${errors.map(e => `<li>${escapeHtml(e)}</li>`).join('')}</ul>` : ''}
+            </div>
+        `;
+    }
+    
     container.innerHTML = `
         <div class="result-content">
             <div class="result-header">
@@ -809,10 +1210,147 @@ function showQueryResult(container, data, title = '생성된 쿼리') {
                 </div>
             ` : ''}
             ${tipsHTML}
+            ${attemptsHTML}
+            ${runFormHTML(data.params)}
+        </div>
+    `;
+}
+
+// DB에 연결되어 있으면 파라미터 입력과 실행 버튼 표시
+function runFormHTML(params) {
+    if (!isConnected) return '';
+    
+    const fields = (params || []).map(p => `
+        <div class="form-group param-field">
+            <label>:${escapeHtml(p.name)}${p.type ? ` (${escapeHtml(p.type)})` : ''}${p.description ? ` - ${escapeHtml(p.description)}` : ''}</label>
+            <input type="text" data-param="${escapeHtml(p.name)}" data-type="${escapeHtml(p.type || '')}" value="${escapeHtml(p.value || '')}" placeholder="NULL 입력 시 NULL">
+        </div>
+    `).join('');
+    
+    return `
+        <div class="result-run">
+            <h4>▶️ 실행</h4>
+            ${fields}
+            <div class="run-actions">
+            <button class="copy-btn run-btn" onclick="runResultQuery(this)">실행</button>
+            <select class="export-format">
+                <option value="csv">CSV</option>
+                <option value="tsv">TSV</option>
+                <option value="xlsx">Excel (XLSX)</option>
+                <option value="ndjson">JSON Lines</option>
+                <option value="markdown">Markdown</option>
+                <option value="sql">INSERT 문</option>
+            </select>
+            <button class="copy-btn run-btn" onclick="exportResultQuery(this)">내보내기</button>
+            </div>
+            <div class="run-output"></div>
         </div>
     `;
 }
 
+// 실행 결과: 결과 집합이 없으면 영향 행 수, 여러 결과 집합은 차례로 표시
+function renderExecuteResult(data, output) {
+    if (!data.columns || data.columns.length === 0) {
+        const lastId = data.last_insert_id !== undefined ? `, 마지막 INSERT ID: ${data.last_insert_id}` : '';
+        output.innerHTML = `<div class="sample-info">✅ 실행 완료 (${data.rows_affected}행 영향${lastId}, ${data.duration}ms)</div>`;
+        return;
+    }
+    
+    const sets = [data, ...(data.more_results || [])];
+    output.innerHTML = sets.map(() => '<div class="run-set"></div>').join('');
+    output.querySelectorAll('.run-set').forEach((el, i) => {
+        const set = sets[i];
+        let note = i === 0 ? `${data.duration}ms` : `결과 집합 ${i + 1}`;
+        if (set.truncated) note += ', 서버 제한으로 이후 행 생략';
+        renderSampleData({ columns: set.columns, column_types: set.column_types, rows: set.rows, count: (set.rows || []).length }, el, note);
+    });
+}
+
+// paramValue 입력값을 타입에 맞게 변환 (CLI와 같은 규칙)
+function paramValue(value, type) {
+    if (value.toUpperCase() === 'NULL') return null;
+    const t = type.toUpperCase();
+    if (t.includes('INT') && /^-?\d+$/.test(value)) return Number(value);
+    if (['DEC', 'NUM', 'FLOAT', 'REAL', 'DOUBLE'].some(k => t.includes(k)) && value !== '' && !isNaN(Number(value))) return Number(value);
+    if (t.includes('BOOL') && /^(true|false)$/i.test(value)) return value.toLowerCase() === 'true';
+    return value;
+}
+
+// resultParams 결과 화면의 파라미터 입력값
+function resultParams(section) {
+    const params = {};
+    section.querySelectorAll('input[data-param]').forEach(input => {
+        params[input.dataset.param] = paramValue(input.value.trim(), input.dataset.type);
+    });
+    return params;
+}
+
+async function runResultQuery(button, confirmed = false) {
+    const section = button.closest('.result-content');
+    const query = section.querySelector('.sql-code pre').textContent;
+    const output = section.querySelector('.run-output');
+    const params = resultParams(section);
+    
+    showLoading(output);
+    
+    try {
+        const response = await fetch(`${API_BASE}/api/execute`, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ query, params, confirm: confirmed })
+        });
+        const result = await response.json();
+        
+        // confirm-dml 정책: 확인 후 다시 요청
+        if (response.status === 409 && result.data && result.data.requires_confirmation) {
+            if (confirm(`${result.error}\n실행하시겠습니까?`)) {
+                return runResultQuery(button, true);
+            }
+            output.innerHTML = '';
+            return;
+        }
+        
+        if (!result.success) {
+            output.innerHTML = `<div class="error-message">❌ ${escapeHtml(result.error)}</div>`;
+            return;
+        }
+        
+        renderExecuteResult(result.data, output);
+    } catch (error) {
+        output.innerHTML = `<div class="error-message">❌ 실행 실패: ${escapeHtml(error.message)}</div>`;
+    }
+}
+
+// 결과를 선택한 형식의 파일로 내려받음 (서버에서 스트리밍)
+async function exportResultQuery(button) {
+    const section = button.closest('.result-content');
+    const query = section.querySelector('.sql-code pre').textContent;
+    const output = section.querySelector('.run-output');
+    const format = section.querySelector('.export-format').value;
+    
+    try {
+        const response = await fetch(`${API_BASE}/api/execute/export?format=${encodeURIComponent(format)}`, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ query, params: resultParams(section) })
+        });
+        if (!response.ok) {
+            const result = await response.json();
+            output.innerHTML = `<div class="error-message">❌ ${escapeHtml(result.error)}</div>`;
+            return;
+        }
+        
+        const blob = await response.blob();
+        const link = document.createElement('a');
+        link.href = URL.createObjectURL(blob);
+        link.download = `result.${format === 'markdown' ? 'md' : format}`;
+        link.click();
+        URL.revokeObjectURL(link.href);
+    } catch (error) {
+        output.innerHTML = `<div class="error-message">❌ 내보내기 실패: ${escapeHtml(error.message)}</div>`;
+    }
+}
+
 function highlightSQL(sql) {
     const keywords = [
         'SELECT', 'FROM', 'WHERE', 'AND', 'OR', 'NOT', 'IN', 'LIKE', 'BETWEEN',
@@ -869,4 +1407,6 @@ function escapeHtml(text) {
 
 // Make copySQL global
 window.copySQL = copySQL;
+window.runResultQuery = runResultQuery;
+window.exportResultQuery = exportResultQuery;
 
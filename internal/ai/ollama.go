@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"sql-genius/pkg/models"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -23,12 +25,22 @@ type ollamaRequest struct {
 	Model  string `json:"model"`
 	Prompt string `json:"prompt"`
 	Stream bool   `json:"stream"`
+	Format string `json:"format,omitempty"` // "json"이면 JSON 형식으로 응답
+
+	Options *ollamaOptions `json:"options,omitempty"`
+}
+
+// ollamaOptions 요청별 모델 파라미터 (비어 있으면 모델 기본값)
+type ollamaOptions struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	NumPredict  int      `json:"num_predict,omitempty"` // 최대 생성 토큰 수
 }
 
 type ollamaResponse struct {
 	Model    string `json:"model"`
 	Response string `json:"response"`
 	Done     bool   `json:"done"`
+	Error    string `json:"error,omitempty"`
 }
 
 // NewOllamaProvider Ollama 제공자 생성
@@ -71,12 +83,34 @@ func (o *OllamaProvider) IsAvailable(ctx context.Context) bool {
 	return resp.StatusCode == http.StatusOK
 }
 
-func (o *OllamaProvider) generate(ctx context.Context, prompt string) (string, error) {
+// request /api/generate 요청 본문 (opts의 모델 설정이 있으면 제공자 설정 대신 사용)
+func (o *OllamaProvider) request(prompt string, stream, jsonOutput bool, opts models.ModelOptions) ollamaRequest {
 	reqBody := ollamaRequest{
 		Model:  o.model,
 		Prompt: prompt,
-		Stream: false,
+		Stream: stream,
+	}
+	if jsonOutput {
+		reqBody.Format = "json"
+	}
+	if opts.Model != "" {
+		reqBody.Model = opts.Model
+	}
+	if opts.Temperature != nil || opts.MaxTokens > 0 {
+		reqBody.Options = &ollamaOptions{Temperature: opts.Temperature, NumPredict: opts.MaxTokens}
 	}
+	return reqBody
+}
+
+// generator opts로 요청하는 generateFunc (JSON 복구 재요청에도 같은 모델 설정 사용)
+func (o *OllamaProvider) generator(opts models.ModelOptions) generateFunc {
+	return func(ctx context.Context, prompt string, jsonOutput bool) (string, error) {
+		return o.generate(ctx, prompt, jsonOutput, opts)
+	}
+}
+
+func (o *OllamaProvider) generate(ctx context.Context, prompt string, jsonOutput bool, opts models.ModelOptions) (string, error) {
+	reqBody := o.request(prompt, false, jsonOutput, opts)
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
@@ -110,42 +144,116 @@ func (o *OllamaProvider) generate(ctx context.Context, prompt string) (string, e
 
 func (o *OllamaProvider) GenerateQuery(ctx context.Context, req *models.QueryRequest) (*models.QueryResponse, error) {
 	prompt := buildQueryPrompt(req)
+	generate := o.generator(req.ModelOptions)
 
 	start := time.Now()
-	response, err := o.generate(ctx, prompt)
+	response, err := generate(ctx, prompt, true)
 	if err != nil {
 		return nil, err
 	}
-	elapsed := time.Since(start).Milliseconds()
 
-	query, explanation, tips := parseQueryResponse(response)
+	result, err := parseQueryResult(ctx, generate, response)
+	if err != nil {
+		return nil, err
+	}
+	result.ExecuteTime = time.Since(start).Milliseconds()
 
-	return &models.QueryResponse{
-		Query:       query,
-		Explanation: explanation,
-		Tips:        tips,
-		ExecuteTime: elapsed,
-	}, nil
+	return result, nil
+}
+
+// generateStream NDJSON 스트림으로 응답을 받아 조각마다 onToken 호출
+func (o *OllamaProvider) generateStream(ctx context.Context, prompt string, jsonOutput bool, opts models.ModelOptions, onToken TokenHandler) (string, error) {
+	reqBody := o.request(prompt, true, jsonOutput, opts)
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("JSON 마샬링 실패: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.endpoint+"/api/generate", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("요청 생성 실패: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("API 오류 (상태 코드: %d): %s", resp.StatusCode, string(body))
+	}
+
+	var sb strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk ollamaResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return "", fmt.Errorf("JSON 파싱 실패: %w", err)
+		}
+		if chunk.Error != "" {
+			return "", fmt.Errorf("API 오류: %s", chunk.Error)
+		}
+		if chunk.Response != "" {
+			sb.WriteString(chunk.Response)
+			if onToken != nil {
+				onToken(chunk.Response)
+			}
+		}
+		if chunk.Done {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("응답 읽기 실패: %w", err)
+	}
+
+	return sb.String(), nil
+}
+
+func (o *OllamaProvider) GenerateQueryStream(ctx context.Context, req *models.QueryRequest, onToken TokenHandler) (*models.QueryResponse, error) {
+	prompt := buildQueryPrompt(req)
+
+	start := time.Now()
+	response, err := o.generateStream(ctx, prompt, true, req.ModelOptions, onToken)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseQueryResult(ctx, o.generator(req.ModelOptions), response)
+	if err != nil {
+		return nil, err
+	}
+	result.ExecuteTime = time.Since(start).Milliseconds()
+
+	return result, nil
 }
 
 func (o *OllamaProvider) OptimizeQuery(ctx context.Context, query string, schema *models.Schema) (*models.QueryResponse, error) {
 	prompt := buildOptimizePrompt(query, schema)
 
 	start := time.Now()
-	response, err := o.generate(ctx, prompt)
+	response, err := o.generate(ctx, prompt, true, models.ModelOptions{})
 	if err != nil {
 		return nil, err
 	}
-	elapsed := time.Since(start).Milliseconds()
 
-	optimized, explanation, tips := parseQueryResponse(response)
+	result, err := parseQueryResult(ctx, o.generator(models.ModelOptions{}), response)
+	if err != nil {
+		return nil, err
+	}
+	result.ExecuteTime = time.Since(start).Milliseconds()
 
-	return &models.QueryResponse{
-		Query:       optimized,
-		Explanation: explanation,
-		Tips:        tips,
-		ExecuteTime: elapsed,
-	}, nil
+	return result, nil
 }
 
 func (o *OllamaProvider) ExplainQuery(ctx context.Context, query string) (string, error) {
@@ -155,7 +263,7 @@ func (o *OllamaProvider) ExplainQuery(ctx context.Context, query string) (string
 
 설명:`, query)
 
-	return o.generate(ctx, prompt)
+	return o.generate(ctx, prompt, false, models.ModelOptions{})
 }
 
 // buildQueryPrompt 쿼리 생성 프롬프트 구성
@@ -179,22 +287,42 @@ func buildQueryPrompt(req *models.QueryRequest) string {
 2. 불필요한 서브쿼리를 피하세요
 3. 적절한 JOIN을 사용하세요
 4. %s 문법에 맞게 작성하세요
+5. 요청에 나온 구체적인 값(ID, 이름, 날짜, 검색어 등)은 쿼리에 직접 넣지 말고 :name 형식의 파라미터로 작성한 뒤 params에 나열하세요
+6. 스키마 정보에 schema.table 형태로 나온 테이블은 쿼리에서도 스키마를 붙여 쓰세요
+7. [값: ...]이 표시된 컬럼은 목록에 있는 값만 대소문자까지 그대로 사용하세요 (이런 고정 값은 파라미터로 만들지 않아도 됩니다)%s
 
 ## 응답 형식:
-SQL:
-(쿼리)
-
-설명:
-(간단한 설명)
+%s
+`, req.Schema.DBType, schemaStr, req.Prompt, req.QueryType, req.Schema.DBType, statsRequirement(&req.Schema, "\n8. "), jsonInstruction(queryResponseFormat))
 
-최적화 팁:
-- (팁1)
-- (팁2)
-`, req.Schema.DBType, schemaStr, req.Prompt, req.QueryType, req.Schema.DBType)
+	if len(req.Attempts) > 0 {
+		prompt += formatAttempts(req.Attempts)
+	}
 
 	return prompt
 }
 
+// formatAttempts 검증에 실패한 이전 시도와 오류를 프롬프트에 추가할 형식으로 변환
+func formatAttempts(attempts []models.QueryAttempt) string {
+	var sb strings.Builder
+	sb.WriteString("\n## 이전 시도 (검증 실패):\n")
+	sb.WriteString("아래 쿼리들은 스키마 또는 데이터베이스 검증에 실패했습니다. 오류를 수정한 새 쿼리를 작성하세요. 스키마에 없는 테이블이나 컬럼은 사용하지 마세요.\n")
+	for i, attempt := range attempts {
+		sb.WriteString(fmt.Sprintf("\n### 시도 %d\n%s\n오류:\n", i+1, attempt.Query))
+		for _, issue := range attempt.Issues {
+			line := "- " + issue.Message
+			if issue.Suggestion != "" {
+				line += " (" + issue.Suggestion + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+		if attempt.DBError != "" {
+			sb.WriteString("- 데이터베이스 오류: " + attempt.DBError + "\n")
+		}
+	}
+	return sb.String()
+}
+
 // buildOptimizePrompt 최적화 프롬프트 구성
 func buildOptimizePrompt(query string, schema *models.Schema) string {
 	schemaStr := formatSchema(schema)
@@ -207,17 +335,9 @@ func buildOptimizePrompt(query string, schema *models.Schema) string {
 ## 스키마 정보:
 %s
 
-## 응답 형식:
-SQL:
-(최적화된 쿼리)
-
-설명:
-(변경 사항 설명)
-
-최적화 팁:
-- (팁1)
-- (팁2)
-`, query, schemaStr)
+## 응답 형식 (query에는 최적화된 쿼리, explanation에는 변경 사항 설명, 원본 쿼리의 파라미터(:name, ? 등)는 그대로 유지):
+%s
+`, query, schemaStr, jsonInstruction(queryResponseFormat))
 }
 
 // formatSchema 스키마를 문자열로 변환
@@ -225,7 +345,7 @@ func formatSchema(schema *models.Schema) string {
 	var sb strings.Builder
 
 	for _, table := range schema.Tables {
-		sb.WriteString(fmt.Sprintf("테이블: %s\n", table.Name))
+		sb.WriteString(fmt.Sprintf("테이블: %s%s\n", table.QualifiedName(), tableStats(table.Stats)))
 		sb.WriteString("컬럼:\n")
 		for _, col := range table.Columns {
 			flags := ""
@@ -238,9 +358,28 @@ func formatSchema(schema *models.Schema) string {
 			if col.IsUnique {
 				flags += " [UNIQUE]"
 			}
+			flags += columnDetails(col)
+			flags += columnStats(col.Stats)
 			sb.WriteString(fmt.Sprintf("  - %s %s%s\n", col.Name, col.Type, flags))
 		}
 
+		var checks []string
+		for _, c := range table.Checks {
+			// 값 목록으로 이미 표시된 CHECK는 생략
+			if len(c.Columns) == 1 {
+				if col := findColumn(table, c.Columns[0]); col != nil && len(col.EnumValues) > 0 {
+					continue
+				}
+			}
+			checks = append(checks, c.Expression)
+		}
+		if len(checks) > 0 {
+			sb.WriteString("CHECK 제약조건:\n")
+			for _, c := range checks {
+				sb.WriteString(fmt.Sprintf("  - %s\n", c))
+			}
+		}
+
 		if len(table.Indexes) > 0 {
 			sb.WriteString("인덱스:\n")
 			for _, idx := range table.Indexes {
@@ -251,16 +390,229 @@ func formatSchema(schema *models.Schema) string {
 		if len(table.ForeignKeys) > 0 {
 			sb.WriteString("외래키:\n")
 			for _, fk := range table.ForeignKeys {
-				sb.WriteString(fmt.Sprintf("  - %s -> %s.%s\n", fk.Column, fk.RefTable, fk.RefColumn))
+				sb.WriteString(fmt.Sprintf("  - %s -> %s.%s\n", fk.Column, models.QualifiedName(fk.RefSchema, fk.RefTable), fk.RefColumn))
 			}
 		}
 		sb.WriteString("\n")
 	}
 
+	formatObjects(&sb, schema)
 	return sb.String()
 }
 
-// parseQueryResponse AI 응답 파싱
+// maxEnumValues 프롬프트에 넣는 컬럼 값 목록의 최대 개수
+const maxEnumValues = 50
+
+// columnDetails 도메인, 콜레이션, 계산식, 허용 값 목록 표시
+func columnDetails(col models.Column) string {
+	var details string
+	if col.Domain != "" {
+		details += " [도메인 " + col.Domain + "]"
+	}
+	if col.Collation != "" {
+		details += " [COLLATE " + col.Collation + "]"
+	}
+	if col.Generated != "" {
+		details += " [계산: " + col.Generated + "]"
+	}
+	if len(col.EnumValues) > 0 {
+		values := col.EnumValues
+		more := ""
+		if len(values) > maxEnumValues {
+			more = fmt.Sprintf(", ... 외 %d개", len(values)-maxEnumValues)
+			values = values[:maxEnumValues]
+		}
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+		}
+		details += " [값: " + strings.Join(quoted, ", ") + more + "]"
+	}
+	return details
+}
+
+// tableStats 추정 행 수와 크기 표시 (통계가 없으면 빈 문자열)
+func tableStats(stats *models.TableStats) string {
+	if stats == nil {
+		return ""
+	}
+	var parts []string
+	if stats.Rows >= 0 {
+		parts = append(parts, "약 "+formatCount(stats.Rows)+"행")
+	}
+	if stats.SizeBytes > 0 {
+		parts = append(parts, formatBytes(stats.SizeBytes))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// maxStatsValue 프롬프트에 넣는 히스토그램 값의 최대 길이
+const maxStatsValue = 40
+
+// columnStats 고유 값 수, NULL 비율, 히스토그램의 값 범위 표시
+func columnStats(stats *models.ColumnStats) string {
+	if stats == nil {
+		return ""
+	}
+	var parts []string
+	if stats.Distinct > 0 {
+		parts = append(parts, "고유 값 약 "+formatCount(stats.Distinct))
+	}
+	if stats.NullFraction > 0 {
+		parts = append(parts, fmt.Sprintf("NULL %.0f%%", stats.NullFraction*100))
+	}
+	if n := len(stats.Histogram); n >= 2 {
+		parts = append(parts, "범위 "+statsValue(stats.Histogram[0])+" ~ "+statsValue(stats.Histogram[n-1]))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
+func statsValue(v string) string {
+	if len([]rune(v)) > maxStatsValue {
+		return string([]rune(v)[:maxStatsValue]) + "..."
+	}
+	return v
+}
+
+// hasStats 테이블이나 컬럼 통계가 하나라도 있는지
+func hasStats(schema *models.Schema) bool {
+	for _, table := range schema.Tables {
+		if table.Stats != nil {
+			return true
+		}
+		for _, col := range table.Columns {
+			if col.Stats != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// statsRequirement 통계가 있을 때 쿼리 생성 요구사항에 추가할 항목 (prefix는 번호)
+func statsRequirement(schema *models.Schema, prefix string) string {
+	if !hasStats(schema) {
+		return ""
+	}
+	return prefix + "테이블의 (약 N행)과 컬럼의 [고유 값, NULL 비율, 범위] 통계를 참고해 선택도가 높은 조건과 인덱스 컬럼으로 먼저 범위를 좁히고, 작은 결과 집합부터 JOIN하며, 큰 테이블의 전체 스캔을 피하세요 (통계는 추정치입니다)"
+}
+
+// statsAnalysis 통계가 있을 때 쿼리 검증 분석 항목에 추가할 항목 (prefix는 번호)
+func statsAnalysis(schema *models.Schema, prefix string) string {
+	if !hasStats(schema) {
+		return ""
+	}
+	return prefix + "스키마에 표시된 테이블 행 수와 컬럼 통계(고유 값 수, NULL 비율, 값 범위)로 조건별 예상 처리 행 수와 인덱스 선택도를 평가하고, 큰 테이블의 전체 스캔이나 선택도가 낮은 인덱스 사용은 문제점으로 보고"
+}
+
+// formatCount 천 단위 구분 기호를 넣은 숫자
+func formatCount(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	for i := len(s) - 3; i > 0 && s[i-1] != '-'; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// formatBytes 크기를 KB, MB, GB 단위로
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	size := float64(n) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if size < unit {
+			return fmt.Sprintf("%.1f %s", size, suffix)
+		}
+		size /= unit
+	}
+	return fmt.Sprintf("%.1f TB", size)
+}
+
+func findColumn(table models.Table, name string) *models.Column {
+	for i := range table.Columns {
+		if strings.EqualFold(table.Columns[i].Name, name) {
+			return &table.Columns[i]
+		}
+	}
+	return nil
+}
+
+// maxViewDefinition 프롬프트에 넣는 뷰 정의의 최대 길이
+const maxViewDefinition = 500
+
+// formatObjects 뷰, 사용자 정의 타입, 시퀀스, 함수/프로시저, 트리거를 스키마 문자열에 추가
+// 루틴과 트리거는 본문 없이 시그니처만, enum 타입 값은 컬럼에 표시하므로 이름만 넣습니다.
+func formatObjects(sb *strings.Builder, schema *models.Schema) {
+	for _, view := range schema.Views {
+		kind := "뷰"
+		if view.Materialized {
+			kind = "구체화된 뷰"
+		}
+		sb.WriteString(fmt.Sprintf("%s: %s\n", kind, view.QualifiedName()))
+		sb.WriteString("컬럼:\n")
+		for _, col := range view.Columns {
+			sb.WriteString(fmt.Sprintf("  - %s %s\n", col.Name, col.Type))
+		}
+		if view.Definition != "" {
+			definition := strings.Join(strings.Fields(view.Definition), " ")
+			if len([]rune(definition)) > maxViewDefinition {
+				definition = string([]rune(definition)[:maxViewDefinition]) + " ..."
+			}
+			sb.WriteString("정의: " + definition + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(schema.Types) > 0 {
+		sb.WriteString("사용자 정의 타입:\n")
+		for _, t := range schema.Types {
+			detail := string(t.Kind)
+			if t.Kind == models.TypeDomain {
+				detail += " " + t.BaseType
+				if t.Check != "" {
+					detail += ", CHECK " + t.Check
+				}
+			}
+			sb.WriteString(fmt.Sprintf("  - %s (%s)\n", t.QualifiedName(), detail))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(schema.Sequences) > 0 {
+		sb.WriteString("시퀀스:\n")
+		for _, seq := range schema.Sequences {
+			sb.WriteString(fmt.Sprintf("  - %s (INCREMENT %d)\n", seq.QualifiedName(), seq.Increment))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(schema.Routines) > 0 {
+		sb.WriteString("함수/프로시저:\n")
+		for _, r := range schema.Routines {
+			sb.WriteString(fmt.Sprintf("  - %s %s\n", r.Kind, r.Signature()))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(schema.Triggers) > 0 {
+		sb.WriteString("트리거:\n")
+		for _, t := range schema.Triggers {
+			sb.WriteString(fmt.Sprintf("  - %s: %s %s ON %s\n", models.QualifiedName(t.Schema, t.Name),
+				t.Timing, strings.Join(t.Events, " OR "), models.QualifiedName(t.Schema, t.Table)))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// parseQueryResponse "SQL:/설명:/최적화 팁:" 텍스트 형식 응답 파싱 (JSON 응답 처리 실패 시 대체용)
 func parseQueryResponse(response string) (query, explanation string, tips []string) {
 	lines := strings.Split(response, "\n")
 
@@ -316,14 +668,13 @@ func (o *OllamaProvider) ValidateQuery(ctx context.Context, query string, schema
 	prompt := buildValidatePrompt(query, schema)
 
 	start := time.Now()
-	response, err := o.generate(ctx, prompt)
+	response, err := o.generate(ctx, prompt, true, models.ModelOptions{})
 	if err != nil {
 		return nil, err
 	}
-	elapsed := time.Since(start).Milliseconds()
 
-	validation := parseValidationResponse(response, query)
-	validation.AIResponseTime = elapsed
+	validation := parseValidationResult(ctx, o.generator(models.ModelOptions{}), response, query)
+	validation.AIResponseTime = time.Since(start).Milliseconds()
 
 	return validation, nil
 }
@@ -346,36 +697,14 @@ func buildValidatePrompt(query string, schema *models.Schema) string {
 3. 발견된 문제점 (type: error/warning/info)
 4. 인덱스 활용 여부
 5. 더 최적화된 쿼리가 있다면 제안
-6. 예상 실행 계획
-
-## 응답 형식 (반드시 이 형식을 따라주세요):
-유효성: (true 또는 false)
-점수: (0-100 숫자만)
-
-문제점:
-- [error] (문제 설명) | 위치: (위치) | 해결: (해결방안)
-- [warning] (문제 설명) | 위치: (위치) | 해결: (해결방안)
-- [info] (문제 설명) | 위치: (위치) | 해결: (해결방안)
+6. 예상 실행 계획%s
 
-인덱스 활용:
-- (사용 가능한 인덱스1)
-- (사용 가능한 인덱스2)
-
-최적화된 쿼리:
-(더 나은 쿼리가 있으면 작성, 없으면 "원본 쿼리가 최적입니다")
-
-실행 계획:
-(예상 실행 계획 설명)
-
-예상 시간: (빠름/보통/느림)
-
-개선 제안:
-- (제안1)
-- (제안2)
-`, query, schemaStr)
+## 응답 형식 (score는 0-100 정수, 더 나은 쿼리가 없으면 optimized_query에 원본 쿼리):
+%s
+`, query, schemaStr, statsAnalysis(schema, "\n7. "), jsonInstruction(validationResponseFormat))
 }
 
-// parseValidationResponse 검증 응답 파싱
+// parseValidationResponse 텍스트 형식 검증 응답 파싱 (JSON 응답 처리 실패 시 대체용)
 func parseValidationResponse(response string, originalQuery string) *models.QueryValidation {
 	validation := &models.QueryValidation{
 		OriginalQuery:  originalQuery,
@@ -0,0 +1,393 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sql-genius/pkg/models"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// OpenAICompatibleProvider OpenAI chat completions 프로토콜을 사용하는 AI 제공자
+// vLLM, LM Studio, llama.cpp server, LocalAI, Azure OpenAI 등 임의의 엔드포인트에서 동작합니다.
+type OpenAICompatibleProvider struct {
+	name        string
+	endpoint    string
+	model       string
+	apiKey      string
+	headers     map[string]string
+	temperature float64
+	maxTokens   int
+	client      *http.Client
+
+	// response_format을 거부한 서버면 이후 JSON 모드 없이 프롬프트 지시만 사용
+	// (400 응답 본문이 response_format/json_object를 언급할 때만 설정)
+	jsonModeUnsupported atomic.Bool
+}
+
+type chatRequest struct {
+	Model       string        `json:"model,omitempty"`
+	Messages    []chatMessage `json:"messages"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	Temperature float64       `json:"temperature"`
+	Stream      bool          `json:"stream,omitempty"`
+
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+}
+
+type responseFormat struct {
+	Type string `json:"type"` // json_object
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatResponse struct {
+	ID      string `json:"id"`
+	Choices []struct {
+		Message struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// chatStreamChunk SSE 스트림의 data 항목
+type chatStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// NewOpenAICompatibleProvider OpenAI 호환 제공자 생성
+// API 키는 선택 사항이며, 지정하면 Authorization: Bearer 헤더로 전송합니다.
+func NewOpenAICompatibleProvider(config models.AIConfig) (*OpenAICompatibleProvider, error) {
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("OpenAI 호환 제공자는 엔드포인트가 필요합니다 (예: http://localhost:8000/v1)")
+	}
+	if _, err := url.Parse(config.Endpoint); err != nil {
+		return nil, fmt.Errorf("잘못된 엔드포인트: %w", err)
+	}
+
+	return newOpenAICompatible("OpenAI 호환", config), nil
+}
+
+func newOpenAICompatible(name string, config models.AIConfig) *OpenAICompatibleProvider {
+	temperature := 0.1 // 낮은 temperature로 일관된 결과
+	if config.Temperature != nil {
+		temperature = *config.Temperature
+	}
+
+	maxTokens := config.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 2048
+	}
+
+	return &OpenAICompatibleProvider{
+		name:        name,
+		endpoint:    strings.TrimSuffix(config.Endpoint, "/"),
+		model:       config.Model,
+		apiKey:      config.APIKey,
+		headers:     config.Headers,
+		temperature: temperature,
+		maxTokens:   maxTokens,
+		client: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+	}
+}
+
+func (o *OpenAICompatibleProvider) Name() string {
+	return o.name
+}
+
+func (o *OpenAICompatibleProvider) IsAvailable(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", o.url("/models"), nil)
+	if err != nil {
+		return false
+	}
+	o.setHeaders(req)
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// url 엔드포인트 경로 뒤에 API 경로를 붙임 (Azure의 ?api-version= 같은 쿼리 문자열은 유지)
+func (o *OpenAICompatibleProvider) url(path string) string {
+	u, err := url.Parse(o.endpoint)
+	if err != nil {
+		return o.endpoint + path
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	return u.String()
+}
+
+func (o *OpenAICompatibleProvider) setHeaders(req *http.Request) {
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+	for k, v := range o.headers {
+		req.Header.Set(k, v)
+	}
+}
+
+// newRequest chat/completions 요청 생성 (opts의 모델 설정이 있으면 제공자 설정 대신 사용)
+func (o *OpenAICompatibleProvider) newRequest(ctx context.Context, prompt string, stream, jsonOutput bool, opts models.ModelOptions) (*http.Request, error) {
+	reqBody := chatRequest{
+		Model: o.model,
+		Messages: []chatMessage{
+			{
+				Role:    "system",
+				Content: "당신은 SQL 전문가입니다. 사용자 요청에 맞는 최적화된 SQL 쿼리를 생성합니다.",
+			},
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+		MaxTokens:   o.maxTokens,
+		Temperature: o.temperature,
+		Stream:      stream,
+	}
+	if opts.Model != "" {
+		reqBody.Model = opts.Model
+	}
+	if opts.Temperature != nil {
+		reqBody.Temperature = *opts.Temperature
+	}
+	if opts.MaxTokens > 0 {
+		reqBody.MaxTokens = opts.MaxTokens
+	}
+	if jsonOutput {
+		reqBody.ResponseFormat = &responseFormat{Type: "json_object"}
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("JSON 마샬링 실패: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.url("/chat/completions"), bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("요청 생성 실패: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	o.setHeaders(req)
+
+	return req, nil
+}
+
+func (o *OpenAICompatibleProvider) generate(ctx context.Context, prompt string, jsonOutput bool, opts models.ModelOptions) (string, error) {
+	useJSONMode := jsonOutput && !o.jsonModeUnsupported.Load()
+	req, err := o.newRequest(ctx, prompt, false, useJSONMode, opts)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("응답 읽기 실패: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusBadRequest && useJSONMode && rejectsJSONMode(body) {
+		o.jsonModeUnsupported.Store(true)
+		return o.generate(ctx, prompt, jsonOutput, opts)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API 오류 (상태 코드: %d): %s", resp.StatusCode, string(body))
+	}
+
+	var chatResp chatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return "", fmt.Errorf("JSON 파싱 실패: %w", err)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return "", fmt.Errorf("응답이 비어있습니다")
+	}
+
+	return chatResp.Choices[0].Message.Content, nil
+}
+
+// generateStream SSE 스트림으로 응답을 받아 조각마다 onToken 호출
+func (o *OpenAICompatibleProvider) generateStream(ctx context.Context, prompt string, jsonOutput bool, opts models.ModelOptions, onToken TokenHandler) (string, error) {
+	useJSONMode := jsonOutput && !o.jsonModeUnsupported.Load()
+	req, err := o.newRequest(ctx, prompt, true, useJSONMode, opts)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusBadRequest && useJSONMode && rejectsJSONMode(body) {
+			o.jsonModeUnsupported.Store(true)
+			return o.generateStream(ctx, prompt, jsonOutput, opts, onToken)
+		}
+		return "", fmt.Errorf("API 오류 (상태 코드: %d): %s", resp.StatusCode, string(body))
+	}
+
+	var sb strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk chatStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", fmt.Errorf("JSON 파싱 실패: %w", err)
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			sb.WriteString(choice.Delta.Content)
+			if onToken != nil {
+				onToken(choice.Delta.Content)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("응답 읽기 실패: %w", err)
+	}
+
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("응답이 비어있습니다")
+	}
+	return sb.String(), nil
+}
+
+// generator opts로 요청하는 generateFunc (JSON 복구 재요청에도 같은 모델 설정 사용)
+// rejectsJSONMode 400 응답이 JSON 모드(response_format)를 거부한 것인지
+// max_tokens, 모델 이름 등 다른 요청 오류로 JSON 모드를 끄지 않도록 본문으로 판단합니다.
+func rejectsJSONMode(body []byte) bool {
+	text := strings.ToLower(string(body))
+	return strings.Contains(text, "response_format") || strings.Contains(text, "json_object")
+}
+
+func (o *OpenAICompatibleProvider) generator(opts models.ModelOptions) generateFunc {
+	return func(ctx context.Context, prompt string, jsonOutput bool) (string, error) {
+		return o.generate(ctx, prompt, jsonOutput, opts)
+	}
+}
+
+func (o *OpenAICompatibleProvider) GenerateQuery(ctx context.Context, req *models.QueryRequest) (*models.QueryResponse, error) {
+	prompt := buildQueryPrompt(req)
+	generate := o.generator(req.ModelOptions)
+
+	start := time.Now()
+	response, err := generate(ctx, prompt, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseQueryResult(ctx, generate, response)
+	if err != nil {
+		return nil, err
+	}
+	result.ExecuteTime = time.Since(start).Milliseconds()
+
+	return result, nil
+}
+
+func (o *OpenAICompatibleProvider) GenerateQueryStream(ctx context.Context, req *models.QueryRequest, onToken TokenHandler) (*models.QueryResponse, error) {
+	prompt := buildQueryPrompt(req)
+
+	start := time.Now()
+	response, err := o.generateStream(ctx, prompt, true, req.ModelOptions, onToken)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseQueryResult(ctx, o.generator(req.ModelOptions), response)
+	if err != nil {
+		return nil, err
+	}
+	result.ExecuteTime = time.Since(start).Milliseconds()
+
+	return result, nil
+}
+
+func (o *OpenAICompatibleProvider) OptimizeQuery(ctx context.Context, query string, schema *models.Schema) (*models.QueryResponse, error) {
+	prompt := buildOptimizePrompt(query, schema)
+
+	start := time.Now()
+	response, err := o.generate(ctx, prompt, true, models.ModelOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseQueryResult(ctx, o.generator(models.ModelOptions{}), response)
+	if err != nil {
+		return nil, err
+	}
+	result.ExecuteTime = time.Since(start).Milliseconds()
+
+	return result, nil
+}
+
+func (o *OpenAICompatibleProvider) ExplainQuery(ctx context.Context, query string) (string, error) {
+	prompt := fmt.Sprintf(`다음 SQL 쿼리를 한국어로 설명해주세요:
+
+%s
+
+설명:`, query)
+
+	return o.generate(ctx, prompt, false, models.ModelOptions{})
+}
+
+func (o *OpenAICompatibleProvider) ValidateQuery(ctx context.Context, query string, schema *models.Schema) (*models.QueryValidation, error) {
+	prompt := buildValidatePrompt(query, schema)
+
+	start := time.Now()
+	response, err := o.generate(ctx, prompt, true, models.ModelOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	validation := parseValidationResult(ctx, o.generator(models.ModelOptions{}), response, query)
+	validation.AIResponseTime = time.Since(start).Milliseconds()
+
+	return validation, nil
+}
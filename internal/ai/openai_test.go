@@ -0,0 +1,148 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sql-genius/pkg/models"
+	"sync"
+	"testing"
+)
+
+// stubChat chat/completions 요청마다 handle의 (상태 코드, 본문 또는 응답 내용)을 돌려주는 서버
+type stubChat struct {
+	mu       sync.Mutex
+	requests []chatRequest
+	handle   func(req chatRequest) (int, string)
+}
+
+func (s *stubChat) start(t *testing.T) *OpenAICompatibleProvider {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.mu.Unlock()
+
+		status, content := s.handle(req)
+		if status != http.StatusOK {
+			http.Error(w, content, status)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []interface{}{
+				map[string]interface{}{"message": map[string]string{"role": "assistant", "content": content}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	p, err := NewOpenAICompatibleProvider(models.AIConfig{Endpoint: srv.URL + "/v1", Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func queryRequest() *models.QueryRequest {
+	return &models.QueryRequest{Prompt: "사용자 수", Schema: models.Schema{DBType: models.PostgreSQL}, QueryType: "SELECT"}
+}
+
+func TestOpenAIJSONModeFallback(t *testing.T) {
+	stub := &stubChat{handle: func(req chatRequest) (int, string) {
+		if req.ResponseFormat != nil {
+			return http.StatusBadRequest, `{"error":{"message":"Unsupported parameter: 'response_format'"}}`
+		}
+		return http.StatusOK, `{"query": "SELECT COUNT(*) FROM users"}`
+	}}
+	p := stub.start(t)
+
+	resp, err := p.GenerateQuery(context.Background(), queryRequest())
+	if err != nil {
+		t.Fatalf("GenerateQuery() error = %v", err)
+	}
+	if resp.Query != "SELECT COUNT(*) FROM users" {
+		t.Errorf("Query = %q", resp.Query)
+	}
+	if !p.jsonModeUnsupported.Load() {
+		t.Error("jsonModeUnsupported = false after response_format was rejected")
+	}
+
+	// 이후 요청은 처음부터 JSON 모드 없이 보냄
+	before := len(stub.requests)
+	if _, err := p.GenerateQuery(context.Background(), queryRequest()); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(stub.requests) - before; got != 1 || stub.requests[before].ResponseFormat != nil {
+		t.Errorf("later request count = %d, response_format = %v, want 1 request without it", got, stub.requests[before].ResponseFormat)
+	}
+}
+
+func TestOpenAIOtherBadRequestKeepsJSONMode(t *testing.T) {
+	stub := &stubChat{handle: func(req chatRequest) (int, string) {
+		if req.MaxTokens > 100000 {
+			return http.StatusBadRequest, `{"error":{"message":"max_tokens is too large"}}`
+		}
+		return http.StatusOK, `{"query": "SELECT 1"}`
+	}}
+	p := stub.start(t)
+
+	req := queryRequest()
+	req.MaxTokens = 1000000
+	if _, err := p.GenerateQuery(context.Background(), req); err == nil {
+		t.Fatal("GenerateQuery() error = nil, want API error")
+	}
+	if p.jsonModeUnsupported.Load() {
+		t.Error("jsonModeUnsupported = true after an unrelated 400")
+	}
+
+	if _, err := p.GenerateQuery(context.Background(), queryRequest()); err != nil {
+		t.Fatal(err)
+	}
+	if last := stub.requests[len(stub.requests)-1]; last.ResponseFormat == nil {
+		t.Error("later request sent without response_format")
+	}
+}
+
+func TestParseQueryResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		repaired string
+		want     string
+		noQuery  bool
+	}{
+		{"JSON", `{"query": "SELECT 1"}`, "", "SELECT 1", false},
+		{"수정 요청으로 복구", `SELECT 1 입니다`, `{"query": "SELECT 2"}`, "SELECT 2", false},
+		{"텍스트 형식", "SQL:\nSELECT 3\n설명:\n하나", "형식 없음", "SELECT 3", false},
+		{"쿼리 없음", "죄송합니다. 도와드릴 수 없습니다.", "여전히 형식 없음", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generate := func(ctx context.Context, prompt string, jsonOutput bool) (string, error) {
+				if tt.repaired == "" {
+					return "", fmt.Errorf("unexpected repair request")
+				}
+				return tt.repaired, nil
+			}
+			resp, err := parseQueryResult(context.Background(), generate, tt.response)
+			if tt.noQuery {
+				if !errors.Is(err, ErrNoQuery) {
+					t.Errorf("parseQueryResult() = %+v, %v, want ErrNoQuery", resp, err)
+				}
+				return
+			}
+			if err != nil || resp.Query != tt.want {
+				t.Errorf("parseQueryResult() = %+v, %v, want query %q", resp, err, tt.want)
+			}
+		})
+	}
+}
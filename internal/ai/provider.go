@@ -5,11 +5,17 @@ import (
 	"sql-genius/pkg/models"
 )
 
+// TokenHandler 스트리밍 중 생성된 텍스트 조각을 받는 콜백
+type TokenHandler func(token string)
+
 // Provider AI 제공자 인터페이스
 type Provider interface {
 	// GenerateQuery 자연어를 SQL 쿼리로 변환
 	GenerateQuery(ctx context.Context, req *models.QueryRequest) (*models.QueryResponse, error)
 	
+	// GenerateQueryStream 자연어를 SQL 쿼리로 변환 (생성 중인 텍스트를 onToken으로 실시간 전달)
+	GenerateQueryStream(ctx context.Context, req *models.QueryRequest, onToken TokenHandler) (*models.QueryResponse, error)
+	
 	// OptimizeQuery 쿼리 최적화 제안
 	OptimizeQuery(ctx context.Context, query string, schema *models.Schema) (*models.QueryResponse, error)
 	
@@ -33,6 +39,8 @@ func NewProvider(config models.AIConfig) (Provider, error) {
 		return NewOllamaProvider(config)
 	case models.Groq:
 		return NewGroqProvider(config)
+	case models.OpenAICompatible:
+		return NewOpenAICompatibleProvider(config)
 	default:
 		return NewOllamaProvider(config) // 기본값: Ollama
 	}
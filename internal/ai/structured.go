@@ -0,0 +1,252 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// generateFunc 프롬프트를 보내고 응답 텍스트를 받는 함수 (jsonOutput: JSON 모드 요청)
+type generateFunc func(ctx context.Context, prompt string, jsonOutput bool) (string, error)
+
+// queryResponseFormat 쿼리 생성/최적화 응답 JSON 형식 (models.QueryResponse)
+const queryResponseFormat = `{
+  "query": "SQL 쿼리 (문자열, 값은 :name 형식 파라미터)",
+  "explanation": "간단한 설명 (문자열)",
+  "tips": ["최적화 팁 (문자열 배열)"],
+  "params": [
+    {"name": "파라미터 이름 (콜론 제외)", "type": "값 타입", "description": "설명", "value": "요청에 나온 값 (없으면 빈 문자열)"}
+  ]
+}`
+
+// validationResponseFormat 쿼리 검증 응답 JSON 형식 (models.QueryValidation)
+const validationResponseFormat = `{
+  "is_valid": true,
+  "score": 0,
+  "issues": [
+    {"type": "error | warning | info", "message": "문제 설명", "location": "위치", "suggestion": "해결방안"}
+  ],
+  "index_usage": ["사용 가능한 인덱스"],
+  "optimized_query": "더 나은 쿼리 (없으면 원본 쿼리)",
+  "execution_plan": "예상 실행 계획 설명",
+  "estimated_time": "빠름 | 보통 | 느림",
+  "suggestions": ["개선 제안"]
+}`
+
+// jsonInstruction 응답 형식 안내 문구
+func jsonInstruction(format string) string {
+	return "다음 형식의 JSON 객체 하나만 출력하세요. 마크다운, 코드 블록, 다른 설명은 포함하지 마세요.\n" + format
+}
+
+// queryJSON 쿼리 응답 디코딩용 (누락 필드 판별을 위해 포인터 사용)
+type queryJSON struct {
+	Query       *string             `json:"query"`
+	Explanation string              `json:"explanation"`
+	Tips        []string            `json:"tips"`
+	Params      []models.QueryParam `json:"params"`
+}
+
+// validationJSON 검증 응답 디코딩용
+type validationJSON struct {
+	IsValid        *bool          `json:"is_valid"`
+	Score          *float64       `json:"score"`
+	Issues         []models.Issue `json:"issues"`
+	IndexUsage     []string       `json:"index_usage"`
+	OptimizedQuery string         `json:"optimized_query"`
+	ExecutionPlan  string         `json:"execution_plan"`
+	EstimatedTime  string         `json:"estimated_time"`
+	Suggestions    []string       `json:"suggestions"`
+}
+
+// extractJSON 응답에서 JSON 객체 부분만 추출 (코드 블록이나 앞뒤 문장 제거)
+func extractJSON(response string) (string, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return "", fmt.Errorf("JSON 객체를 찾을 수 없습니다")
+	}
+	return response[start : end+1], nil
+}
+
+// decodeQueryJSON JSON 응답을 쿼리 응답으로 변환하고 형식 검증
+func decodeQueryJSON(response string) (*models.QueryResponse, error) {
+	raw, err := extractJSON(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed queryJSON
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return nil, fmt.Errorf("JSON 파싱 실패: %w", err)
+	}
+	if parsed.Query == nil {
+		return nil, fmt.Errorf("query 필드가 없습니다")
+	}
+
+	query := strings.TrimSpace(*parsed.Query)
+	query = strings.TrimPrefix(query, "```sql")
+	query = strings.TrimPrefix(query, "```")
+	query = strings.TrimSuffix(query, "```")
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("query 필드가 비어 있습니다")
+	}
+
+	tips := []string{}
+	for _, tip := range parsed.Tips {
+		if tip = strings.TrimSpace(tip); tip != "" {
+			tips = append(tips, tip)
+		}
+	}
+
+	var params []models.QueryParam
+	for _, param := range parsed.Params {
+		param.Name = strings.TrimLeft(strings.TrimSpace(param.Name), ":@")
+		if param.Name == "" {
+			continue
+		}
+		param.Type = strings.TrimSpace(param.Type)
+		param.Description = strings.TrimSpace(param.Description)
+		param.Value = strings.TrimSpace(param.Value)
+		params = append(params, param)
+	}
+
+	return &models.QueryResponse{
+		Query:       query,
+		Explanation: strings.TrimSpace(parsed.Explanation),
+		Tips:        tips,
+		Params:      params,
+	}, nil
+}
+
+// decodeValidationJSON JSON 응답을 검증 결과로 변환하고 형식 검증
+func decodeValidationJSON(response string, originalQuery string) (*models.QueryValidation, error) {
+	raw, err := extractJSON(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed validationJSON
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return nil, fmt.Errorf("JSON 파싱 실패: %w", err)
+	}
+	if parsed.IsValid == nil {
+		return nil, fmt.Errorf("is_valid 필드가 없습니다")
+	}
+	if parsed.Score == nil {
+		return nil, fmt.Errorf("score 필드가 없습니다")
+	}
+	if *parsed.Score < 0 || *parsed.Score > 100 {
+		return nil, fmt.Errorf("score는 0-100 범위여야 합니다: %v", *parsed.Score)
+	}
+
+	issues := []models.Issue{}
+	for _, issue := range parsed.Issues {
+		switch issue.Type {
+		case "error", "warning", "info":
+		default:
+			return nil, fmt.Errorf("issues[].type은 error, warning, info 중 하나여야 합니다: %q", issue.Type)
+		}
+		if strings.TrimSpace(issue.Message) == "" {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+
+	optimized := strings.TrimSpace(parsed.OptimizedQuery)
+	optimized = strings.TrimPrefix(optimized, "```sql")
+	optimized = strings.TrimSuffix(optimized, "```")
+	optimized = strings.TrimSpace(optimized)
+	if optimized == "" {
+		optimized = originalQuery
+	}
+
+	return &models.QueryValidation{
+		IsValid:        *parsed.IsValid,
+		Score:          int(math.Round(*parsed.Score)),
+		OriginalQuery:  originalQuery,
+		OptimizedQuery: optimized,
+		Issues:         issues,
+		Suggestions:    nonEmpty(parsed.Suggestions),
+		IndexUsage:     nonEmpty(parsed.IndexUsage),
+		ExecutionPlan:  strings.TrimSpace(parsed.ExecutionPlan),
+		EstimatedTime:  strings.TrimSpace(parsed.EstimatedTime),
+	}, nil
+}
+
+func nonEmpty(items []string) []string {
+	result := []string{}
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// buildRepairPrompt 형식이 잘못된 응답을 JSON으로 다시 작성하도록 요청
+func buildRepairPrompt(response string, err error, format string) string {
+	return fmt.Sprintf(`이전 응답이 요구한 JSON 형식이 아닙니다 (%v).
+아래 응답의 내용을 유지한 채 형식에 맞게 다시 작성해주세요.
+
+## 이전 응답:
+%s
+
+## 응답 형식:
+%s
+`, err, response, jsonInstruction(format))
+}
+
+// ErrNoQuery 수정 요청과 텍스트 파서로도 AI 응답에서 쿼리를 찾지 못함 (다시 생성하면 나을 수 있음)
+var ErrNoQuery = errors.New("AI 응답에서 쿼리를 찾을 수 없습니다")
+
+// parseQueryResult JSON 응답 파싱 → 실패 시 수정 요청 1회 → 그래도 실패하면 텍스트 파서로 대체
+// 텍스트 파서로도 쿼리를 찾지 못하면 ErrNoQuery를 반환합니다.
+func parseQueryResult(ctx context.Context, generate generateFunc, response string) (*models.QueryResponse, error) {
+	result, err := decodeQueryJSON(response)
+	if err == nil {
+		return result, nil
+	}
+
+	if ctx.Err() == nil {
+		repaired, genErr := generate(ctx, buildRepairPrompt(response, err, queryResponseFormat), true)
+		if genErr == nil {
+			if result, err := decodeQueryJSON(repaired); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	query, explanation, tips := parseQueryResponse(response)
+	if query == "" {
+		return nil, fmt.Errorf("%w (%v)", ErrNoQuery, err)
+	}
+	return &models.QueryResponse{
+		Query:       query,
+		Explanation: explanation,
+		Tips:        tips,
+	}, nil
+}
+
+// parseValidationResult 검증 응답 파싱 (parseQueryResult와 같은 순서로 대체)
+func parseValidationResult(ctx context.Context, generate generateFunc, response string, originalQuery string) *models.QueryValidation {
+	validation, err := decodeValidationJSON(response, originalQuery)
+	if err == nil {
+		return validation
+	}
+
+	if ctx.Err() == nil {
+		repaired, genErr := generate(ctx, buildRepairPrompt(response, err, validationResponseFormat), true)
+		if genErr == nil {
+			if validation, err := decodeValidationJSON(repaired, originalQuery); err == nil {
+				return validation
+			}
+		}
+	}
+
+	return parseValidationResponse(response, originalQuery)
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sql-genius/internal/db"
+	"sql-genius/pkg/models"
+	"sync"
+)
+
+// fileVersion 스키마 캐시 파일 형식 버전
+const fileVersion = 1
+
+// file 스키마 캐시 파일 내용
+type file struct {
+	Version int `json:"version"`
+	db.Snapshot
+}
+
+// Store 연결별 스키마 스냅숏 파일 (디렉터리에 연결 지문마다 JSON 파일 하나, 권한 0600)
+// 스키마 정의만 저장하며 비밀번호 등 연결 정보는 저장하지 않습니다.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// DefaultDir 사용자 캐시 디렉터리의 스키마 캐시 (예: ~/.cache/sql-genius/schema)
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("캐시 디렉터리를 찾을 수 없습니다: %w", err)
+	}
+	return filepath.Join(dir, "sql-genius", "schema"), nil
+}
+
+// Open dir에 스냅숏을 저장하는 Store (디렉터리는 처음 저장할 때 생성)
+func Open(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir 캐시 디렉터리
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Key 연결 지문 (같은 DB의 같은 추출 범위면 같은 값)
+// DB 종류, 주소, 데이터베이스, 사용자, 드라이버 파라미터, SSH 배스천과 추출 범위로 만들며
+// 비밀번호와 TLS, 타임아웃처럼 스키마 내용에 영향이 없는 설정은 넣지 않습니다.
+func Key(config models.DBConfig, opts db.ExtractOptions) string {
+	database := config.Database
+	if config.Type == models.SQLite {
+		if abs, err := filepath.Abs(database); err == nil {
+			database = abs
+		}
+	}
+
+	fingerprint := struct {
+		Type        models.DBType     `json:"type"`
+		Host        string            `json:"host"`
+		Port        int               `json:"port"`
+		Database    string            `json:"database"`
+		User        string            `json:"user"`
+		ServiceName string            `json:"service_name"`
+		SID         string            `json:"sid"`
+		Params      map[string]string `json:"params"`
+		SSH         string            `json:"ssh"`
+		Extract     db.ExtractOptions `json:"extract"`
+	}{
+		Type:        config.Type,
+		Host:        config.Host,
+		Port:        config.Port,
+		Database:    database,
+		User:        config.User,
+		ServiceName: config.ServiceName,
+		SID:         config.SID,
+		Params:      config.Params,
+		Extract:     opts,
+	}
+	if config.SSH != nil {
+		fingerprint.SSH = fmt.Sprintf("%s@%s:%d", config.SSH.User, config.SSH.Host, config.SSH.Port)
+	}
+
+	data, _ := json.Marshal(fingerprint)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// Load 연결 지문의 스냅숏 (없으면 nil)
+func (s *Store) Load(key string) (*db.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("스키마 캐시 읽기 실패: %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("스키마 캐시 파싱 실패: %w", err)
+	}
+	// 다른 형식 버전의 캐시는 없는 것으로 보고 다시 추출
+	if f.Version != fileVersion || f.Schema == nil {
+		return nil, nil
+	}
+	return &f.Snapshot, nil
+}
+
+// Save 연결 지문의 스냅숏 저장 (임시 파일에 쓴 뒤 교체)
+func (s *Store) Save(key string, snapshot *db.Snapshot) error {
+	data, err := json.Marshal(file{Version: fileVersion, Snapshot: *snapshot})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("스키마 캐시 디렉터리 생성 실패: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".schema-*.tmp")
+	if err != nil {
+		return fmt.Errorf("스키마 캐시 쓰기 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("스키마 캐시 쓰기 실패: %w", err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("스키마 캐시 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("스키마 캐시 쓰기 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("스키마 캐시 쓰기 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
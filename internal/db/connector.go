@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sql-genius/pkg/models"
+	"time"
 )
 
 // Connector 데이터베이스 연결 인터페이스
@@ -18,12 +19,37 @@ type Connector interface {
 	// Ping 연결 상태 확인
 	Ping(ctx context.Context) error
 
-	// ExtractSchema 스키마 추출
-	ExtractSchema(ctx context.Context) (*models.Schema, error)
+	// ExtractSchema 스키마 추출 (opts로 스키마·테이블 범위 지정)
+	ExtractSchema(ctx context.Context, opts ExtractOptions) (*models.Schema, error)
 
-	// ExecuteQuery 쿼리 실행 (결과 반환)
+	// TableVersions 추출 범위의 테이블과 버전 (DDL 시각 등 테이블 정의가 바뀌면 달라지는 값)
+	// RefreshSnapshot이 캐시와 비교해 바뀐 테이블만 다시 추출할 때 사용합니다.
+	TableVersions(ctx context.Context, opts ExtractOptions) ([]TableVersion, error)
+
+	// ExtractStats 스키마의 테이블과 컬럼에 카탈로그 통계(추정 행 수, 크기, 고유 값 수 등)를 채움
+	// 통계가 수집되지 않은 항목은 비워 둡니다.
+	ExtractStats(ctx context.Context, schema *models.Schema) error
+
+	// ExecuteQuery 쿼리 실행 (결과 반환, 기본 옵션으로 Execute 호출)
 	ExecuteQuery(ctx context.Context, query string) (*QueryResult, error)
 
+	// Execute 실행 정책을 적용해 쿼리 실행 (조회는 읽기 전용 트랜잭션에서 실행)
+	Execute(ctx context.Context, query string, opts ExecOptions) (*QueryResult, error)
+
+	// Policy 연결의 실행 정책
+	Policy() models.ExecPolicy
+
+	// OpenCursor 결과 집합을 반환하는 한 문장을 실행하고 행을 하나씩 읽는 커서 반환
+	// 결과 집합이 없는 문장이면 ErrNoResultSet을 반환합니다.
+	OpenCursor(ctx context.Context, query string, opts ExecOptions) (*Cursor, error)
+
+	// OpenPage 조회 쿼리의 offset개 행 다음부터 opts.MaxRows행을 읽는 커서 반환
+	// 행 범위를 방언의 문법(LIMIT/OFFSET, OFFSET ... FETCH, ROWNUM)으로 쿼리에 넣어 앞 행을 DB가 건너뛰게 합니다.
+	OpenPage(ctx context.Context, query string, offset int, opts ExecOptions) (*Cursor, error)
+
+	// DryRun 트랜잭션 안에서 DML을 실행해 영향을 받는 행을 확인한 뒤 롤백
+	DryRun(ctx context.Context, query string, params Params) (*DryRunResult, error)
+
 	// Explain 실행 계획 조회
 	Explain(ctx context.Context, query string) (string, error)
 
@@ -37,13 +63,22 @@ type Connector interface {
 // QueryResult 쿼리 실행 결과
 type QueryResult struct {
 	Columns      []string        `json:"columns"`
+	ColumnTypes  []ColumnType    `json:"column_types,omitempty"` // 컬럼별 타입과 값 인코딩 종류
 	Rows         [][]interface{} `json:"rows"`
 	RowsAffected int64           `json:"rows_affected"`
-	Duration     int64           `json:"duration"` // ms
+	LastInsertID *int64          `json:"last_insert_id,omitempty"` // MySQL, SQLite INSERT
+	Truncated    bool            `json:"truncated"`                // MaxRows/MaxBytes 제한으로 남은 행을 읽지 않음
+	Duration     int64           `json:"duration"`                 // ms
+
+	More []*QueryResult `json:"more_results,omitempty"` // 여러 문장·배치의 이후 결과 집합
 }
 
 // NewConnector DB 연결자 생성
 func NewConnector(config models.DBConfig) (Connector, error) {
+	if _, err := ParseTLSMode(string(config.TLSMode)); err != nil {
+		return nil, err
+	}
+
 	switch config.Type {
 	case models.MySQL:
 		return NewMySQLConnector(config)
@@ -53,6 +88,8 @@ func NewConnector(config models.DBConfig) (Connector, error) {
 		return NewOracleConnector(config)
 	case models.SQLServer:
 		return NewSQLServerConnector(config)
+	case models.SQLite:
+		return NewSQLiteConnector(config)
 	default:
 		return nil, fmt.Errorf("지원하지 않는 데이터베이스 타입: %s", config.Type)
 	}
@@ -62,17 +99,36 @@ func NewConnector(config models.DBConfig) (Connector, error) {
 type BaseConnector struct {
 	db     *sql.DB
 	config models.DBConfig
+	tunnel *sshTunnel // config.SSH가 있을 때 DB 연결이 지나가는 SSH 연결
+	multi  *sql.DB    // 여러 문장을 한 번에 보내는 전용 연결 (MySQL unrestricted 정책일 때만)
 }
 
 func (b *BaseConnector) GetDB() *sql.DB {
 	return b.db
 }
 
+// Close DB 연결을 닫은 뒤 SSH 터널 종료
 func (b *BaseConnector) Close() error {
+	var err error
 	if b.db != nil {
-		return b.db.Close()
+		err = b.db.Close()
 	}
-	return nil
+	if b.multi != nil {
+		b.multi.Close()
+	}
+	if tunnelErr := b.tunnel.Close(); err == nil {
+		err = tunnelErr
+	}
+	return err
+}
+
+// openTunnel SSH 설정이 있으면 터널을 열어 드라이버 dialer로 쓸 수 있게 함 (없으면 nil)
+func (b *BaseConnector) openTunnel(ctx context.Context) (*sshTunnel, error) {
+	tunnel, err := openTunnel(ctx, b.config.SSH, seconds(b.config.ConnectTimeout, 30*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("SSH 터널 연결 실패: %w", err)
+	}
+	return tunnel, nil
 }
 
 func (b *BaseConnector) Ping(ctx context.Context) error {
@@ -83,3 +139,116 @@ func (b *BaseConnector) Type() models.DBType {
 	return b.config.Type
 }
 
+// queryer *sql.DB, *sql.Tx, *sql.Conn 공통 조회 인터페이스
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// runner 조회와 실행을 모두 하는 *sql.DB, *sql.Tx, *sql.Conn 공통 인터페이스
+type runner interface {
+	queryer
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// runQuery 쿼리를 실행하고 모든 행을 읽음
+func (b *BaseConnector) runQuery(ctx context.Context, q queryer, query string) (*QueryResult, error) {
+	return b.runQueryLimit(ctx, q, Limits{}, query)
+}
+
+// runQueryLimit 쿼리를 실행하고 결과 집합마다 limits까지 행을 읽음
+// 결과 집합이 여러 개면 첫 집합 이후는 More에 담습니다.
+func (b *BaseConnector) runQueryLimit(ctx context.Context, q queryer, limits Limits, query string, args ...interface{}) (*QueryResult, error) {
+	start := time.Now()
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result *QueryResult
+	for {
+		set, err := readRows(rows, b.config.Type, limits)
+		if err != nil {
+			return nil, err
+		}
+		result = mergeResults(result, set)
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result.Duration = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// readRows 현재 결과 집합의 행을 읽음 (제한에 걸리면 Truncated 표시)
+func readRows(rows *sql.Rows, dbType models.DBType, limits Limits) (*QueryResult, error) {
+	c, err := newCursor(rows, dbType, limits, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueryResult{Columns: c.Columns(), ColumnTypes: c.ColumnTypes()}
+	for c.Next() {
+		result.Rows = append(result.Rows, c.Row())
+	}
+	if err := c.Err(); err != nil {
+		return nil, err
+	}
+	result.Truncated = c.Truncated()
+	return result, nil
+}
+
+// runExec 결과 집합이 없는 문장 실행 (영향 행 수, insert면 MySQL·SQLite의 마지막 INSERT ID)
+func (b *BaseConnector) runExec(ctx context.Context, r runner, insert bool, query string, args ...interface{}) (*QueryResult, error) {
+	start := time.Now()
+
+	res, err := r.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueryResult{}
+	if n, err := res.RowsAffected(); err == nil {
+		result.RowsAffected = n
+	}
+	// PostgreSQL, SQL Server, Oracle 드라이버는 LastInsertId를 지원하지 않음 (RETURNING/OUTPUT 사용)
+	if insert && (b.config.Type == models.MySQL || b.config.Type == models.SQLite) {
+		if id, err := res.LastInsertId(); err == nil {
+			result.LastInsertID = &id
+		}
+	}
+
+	result.Duration = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// mergeResults 여러 문장·결과 집합을 하나로 합침
+// 컬럼이 있는 첫 결과 집합이 본문이 되고 이후 집합은 More에 쌓이며, 영향 행 수는 합산합니다.
+func mergeResults(acc, part *QueryResult) *QueryResult {
+	if acc == nil {
+		return part
+	}
+
+	acc.RowsAffected += part.RowsAffected
+	if part.LastInsertID != nil {
+		acc.LastInsertID = part.LastInsertID
+	}
+
+	sets := part.More
+	if len(part.Columns) > 0 {
+		head := *part
+		head.More = nil
+		sets = append([]*QueryResult{&head}, sets...)
+	}
+	if len(acc.Columns) == 0 && len(sets) > 0 {
+		acc.Columns, acc.ColumnTypes, acc.Rows, acc.Truncated = sets[0].Columns, sets[0].ColumnTypes, sets[0].Rows, sets[0].Truncated
+		sets = sets[1:]
+	}
+	acc.More = append(acc.More, sets...)
+	return acc
+}
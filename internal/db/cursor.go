@@ -0,0 +1,272 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sql-genius/internal/schema"
+	"sql-genius/pkg/models"
+	"time"
+)
+
+// ErrNoResultSet 결과 집합을 반환하지 않는 문장을 커서로 열려고 함 (Execute 사용)
+var ErrNoResultSet = errors.New("결과 집합을 반환하지 않는 문장입니다")
+
+// Cursor 결과 행을 하나씩 읽는 커서
+// 행을 모두 메모리에 올리지 않으며, MaxRows/MaxBytes에 도달하면 멈추고 Truncated를 표시합니다.
+// 읽기 전용 트랜잭션이나 연결을 잡고 있으므로 반드시 Close해야 합니다.
+type Cursor struct {
+	rows    *sql.Rows
+	columns []string
+	types   []ColumnType
+	dbType  models.DBType
+	cleanup func()
+	hidden  int // 결과 끝에서 숨기는 내부 컬럼 수 (ROWNUM 페이지의 행 번호)
+
+	maxRows  int
+	maxBytes int64
+
+	row       []interface{}
+	count     int
+	bytes     int64
+	truncated bool
+	err       error
+	start     time.Time
+}
+
+func newCursor(rows *sql.Rows, dbType models.DBType, limits Limits, cleanup func()) (*Cursor, error) {
+	columns, err := rows.Columns()
+	var types []*sql.ColumnType
+	if err == nil {
+		types, err = rows.ColumnTypes()
+	}
+	if err != nil {
+		rows.Close()
+		if cleanup != nil {
+			cleanup()
+		}
+		return nil, err
+	}
+	return &Cursor{
+		rows:     rows,
+		columns:  columns,
+		types:    columnTypes(types, dbType),
+		dbType:   dbType,
+		cleanup:  cleanup,
+		maxRows:  limits.MaxRows,
+		maxBytes: limits.MaxBytes,
+		start:    time.Now(),
+	}, nil
+}
+
+// Columns 결과 컬럼 이름
+func (c *Cursor) Columns() []string {
+	return c.columns
+}
+
+// ColumnTypes 결과 컬럼 타입과 값 인코딩 종류
+func (c *Cursor) ColumnTypes() []ColumnType {
+	return c.types
+}
+
+// Next 다음 행으로 이동 (제한에 도달했거나 행이 없으면 false)
+func (c *Cursor) Next() bool {
+	if c.err != nil || c.truncated {
+		return false
+	}
+	if c.maxRows > 0 && c.count >= c.maxRows {
+		// 남은 행이 있는지만 확인
+		c.truncated = c.rows.Next()
+		return false
+	}
+	if !c.rows.Next() {
+		c.err = c.rows.Err()
+		return false
+	}
+
+	row, err := c.scan()
+	if err != nil {
+		c.err = err
+		return false
+	}
+	size := rowSize(row)
+	if c.maxBytes > 0 && c.count > 0 && c.bytes+size > c.maxBytes {
+		c.truncated = true
+		return false
+	}
+
+	c.row = row
+	c.count++
+	c.bytes += size
+	return true
+}
+
+// Skip n개 행을 읽고 버림 (페이지 이동용, 제한에 포함하지 않음)
+func (c *Cursor) Skip(n int) error {
+	for i := 0; i < n; i++ {
+		if !c.rows.Next() {
+			return c.rows.Err()
+		}
+	}
+	return nil
+}
+
+func (c *Cursor) scan() ([]interface{}, error) {
+	values := make([]interface{}, len(c.columns)+c.hidden)
+	valuePtrs := make([]interface{}, len(values))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+	if err := c.rows.Scan(valuePtrs...); err != nil {
+		return nil, err
+	}
+
+	values = values[:len(c.columns)]
+	for i, v := range values {
+		values[i] = encodeValue(v, c.types[i], c.dbType)
+	}
+	return values, nil
+}
+
+// hide 결과 마지막 n개 컬럼을 숨김 (페이지 쿼리가 덧붙인 컬럼)
+func (c *Cursor) hide(n int) {
+	if n <= 0 || n > len(c.columns) {
+		return
+	}
+	c.columns = c.columns[:len(c.columns)-n]
+	c.types = c.types[:len(c.types)-n]
+	c.hidden += n
+}
+
+// Row 현재 행
+func (c *Cursor) Row() []interface{} {
+	return c.row
+}
+
+// Count 지금까지 읽은 행 수
+func (c *Cursor) Count() int {
+	return c.count
+}
+
+// Truncated 제한 때문에 남은 행을 읽지 않았는지
+func (c *Cursor) Truncated() bool {
+	return c.truncated
+}
+
+// Err 읽는 중 발생한 오류
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+// Duration 커서를 연 뒤 경과 시간 (ms)
+func (c *Cursor) Duration() int64 {
+	return time.Since(c.start).Milliseconds()
+}
+
+// Close 결과를 닫고 트랜잭션·연결 정리
+func (c *Cursor) Close() error {
+	err := c.rows.Close()
+	if c.cleanup != nil {
+		c.cleanup()
+		c.cleanup = nil
+	}
+	return err
+}
+
+// rowSize 행의 대략적인 메모리 크기 (MaxBytes 판단용)
+func rowSize(row []interface{}) int64 {
+	var size int64
+	for _, v := range row {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			size += int64(len(v))
+		case []byte:
+			size += int64(len(v))
+		case time.Time:
+			size += 24
+		default:
+			size += 8
+		}
+	}
+	return size
+}
+
+// openCursor 정책 확인 후 결과 집합을 반환하는 한 문장을 커서로 실행
+// 조회는 읽기 전용 트랜잭션 안에서 열리며 Close할 때 롤백합니다.
+func (b *BaseConnector) openCursor(ctx context.Context, query string, opts ExecOptions, begin readOnlyBegin) (*Cursor, error) {
+	c, readOnly, err := b.authorize(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.Kind == schema.StatementMulti {
+		return nil, fmt.Errorf("여러 문장은 커서로 읽을 수 없습니다 (%d개 문장)", len(c.Statements))
+	}
+	if !c.ReturnsRows {
+		return nil, ErrNoResultSet
+	}
+
+	units, err := b.executionUnits(query, c)
+	if err != nil {
+		return nil, err
+	}
+	if len(units) != 1 {
+		return nil, fmt.Errorf("여러 배치는 커서로 읽을 수 없습니다 (%d개 배치)", len(units))
+	}
+	bound, args, err := b.bind(units[0], opts.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	var q queryer = b.db
+	var cleanup func()
+	if readOnly {
+		tx, done, err := begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("읽기 전용 트랜잭션 시작 실패: %w", err)
+		}
+		q, cleanup = tx, done
+	}
+
+	rows, err := q.QueryContext(ctx, bound, args...)
+	if err != nil {
+		if cleanup != nil {
+			cleanup()
+		}
+		return nil, err
+	}
+	return newCursor(rows, b.config.Type, opts.Limits, cleanup)
+}
+
+// openPage 조회 쿼리에 행 범위를 넣어 offset개 행 다음부터 opts.MaxRows행을 읽는 커서 열기
+// 다음 페이지가 있는지 알 수 있도록 한 행을 더 요청합니다. 범위를 넣을 수 없는 쿼리(SHOW, 이미 LIMIT·TOP이 있는 조회 등)나
+// MaxRows가 없으면 쿼리를 그대로 실행하고 앞 행을 읽어 버립니다.
+func (b *BaseConnector) openPage(ctx context.Context, query string, offset int, opts ExecOptions, begin readOnlyBegin, rowNum bool) (*Cursor, error) {
+	if opts.MaxRows > 0 {
+		paged, ok, err := schema.NewParser().PageQuery(query, b.config.Type, offset, opts.MaxRows+1, rowNum)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cursor, err := b.openCursor(ctx, paged, opts, begin)
+			if err != nil {
+				return nil, err
+			}
+			if rowNum {
+				cursor.hide(1)
+			}
+			return cursor, nil
+		}
+	}
+
+	cursor, err := b.openCursor(ctx, query, opts, begin)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.Skip(offset); err != nil {
+		cursor.Close()
+		return nil, err
+	}
+	return cursor, nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"modernc.org/sqlite"
+)
+
+// pageReads sg_read()가 호출된 횟수 (DB가 결과 행을 만든 수)
+var pageReads atomic.Int64
+
+func init() {
+	sqlite.MustRegisterScalarFunction("sg_read", 1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		pageReads.Add(1)
+		return args[0], nil
+	})
+}
+
+const pageQuery = `WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 1000)
+SELECT sg_read(i) AS i FROM n`
+
+// readPage offset부터 pageSize행을 읽은 첫 값, 행 수, 다음 페이지 여부
+func readPage(t *testing.T, conn Connector, query string, offset, pageSize int) (first int64, rows int, more bool) {
+	t.Helper()
+	cursor, err := conn.OpenPage(context.Background(), query, offset, ExecOptions{Limits: Limits{MaxRows: pageSize}})
+	if err != nil {
+		t.Fatalf("OpenPage(%d) error = %v", offset, err)
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		if rows == 0 {
+			first = cursor.Row()[0].(int64)
+		}
+		rows++
+	}
+	if err := cursor.Err(); err != nil {
+		t.Fatalf("OpenPage(%d) read error = %v", offset, err)
+	}
+	return first, rows, cursor.Truncated()
+}
+
+func TestSQLiteOpenPage(t *testing.T) {
+	conn := newSQLite(t, "")
+	pageReads.Store(0)
+
+	for offset := 0; offset < 1000; offset += 100 {
+		first, rows, more := readPage(t, conn, pageQuery, offset, 100)
+		if first != int64(offset+1) || rows != 100 || more != (offset < 900) {
+			t.Errorf("page %d = first %d, %d rows, more %v", offset, first, rows, more)
+		}
+	}
+	// 페이지마다 처음부터 다시 읽으면 5,500행 이상 (페이지마다 다음 페이지 확인용 1행만 더 읽어야 함)
+	if reads := pageReads.Load(); reads > 1000+10 {
+		t.Errorf("rows produced = %d, want <= 1010 (query re-read from row 0)", reads)
+	}
+}
+
+func TestSQLiteOpenPageFallback(t *testing.T) {
+	conn := newSQLite(t, "")
+
+	// 이미 LIMIT가 있으면 범위를 넣지 않고 앞 행을 건너뜀
+	first, rows, more := readPage(t, conn, pageQuery+" LIMIT 250", 200, 100)
+	if first != 201 || rows != 50 || more {
+		t.Errorf("page = first %d, %d rows, more %v, want 201, 50 rows, no more", first, rows, more)
+	}
+}
+
+func TestCursorHide(t *testing.T) {
+	conn := newSQLite(t, "")
+	cursor, err := conn.OpenCursor(context.Background(), "SELECT 1 AS a, 2 AS b, 3 AS sg_page_rn", ExecOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cursor.Close()
+	cursor.hide(1)
+
+	if !reflect.DeepEqual(cursor.Columns(), []string{"a", "b"}) || len(cursor.ColumnTypes()) != 2 {
+		t.Errorf("Columns() = %v, %d types", cursor.Columns(), len(cursor.ColumnTypes()))
+	}
+	if !cursor.Next() || !reflect.DeepEqual(cursor.Row(), []interface{}{int64(1), int64(2)}) {
+		t.Errorf("Row() = %#v, err = %v", cursor.Row(), cursor.Err())
+	}
+}
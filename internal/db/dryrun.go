@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sql-genius/internal/schema"
+	"sql-genius/pkg/models"
+	"strings"
+	"time"
+)
+
+// dryRunPreviewLimit 미리보기로 읽는 최대 행 수
+const dryRunPreviewLimit = 20
+
+// DryRunResult DML 미리 실행 결과 (변경 사항은 모두 롤백됨)
+type DryRunResult struct {
+	Statement    string       `json:"statement"`               // INSERT, UPDATE, DELETE, MERGE
+	Table        string       `json:"table,omitempty"`         // 대상 테이블
+	RowsAffected int64        `json:"rows_affected"`           // 실행했을 때 영향을 받는 행 수
+	PreviewQuery string       `json:"preview_query,omitempty"` // 대상 행을 조회한 SELECT
+	PreviewError string       `json:"preview_error,omitempty"` // 미리보기 조회 실패 사유
+	PreviewLimit int          `json:"preview_limit"`           // 미리보기 최대 행 수
+	Before       *QueryResult `json:"before,omitempty"`        // 실행 전 대상 행 (INSERT는 삽입될 행)
+	After        *QueryResult `json:"after,omitempty"`         // UPDATE 실행 후 같은 행 (기본 키로 다시 조회)
+	Duration     int64        `json:"duration"`                // ms
+}
+
+// dryRunPrepare 미리 실행 전 대상 테이블을 확인하고 기본 키 컬럼을 반환
+// target.Schema는 쿼리에 쓴 한정자이며 비어 있으면 연결의 기본 스키마입니다.
+type dryRunPrepare func(ctx context.Context, target *schema.DMLTarget) ([]string, error)
+
+// dryRun 트랜잭션 안에서 미리보기 조회와 DML 실행 후 항상 롤백
+// read-only 정책에서는 차단하고, confirm-dml 정책에서는 확인 없이 허용합니다 (커밋하지 않으므로).
+func (b *BaseConnector) dryRun(ctx context.Context, query string, params Params, prepare dryRunPrepare) (*DryRunResult, error) {
+	parser := schema.NewParser()
+	c, err := parser.Classify(query, b.config.Type)
+	if err != nil {
+		return nil, err
+	}
+	if c.Kind != schema.StatementDML {
+		return nil, fmt.Errorf("미리 실행은 한 개의 DML 문(INSERT, UPDATE, DELETE, MERGE)만 지원합니다")
+	}
+	if policy := b.Policy(); policy == models.PolicyReadOnly {
+		return nil, &PolicyError{Policy: policy, Kind: c.Kind}
+	}
+
+	// 미리보기 SELECT는 원문 일부이므로 위치 파라미터 번호가 달라지지 않도록 이름으로 통일
+	query, params, err = b.namedParams(query, params)
+	if err != nil {
+		return nil, err
+	}
+	target, err := parser.ParseDML(query, b.config.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result := &DryRunResult{
+		Statement:    target.Statement,
+		Table:        models.QualifiedName(target.Schema, target.Table),
+		PreviewQuery: target.PreviewQuery(),
+		PreviewLimit: dryRunPreviewLimit,
+	}
+
+	// SQLite는 연결이 하나뿐이므로 트랜잭션 시작 전에 조회
+	var pks []string
+	if target.Table != "" {
+		if pks, err = prepare(ctx, target); err != nil {
+			return nil, fmt.Errorf("미리 실행 준비 실패: %w", err)
+		}
+	}
+
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	// 미리보기 실패 후 다시 시작한 트랜잭션도 롤백 (다시 시작하지 못하면 nil)
+	defer func() {
+		if tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if result.PreviewQuery != "" {
+		preview, previewArgs, err := b.bind(result.PreviewQuery, params)
+		if err == nil {
+			result.Before, err = b.runQueryLimit(ctx, tx, Limits{MaxRows: dryRunPreviewLimit}, preview, previewArgs...)
+		}
+		if err != nil {
+			// PostgreSQL은 오류가 난 트랜잭션을 더 쓸 수 없으므로 새로 시작
+			result.PreviewError = err.Error()
+			tx.Rollback()
+			if tx, err = b.db.BeginTx(ctx, nil); err != nil {
+				return nil, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+			}
+		}
+	}
+
+	bound, args, err := b.bind(query, params)
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.ExecContext(ctx, bound, args...)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected, err = res.RowsAffected(); err != nil {
+		result.RowsAffected = -1
+	}
+
+	if target.Statement == "UPDATE" && result.Before != nil {
+		if after, args := b.afterQuery(target, pks, result.Before); after != "" {
+			result.After, err = b.runQueryLimit(ctx, tx, Limits{MaxRows: dryRunPreviewLimit}, after, args...)
+			if err != nil {
+				result.PreviewError = err.Error()
+			}
+		}
+	}
+
+	result.Duration = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// afterQuery 미리보기 행의 기본 키로 UPDATE 이후 값을 다시 조회하는 SELECT
+func (b *BaseConnector) afterQuery(target *schema.DMLTarget, pks []string, before *QueryResult) (string, []interface{}) {
+	if len(pks) == 0 || len(before.Rows) == 0 {
+		return "", nil
+	}
+
+	// 조인 미리보기는 다른 테이블 컬럼이 섞여 있지 않도록 target.*만 조회하므로 이름으로 찾음
+	index := make([]int, len(pks))
+	for i, pk := range pks {
+		index[i] = -1
+		for j, col := range before.Columns {
+			if strings.EqualFold(col, pk) {
+				index[i] = j
+				break
+			}
+		}
+		if index[i] < 0 {
+			return "", nil
+		}
+	}
+
+	var conds []string
+	var args []interface{}
+	for _, row := range before.Rows {
+		var parts []string
+		for i, pk := range pks {
+			args = append(args, row[index[i]])
+			parts = append(parts, fmt.Sprintf("%s = %s", QuoteIdent(b.config.Type, pk), b.placeholder(len(args))))
+		}
+		conds = append(conds, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return fmt.Sprintf("SELECT * FROM %s WHERE %s", target.TableRef, strings.Join(conds, " OR ")), args
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sql-genius/pkg/models"
+	"time"
+)
+
+// tlsModes 지원하는 TLS 모드
+var tlsModes = map[models.TLSMode]bool{
+	models.TLSDisable:    true,
+	models.TLSPrefer:     true,
+	models.TLSRequire:    true,
+	models.TLSVerifyCA:   true,
+	models.TLSVerifyFull: true,
+}
+
+// ParseTLSMode 문자열을 TLS 모드로 변환 (빈 문자열은 DB별 기본값)
+func ParseTLSMode(s string) (models.TLSMode, error) {
+	mode := models.TLSMode(s)
+	if s != "" && !tlsModes[mode] {
+		return "", fmt.Errorf("알 수 없는 TLS 모드: %s (disable, prefer, require, verify-ca, verify-full)", s)
+	}
+	return mode, nil
+}
+
+// tlsConfig 인증서 파일로 만든 crypto/tls 설정 (MySQL처럼 드라이버가 파일 경로를 받지 않을 때)
+// require는 검증하지 않고, verify-ca는 호스트 이름을 빼고 CA만 검증합니다.
+func tlsConfig(config models.DBConfig) (*tls.Config, error) {
+	cfg := &tls.Config{ServerName: config.Host, MinVersion: tls.VersionTLS12}
+
+	if config.CACert != "" {
+		pem, err := os.ReadFile(config.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("CA 인증서 읽기 실패: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA 인증서에 PEM 인증서가 없습니다: %s", config.CACert)
+		}
+	}
+	if config.ClientCert != "" || config.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("클라이언트 인증서 읽기 실패: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	switch config.TLSMode {
+	case models.TLSPrefer, models.TLSRequire:
+		cfg.InsecureSkipVerify = true
+	case models.TLSVerifyCA:
+		// 호스트 이름 검증만 생략: 기본 검증을 끄고 체인은 직접 확인
+		cfg.InsecureSkipVerify = true
+		roots := cfg.RootCAs
+		cfg.VerifyPeerCertificate = func(raw [][]byte, _ [][]*x509.Certificate) error {
+			if len(raw) == 0 {
+				return errors.New("서버 인증서가 없습니다")
+			}
+			certs := make([]*x509.Certificate, len(raw))
+			for i, der := range raw {
+				cert, err := x509.ParseCertificate(der)
+				if err != nil {
+					return err
+				}
+				certs[i] = cert
+			}
+			opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+			for _, cert := range certs[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := certs[0].Verify(opts)
+			return err
+		}
+	}
+	return cfg, nil
+}
+
+// seconds 초 단위 설정값 (0 이하면 기본값)
+func seconds(n int, def time.Duration) time.Duration {
+	if n <= 0 {
+		return def
+	}
+	return time.Duration(n) * time.Second
+}
+
+// configurePool 연결 풀 크기 (설정이 없으면 기본값 10/5)
+func configurePool(db *sql.DB, config models.DBConfig) {
+	maxOpen, maxIdle := 10, 5
+	if config.MaxOpenConns > 0 {
+		maxOpen = config.MaxOpenConns
+	}
+	if config.MaxIdleConns > 0 {
+		maxIdle = config.MaxIdleConns
+	}
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(time.Hour)
+}
@@ -0,0 +1,373 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sql-genius/internal/schema"
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// ExtractOptions 스키마 추출 범위
+// 패턴은 *와 ?를 쓰는 glob이며 대소문자를 구분하지 않습니다.
+// 테이블 패턴에 .이 있으면 schema.table 전체와, 없으면 테이블 이름과 비교합니다.
+type ExtractOptions struct {
+	Schemas        []string `json:"schemas,omitempty"`         // 포함할 스키마 (비어 있으면 연결의 기본 스키마만)
+	ExcludeSchemas []string `json:"exclude_schemas,omitempty"` // 제외할 스키마
+	Tables         []string `json:"tables,omitempty"`          // 포함할 테이블 (비어 있으면 전체)
+	ExcludeTables  []string `json:"exclude_tables,omitempty"`  // 제외할 테이블
+
+	// Progress 카탈로그 조회가 하나 끝날 때마다 호출 (nil이면 보고하지 않음)
+	Progress func(ExtractProgress) `json:"-"`
+
+	only map[tableRef]bool // 증분 갱신에서 다시 추출할 테이블 (nil이면 제한 없음)
+}
+
+// ExtractProgress 스키마 추출 진행 상황
+type ExtractProgress struct {
+	Step   string // 끝난 조회 (예: "public 컬럼")
+	Done   int    // 끝난 조회 수
+	Total  int    // 전체 조회 수 (테이블 목록 조회 후 정해짐)
+	Tables int    // 추출 대상 테이블 수
+}
+
+// allSchemas 기본 스키마 외의 스키마도 조회해야 하는지
+func (o ExtractOptions) allSchemas() bool {
+	return len(o.Schemas) > 0
+}
+
+// includeSchema 스키마가 추출 대상인지 (Schemas가 비어 있으면 호출자가 기본 스키마로 제한)
+func (o ExtractOptions) includeSchema(schema string) bool {
+	if len(o.Schemas) > 0 && !matchAny(o.Schemas, schema) {
+		return false
+	}
+	return !matchAny(o.ExcludeSchemas, schema)
+}
+
+// includeTable 테이블이 추출 대상인지
+func (o ExtractOptions) includeTable(ref tableRef) bool {
+	if !o.includeSchema(ref.Schema) && ref.Schema != "" {
+		return false
+	}
+	if len(o.Tables) > 0 && !matchTable(o.Tables, ref) {
+		return false
+	}
+	return !matchTable(o.ExcludeTables, ref)
+}
+
+func matchTable(patterns []string, ref tableRef) bool {
+	for _, pattern := range patterns {
+		name := ref.Name
+		if strings.Contains(pattern, ".") {
+			name = ref.Schema + "." + ref.Name
+		}
+		if matchPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern 대소문자를 구분하지 않는 glob 비교 (잘못된 패턴은 일치하지 않음)
+func matchPattern(pattern, name string) bool {
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return err == nil && ok
+}
+
+// tableRef 스키마로 한정한 테이블 이름
+type tableRef struct {
+	Schema string
+	Name   string
+}
+
+// filterTables 추출 옵션에 맞는 테이블만 (증분 갱신이면 다시 추출할 테이블만)
+func (o ExtractOptions) filterTables(tables []TableVersion) []TableVersion {
+	var filtered []TableVersion
+	for _, t := range tables {
+		if o.only != nil && !o.only[t.ref()] {
+			continue
+		}
+		if o.includeTable(t.ref()) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// catalogExtractor 테이블 카탈로그 조회 (연결자마다 구현)
+// getTables 이외의 조회는 스키마 하나의 모든 테이블을 한 번에 읽어 테이블 이름별로 돌려주며,
+// 추출 대상이 아닌 테이블의 항목은 extractCatalog가 버립니다. 큰 DB에서도 왕복 횟수가
+// 테이블 수와 관계없이 (스키마 수 × 조회 종류)로 고정됩니다.
+// getTables는 테이블마다 정의가 바뀌면 달라지는 버전 값도 돌려줍니다 (증분 갱신에서 비교).
+type catalogExtractor interface {
+	objectExtractor
+	getTables(ctx context.Context, opts ExtractOptions) ([]TableVersion, error)
+	getColumns(ctx context.Context, schema string) (map[string][]models.Column, error)
+	getIndexes(ctx context.Context, schema string) (map[string][]models.Index, error)
+	getForeignKeys(ctx context.Context, schema string) (map[string][]models.FK, error)
+	getPrimaryKeys(ctx context.Context, schema string) (map[string][]string, error)
+	getChecks(ctx context.Context, schema string) (map[string][]models.Check, error)
+}
+
+// typeExtractor 사용자 정의 타입 조회 (PostgreSQL)
+type typeExtractor interface {
+	getTypes(ctx context.Context, opts ExtractOptions) ([]models.UserType, error)
+}
+
+// tableCatalog 스키마 하나의 테이블별 카탈로그 정보
+type tableCatalog struct {
+	columns map[string][]models.Column
+	indexes map[string][]models.Index
+	fks     map[string][]models.FK
+	pks     map[string][]string
+	checks  map[string][]models.Check
+}
+
+// extractCatalog 테이블, 사용자 정의 타입, 뷰 등 객체를 조회해 schema에 채움
+// 카탈로그는 스키마마다 종류별로 한 번씩 조회한 뒤 메모리에서 테이블로 조립하고,
+// 조회가 끝날 때마다 opts.Progress로 보고하며 ctx가 취소되면 바로 중단합니다.
+func extractCatalog(ctx context.Context, x catalogExtractor, schema *models.Schema, opts ExtractOptions) error {
+	tables, err := x.getTables(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("테이블 목록 조회 실패: %w", err)
+	}
+
+	var schemas []string
+	seen := make(map[string]bool)
+	for _, ref := range tables {
+		if !seen[ref.Schema] {
+			seen[ref.Schema] = true
+			schemas = append(schemas, ref.Schema)
+		}
+	}
+
+	tx, hasTypes := x.(typeExtractor)
+	total := len(schemas)*5 + 4
+	if hasTypes {
+		total++
+	}
+	progress := &extractProgress{report: opts.Progress, total: total, tables: len(tables)}
+
+	catalogs := make(map[string]*tableCatalog)
+	for _, name := range schemas {
+		c, err := fetchCatalog(ctx, x, name, progress)
+		if err != nil {
+			return err
+		}
+		catalogs[name] = c
+	}
+
+	for _, ref := range tables {
+		c := catalogs[ref.Schema]
+		table := models.Table{
+			Schema:      ref.Schema,
+			Name:        ref.Name,
+			Columns:     c.columns[ref.Name],
+			PrimaryKey:  c.pks[ref.Name],
+			ForeignKeys: c.fks[ref.Name],
+			Indexes:     c.indexes[ref.Name],
+		}
+		addChecks(&table, c.checks[ref.Name], schema.DBType)
+		schema.Tables = append(schema.Tables, table)
+	}
+
+	if hasTypes {
+		types, err := tx.getTypes(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("사용자 정의 타입 조회 실패: %w", err)
+		}
+		schema.Types = types
+		resolveTypes(schema)
+		progress.step("사용자 정의 타입")
+	}
+
+	if err := extractObjects(ctx, x, schema, opts, progress); err != nil {
+		return err
+	}
+
+	// 컬럼을 따로 채우지 않은 뷰는 같은 스키마의 컬럼 조회 결과에서 (테이블이 없는 스키마만 새로 조회)
+	for i := range schema.Views {
+		view := &schema.Views[i]
+		if view.Columns != nil {
+			continue
+		}
+		c, ok := catalogs[view.Schema]
+		if !ok {
+			columns, err := x.getColumns(ctx, view.Schema)
+			if err != nil {
+				return fmt.Errorf("뷰 컬럼 조회 실패: %w", err)
+			}
+			c = &tableCatalog{columns: columns}
+			catalogs[view.Schema] = c
+		}
+		view.Columns = c.columns[view.Name]
+	}
+	return nil
+}
+
+// fetchCatalog 스키마 하나의 컬럼, 인덱스, 외래키, 기본키, CHECK 제약조건 조회
+func fetchCatalog(ctx context.Context, x catalogExtractor, schema string, progress *extractProgress) (*tableCatalog, error) {
+	var c tableCatalog
+	var err error
+
+	if c.columns, err = x.getColumns(ctx, schema); err != nil {
+		return nil, fmt.Errorf("컬럼 조회 실패: %w", err)
+	}
+	progress.step(schemaStep(schema, "컬럼"))
+
+	if c.indexes, err = x.getIndexes(ctx, schema); err != nil {
+		return nil, fmt.Errorf("인덱스 조회 실패: %w", err)
+	}
+	progress.step(schemaStep(schema, "인덱스"))
+
+	if c.fks, err = x.getForeignKeys(ctx, schema); err != nil {
+		return nil, fmt.Errorf("외래키 조회 실패: %w", err)
+	}
+	progress.step(schemaStep(schema, "외래키"))
+
+	if c.pks, err = x.getPrimaryKeys(ctx, schema); err != nil {
+		return nil, fmt.Errorf("기본키 조회 실패: %w", err)
+	}
+	progress.step(schemaStep(schema, "기본키"))
+
+	if c.checks, err = x.getChecks(ctx, schema); err != nil {
+		return nil, fmt.Errorf("CHECK 제약조건 조회 실패: %w", err)
+	}
+	progress.step(schemaStep(schema, "CHECK 제약조건"))
+
+	return &c, ctx.Err()
+}
+
+func schemaStep(schema, step string) string {
+	if schema == "" {
+		return step
+	}
+	return schema + " " + step
+}
+
+// extractProgress 진행 상황 집계 (report가 nil이면 보고하지 않음)
+type extractProgress struct {
+	report func(ExtractProgress)
+	done   int
+	total  int
+	tables int
+}
+
+func (p *extractProgress) step(name string) {
+	p.done++
+	if p.report != nil {
+		p.report(ExtractProgress{Step: name, Done: p.done, Total: p.total, Tables: p.tables})
+	}
+}
+
+// objectExtractor 테이블 이외의 스키마 객체 조회 (연결자마다 구현, 지원하지 않는 객체는 nil)
+// 조회 범위는 getTables와 같으며 패턴 필터는 extractObjects에서 적용합니다.
+// getViews가 컬럼을 채우지 않으면 extractCatalog가 getColumns 결과로 채웁니다.
+type objectExtractor interface {
+	getViews(ctx context.Context, opts ExtractOptions) ([]models.View, error)
+	getSequences(ctx context.Context, opts ExtractOptions) ([]models.Sequence, error)
+	getRoutines(ctx context.Context, opts ExtractOptions) ([]models.Routine, error)
+	getTriggers(ctx context.Context, opts ExtractOptions) ([]models.Trigger, error)
+}
+
+// extractObjects 뷰, 시퀀스, 루틴, 트리거를 추출 옵션에 맞게 스키마에 추가
+// 뷰는 테이블 패턴을, 트리거는 대상 테이블의 패턴을, 시퀀스와 루틴은 스키마 패턴만 적용합니다.
+func extractObjects(ctx context.Context, x objectExtractor, schema *models.Schema, opts ExtractOptions, progress *extractProgress) error {
+	views, err := x.getViews(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("뷰 조회 실패: %w", err)
+	}
+	for _, v := range views {
+		if opts.includeTable(tableRef{Schema: v.Schema, Name: v.Name}) {
+			schema.Views = append(schema.Views, v)
+		}
+	}
+	progress.step("뷰")
+
+	sequences, err := x.getSequences(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("시퀀스 조회 실패: %w", err)
+	}
+	for _, seq := range sequences {
+		if opts.includeObject(seq.Schema) {
+			schema.Sequences = append(schema.Sequences, seq)
+		}
+	}
+	progress.step("시퀀스")
+
+	routines, err := x.getRoutines(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("함수/프로시저 조회 실패: %w", err)
+	}
+	for _, r := range routines {
+		if opts.includeObject(r.Schema) {
+			schema.Routines = append(schema.Routines, r)
+		}
+	}
+	progress.step("함수/프로시저")
+
+	triggers, err := x.getTriggers(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("트리거 조회 실패: %w", err)
+	}
+	for _, t := range triggers {
+		if opts.includeTable(tableRef{Schema: t.Schema, Name: t.Table}) {
+			schema.Triggers = append(schema.Triggers, t)
+		}
+	}
+	progress.step("트리거")
+	return nil
+}
+
+// includeObject 스키마 패턴만 적용 (스키마가 없는 객체는 항상 포함)
+func (o ExtractOptions) includeObject(schema string) bool {
+	return schema == "" || o.includeSchema(schema)
+}
+
+// viewHeader CREATE VIEW ... AS 머리 부분
+var viewHeader = regexp.MustCompile(`(?is)^\s*CREATE\s.*?\bVIEW\b.*?\bAS\b\s*`)
+
+// viewQuery CREATE VIEW 문에서 SELECT 부분만 (CREATE 문이 아니면 그대로)
+func viewQuery(definition string) string {
+	query := strings.TrimSpace(viewHeader.ReplaceAllString(definition, ""))
+	return strings.TrimSpace(strings.TrimSuffix(query, ";"))
+}
+
+// addChecks CHECK 제약조건을 테이블에 추가
+// 참조 컬럼이 없으면 표현식에서 찾고 col IN (...) 형태의 값 목록을 컬럼에 채우는 규칙은 DDL 파서와 같습니다.
+func addChecks(table *models.Table, checks []models.Check, dbType models.DBType) {
+	for _, c := range checks {
+		schema.AddCheck(table, c, dbType)
+	}
+}
+
+// generatedExpr 카탈로그의 계산 컬럼 표현식 (DDL 파서와 같이 바깥 괄호를 벗겨냄)
+func generatedExpr(expr string) string {
+	return schema.TrimParens(expr)
+}
+
+// enumTypeValues ENUM('a', 'b') / SET(...) 컬럼 타입의 값 목록 (MySQL COLUMN_TYPE)
+func enumTypeValues(columnType string) []string {
+	return schema.EnumTypeValues(columnType)
+}
+
+// resolveTypes enum 타입과 도메인을 쓰는 컬럼에 값 목록을 채움 (DDL 파서와 같은 규칙)
+func resolveTypes(s *models.Schema) {
+	schema.ResolveTypes(s)
+}
+
+// joinChecks 도메인의 CHECK 정의 여러 개를 하나의 표현식으로
+func joinChecks(definitions []string) string {
+	return schema.JoinChecks(definitions)
+}
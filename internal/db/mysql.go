@@ -3,12 +3,19 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"sql-genius/internal/schema"
 	"sql-genius/pkg/models"
+	"strconv"
 	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
 // MySQLConnector MySQL 연결자
@@ -24,122 +31,176 @@ func NewMySQLConnector(config models.DBConfig) (*MySQLConnector, error) {
 }
 
 func (m *MySQLConnector) Connect(ctx context.Context) error {
-	// 연결 타임아웃 60초, 읽기/쓰기 타임아웃 30초
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4&timeout=60s&readTimeout=30s&writeTimeout=30s",
-		m.config.User, m.config.Password, m.config.Host, m.config.Port, m.config.Database)
-
-	db, err := sql.Open("mysql", dsn)
+	cfg, err := m.dsnConfig()
+	if err != nil {
+		return fmt.Errorf("MySQL 연결 설정 오류: %w", err)
+	}
+	tunnel, err := m.openTunnel(ctx)
 	if err != nil {
+		return err
+	}
+	if tunnel != nil {
+		cfg.DialFunc = tunnel.DialContext
+	}
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		tunnel.Close()
 		return fmt.Errorf("MySQL 연결 실패: %w", err)
 	}
+	db := sql.OpenDB(connector)
+	configurePool(db, m.config)
 
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(time.Hour)
-
-	// 연결 테스트 (최대 60초 대기)
-	pingCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	// 연결 테스트 (연결 타임아웃만큼 대기)
+	pingCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
 	defer cancel()
 
 	if err := db.PingContext(pingCtx); err != nil {
+		db.Close()
+		tunnel.Close()
 		return fmt.Errorf("MySQL Ping 실패: %w", err)
 	}
 
-	m.db = db
+	// 여러 문장은 unrestricted 정책에서만 실행할 수 있으므로 그때만 multiStatements 전용 연결을 둠
+	// (다른 정책의 연결은 주석 등으로 숨긴 뒤 문장이 서버에서 실행될 여지가 없도록 한 문장씩만 보냄)
+	if m.Policy() == models.PolicyUnrestricted {
+		multiCfg := cfg.Clone()
+		multiCfg.MultiStatements = true
+		multiConnector, err := mysql.NewConnector(multiCfg)
+		if err != nil {
+			db.Close()
+			tunnel.Close()
+			return fmt.Errorf("MySQL 연결 실패: %w", err)
+		}
+		m.multi = sql.OpenDB(multiConnector)
+		m.multi.SetMaxOpenConns(1)
+	}
+
+	m.db, m.tunnel = db, tunnel
 	return nil
 }
 
-func (m *MySQLConnector) ExtractSchema(ctx context.Context) (*models.Schema, error) {
-	schema := &models.Schema{
-		Database: m.config.Database,
-		DBType:   models.MySQL,
-		Tables:   []models.Table{},
+// dsnConfig 연결 설정으로 드라이버 설정 생성
+// 기본값: 연결 타임아웃 60초, 읽기/쓰기 타임아웃 30초, utf8mb4
+func (m *MySQLConnector) dsnConfig() (*mysql.Config, error) {
+	cfg := mysql.NewConfig()
+	cfg.User = m.config.User
+	cfg.Passwd = m.config.Password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+	cfg.DBName = m.config.Database
+	cfg.ParseTime = true
+	cfg.Timeout = seconds(m.config.ConnectTimeout, 60*time.Second)
+	cfg.ReadTimeout = seconds(m.config.ReadTimeout, 30*time.Second)
+	cfg.WriteTimeout = seconds(m.config.ReadTimeout, 30*time.Second)
+	if m.config.ApplicationName != "" {
+		cfg.ConnectionAttributes = "program_name:" + m.config.ApplicationName
 	}
 
-	// 테이블 목록 조회
-	tables, err := m.getTables(ctx)
+	// charset 등 추가 파라미터는 DSN 문자열로 다시 읽어 드라이버가 해석하도록
+	params := url.Values{"charset": {"utf8mb4"}}
+	for k, v := range m.config.Params {
+		params.Set(k, v)
+	}
+	dsn := cfg.FormatDSN()
+	if strings.Contains(dsn, "?") {
+		dsn += "&" + params.Encode()
+	} else {
+		dsn += "?" + params.Encode()
+	}
+	cfg, err := mysql.ParseDSN(dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, tableName := range tables {
-		table := models.Table{Name: tableName}
-
-		// 컬럼 정보
-		columns, err := m.getColumns(ctx, tableName)
-		if err != nil {
+	// 파라미터로 tls를 지정하지 않았으면 TLS 모드 적용
+	if _, ok := m.config.Params["tls"]; !ok && m.config.TLSMode != "" && m.config.TLSMode != models.TLSDisable {
+		if cfg.TLS, err = tlsConfig(m.config); err != nil {
 			return nil, err
 		}
-		table.Columns = columns
-
-		// 인덱스 정보
-		indexes, err := m.getIndexes(ctx, tableName)
-		if err != nil {
-			return nil, err
-		}
-		table.Indexes = indexes
-
-		// 외래키 정보
-		fks, err := m.getForeignKeys(ctx, tableName)
-		if err != nil {
-			return nil, err
-		}
-		table.ForeignKeys = fks
-
-		// 기본키 정보
-		pks, err := m.getPrimaryKeys(ctx, tableName)
-		if err != nil {
-			return nil, err
-		}
-		table.PrimaryKey = pks
+		cfg.AllowFallbackToPlaintext = m.config.TLSMode == models.TLSPrefer
+	}
+	return cfg, nil
+}
 
-		schema.Tables = append(schema.Tables, table)
+func (m *MySQLConnector) ExtractSchema(ctx context.Context, opts ExtractOptions) (*models.Schema, error) {
+	schema := &models.Schema{
+		Database: m.config.Database,
+		DBType:   models.MySQL,
+		Tables:   []models.Table{},
 	}
 
+	if err := extractCatalog(ctx, m, schema, opts); err != nil {
+		return nil, err
+	}
 	return schema, nil
 }
 
-func (m *MySQLConnector) getTables(ctx context.Context) ([]string, error) {
-	query := "SHOW TABLES"
-	rows, err := m.db.QueryContext(ctx, query)
+// TableVersions 추출 범위의 테이블과 CREATE_TIME/UPDATE_TIME 버전
+func (m *MySQLConnector) TableVersions(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	return m.getTables(ctx, opts)
+}
+
+// mysqlSystemSchemas 추출하지 않는 시스템 데이터베이스
+const mysqlSystemSchemas = `('mysql', 'information_schema', 'performance_schema', 'sys')`
+
+// getTables 추출할 테이블 (스키마 패턴이 없으면 연결한 데이터베이스만, 있으면 시스템 데이터베이스를 뺀 전체에서 선택)
+// 버전은 CREATE_TIME(테이블을 다시 만드는 ALTER에서 바뀜)과 UPDATE_TIME입니다.
+func (m *MySQLConnector) getTables(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	query := `
+		SELECT TABLE_SCHEMA, TABLE_NAME, CONCAT_WS('/', CREATE_TIME, UPDATE_TIME)
+		FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_TYPE = 'BASE TABLE'
+			AND (? OR TABLE_SCHEMA = DATABASE())
+			AND TABLE_SCHEMA NOT IN ` + mysqlSystemSchemas + `
+		ORDER BY TABLE_SCHEMA, TABLE_NAME`
+
+	rows, err := m.db.QueryContext(ctx, query, opts.allSchemas())
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var tables []string
+	var tables []TableVersion
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var t TableVersion
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Version); err != nil {
 			return nil, err
 		}
-		tables = append(tables, name)
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return tables, nil
+	return opts.filterTables(tables), nil
 }
 
-func (m *MySQLConnector) getColumns(ctx context.Context, table string) ([]models.Column, error) {
+// getColumns 데이터베이스의 테이블과 뷰 컬럼
+func (m *MySQLConnector) getColumns(ctx context.Context, schema string) (map[string][]models.Column, error) {
 	query := `
 		SELECT 
-			COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, 
-			COLUMN_KEY, EXTRA, COLUMN_COMMENT
-		FROM INFORMATION_SCHEMA.COLUMNS 
-		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
-		ORDER BY ORDINAL_POSITION`
-
-	rows, err := m.db.QueryContext(ctx, query, m.config.Database, table)
+			c.TABLE_NAME, c.COLUMN_NAME, c.DATA_TYPE, c.IS_NULLABLE, c.COLUMN_DEFAULT, 
+			c.COLUMN_KEY, c.EXTRA, c.COLUMN_COMMENT, c.COLUMN_TYPE,
+			CASE WHEN c.COLLATION_NAME <> t.TABLE_COLLATION THEN c.COLLATION_NAME END,
+			c.GENERATION_EXPRESSION
+		FROM INFORMATION_SCHEMA.COLUMNS c
+		JOIN INFORMATION_SCHEMA.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+		WHERE c.TABLE_SCHEMA = ?
+		ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`
+
+	rows, err := m.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var columns []models.Column
+	columns := make(map[string][]models.Column)
 	for rows.Next() {
 		var col models.Column
-		var nullable, columnKey, extra string
-		var defaultVal, comment sql.NullString
+		var table, nullable, columnKey, extra, columnType string
+		var defaultVal, comment, collation, generated sql.NullString
 
-		if err := rows.Scan(&col.Name, &col.Type, &nullable, &defaultVal, &columnKey, &extra, &comment); err != nil {
+		if err := rows.Scan(&table, &col.Name, &col.Type, &nullable, &defaultVal, &columnKey, &extra, &comment,
+			&columnType, &collation, &generated); err != nil {
 			return nil, err
 		}
 
@@ -154,87 +215,273 @@ func (m *MySQLConnector) getColumns(ctx context.Context, table string) ([]models
 		if comment.Valid {
 			col.Comment = comment.String
 		}
+		// ENUM('a','b') / SET(...) 값 목록
+		col.EnumValues = enumTypeValues(columnType)
+		col.Collation = collation.String
+		if strings.Contains(extra, "GENERATED") && generated.String != "" {
+			col.Generated = generatedExpr(mysqlExpression(generated.String))
+			col.Stored = strings.Contains(extra, "STORED")
+			col.Default = ""
+		}
 
-		columns = append(columns, col)
+		columns[table] = append(columns[table], col)
 	}
-	return columns, nil
+	return columns, rows.Err()
 }
 
-func (m *MySQLConnector) getIndexes(ctx context.Context, table string) ([]models.Index, error) {
+// getViews 뷰 (컬럼은 extractCatalog가 getColumns 결과로 채움)
+func (m *MySQLConnector) getViews(ctx context.Context, opts ExtractOptions) ([]models.View, error) {
 	query := `
-		SELECT INDEX_NAME, COLUMN_NAME, NON_UNIQUE, INDEX_TYPE
-		FROM INFORMATION_SCHEMA.STATISTICS
-		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
-		ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+		SELECT TABLE_SCHEMA, TABLE_NAME, VIEW_DEFINITION
+		FROM INFORMATION_SCHEMA.VIEWS
+		WHERE (? OR TABLE_SCHEMA = DATABASE()) AND TABLE_SCHEMA NOT IN ` + mysqlSystemSchemas + `
+		ORDER BY TABLE_SCHEMA, TABLE_NAME`
 
-	rows, err := m.db.QueryContext(ctx, query, m.config.Database, table)
+	rows, err := m.db.QueryContext(ctx, query, opts.allSchemas())
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	indexMap := make(map[string]*models.Index)
+	var views []models.View
 	for rows.Next() {
-		var indexName, columnName, indexType string
-		var nonUnique int
-
-		if err := rows.Scan(&indexName, &columnName, &nonUnique, &indexType); err != nil {
+		var v models.View
+		var definition sql.NullString
+		if err := rows.Scan(&v.Schema, &v.Name, &definition); err != nil {
 			return nil, err
 		}
+		v.Definition = definition.String
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
 
-		if idx, exists := indexMap[indexName]; exists {
-			idx.Columns = append(idx.Columns, columnName)
-		} else {
-			indexMap[indexName] = &models.Index{
-				Name:     indexName,
-				Columns:  []string{columnName},
-				IsUnique: nonUnique == 0,
-				Type:     indexType,
+// getSequences MySQL은 시퀀스가 없음 (AUTO_INCREMENT 컬럼으로 표시)
+func (m *MySQLConnector) getSequences(ctx context.Context, opts ExtractOptions) ([]models.Sequence, error) {
+	return nil, nil
+}
+
+// getRoutines 함수와 프로시저 (CREATE 문은 본문과 파라미터로 재구성)
+// 본문을 볼 권한이 없으면 ROUTINE_DEFINITION이 NULL이어서 시그니처만 기록합니다.
+func (m *MySQLConnector) getRoutines(ctx context.Context, opts ExtractOptions) ([]models.Routine, error) {
+	query := `
+		SELECT r.ROUTINE_SCHEMA, r.ROUTINE_NAME, r.ROUTINE_TYPE,
+			COALESCE((
+				SELECT GROUP_CONCAT(
+					CONCAT_WS(' ', IF(r.ROUTINE_TYPE = 'PROCEDURE', p.PARAMETER_MODE, NULL), p.PARAMETER_NAME, p.DTD_IDENTIFIER)
+					ORDER BY p.ORDINAL_POSITION SEPARATOR ', ')
+				FROM INFORMATION_SCHEMA.PARAMETERS p
+				WHERE p.SPECIFIC_SCHEMA = r.ROUTINE_SCHEMA AND p.SPECIFIC_NAME = r.SPECIFIC_NAME
+					AND p.ORDINAL_POSITION > 0
+			), ''),
+			IF(r.ROUTINE_TYPE = 'FUNCTION', r.DTD_IDENTIFIER, ''),
+			r.IS_DETERMINISTIC, r.SQL_DATA_ACCESS, r.ROUTINE_DEFINITION
+		FROM INFORMATION_SCHEMA.ROUTINES r
+		WHERE (? OR r.ROUTINE_SCHEMA = DATABASE()) AND r.ROUTINE_SCHEMA NOT IN ` + mysqlSystemSchemas + `
+		ORDER BY r.ROUTINE_SCHEMA, r.ROUTINE_NAME`
+
+	rows, err := m.db.QueryContext(ctx, query, opts.allSchemas())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routines []models.Routine
+	for rows.Next() {
+		var r models.Routine
+		var kind, deterministic, dataAccess string
+		var returns, body sql.NullString
+		if err := rows.Scan(&r.Schema, &r.Name, &kind, &r.Arguments, &returns, &deterministic, &dataAccess, &body); err != nil {
+			return nil, err
+		}
+		r.Kind = models.RoutineKind(kind)
+		r.Returns = returns.String
+		r.Language = "SQL"
+
+		if body.Valid {
+			header := fmt.Sprintf("CREATE %s %s.%s(%s)", kind,
+				QuoteIdent(models.MySQL, r.Schema), QuoteIdent(models.MySQL, r.Name), r.Arguments)
+			if r.Returns != "" {
+				header += " RETURNS " + r.Returns
+			}
+			if deterministic == "YES" {
+				header += " DETERMINISTIC"
 			}
+			r.Definition = header + " " + dataAccess + "\n" + body.String
+		}
+		routines = append(routines, r)
+	}
+	return routines, rows.Err()
+}
+
+// getTriggers 트리거 (MySQL 트리거는 항상 FOR EACH ROW)
+func (m *MySQLConnector) getTriggers(ctx context.Context, opts ExtractOptions) ([]models.Trigger, error) {
+	query := `
+		SELECT TRIGGER_SCHEMA, TRIGGER_NAME, EVENT_OBJECT_TABLE, ACTION_TIMING, EVENT_MANIPULATION, ACTION_STATEMENT
+		FROM INFORMATION_SCHEMA.TRIGGERS
+		WHERE (? OR TRIGGER_SCHEMA = DATABASE()) AND TRIGGER_SCHEMA NOT IN ` + mysqlSystemSchemas + `
+		ORDER BY TRIGGER_SCHEMA, EVENT_OBJECT_TABLE, ACTION_ORDER`
+
+	rows, err := m.db.QueryContext(ctx, query, opts.allSchemas())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []models.Trigger
+	for rows.Next() {
+		var t models.Trigger
+		var event, statement string
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Table, &t.Timing, &event, &statement); err != nil {
+			return nil, err
 		}
+		t.Events = []string{event}
+		t.Definition = fmt.Sprintf("CREATE TRIGGER %s.%s %s %s ON %s.%s FOR EACH ROW\n%s",
+			QuoteIdent(models.MySQL, t.Schema), QuoteIdent(models.MySQL, t.Name), t.Timing, event,
+			QuoteIdent(models.MySQL, t.Schema), QuoteIdent(models.MySQL, t.Table), statement)
+		triggers = append(triggers, t)
 	}
+	return triggers, rows.Err()
+}
+
+// getIndexes 데이터베이스의 인덱스 (테이블별로 이름순)
+func (m *MySQLConnector) getIndexes(ctx context.Context, schema string) (map[string][]models.Index, error) {
+	query := `
+		SELECT TABLE_NAME, INDEX_NAME, COLUMN_NAME, NON_UNIQUE, INDEX_TYPE
+		FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE TABLE_SCHEMA = ?
+		ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`
 
-	var indexes []models.Index
-	for _, idx := range indexMap {
-		indexes = append(indexes, *idx)
+	rows, err := m.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
 	}
-	return indexes, nil
+	defer rows.Close()
+
+	indexes := make(map[string][]models.Index)
+	for rows.Next() {
+		var table, indexName, columnName, indexType string
+		var nonUnique int
+
+		if err := rows.Scan(&table, &indexName, &columnName, &nonUnique, &indexType); err != nil {
+			return nil, err
+		}
+
+		list := indexes[table]
+		if n := len(list); n > 0 && list[n-1].Name == indexName {
+			list[n-1].Columns = append(list[n-1].Columns, columnName)
+			continue
+		}
+		indexes[table] = append(list, models.Index{
+			Name:     indexName,
+			Columns:  []string{columnName},
+			IsUnique: nonUnique == 0,
+			Type:     indexType,
+		})
+	}
+	return indexes, rows.Err()
 }
 
-func (m *MySQLConnector) getForeignKeys(ctx context.Context, table string) ([]models.FK, error) {
+func (m *MySQLConnector) getForeignKeys(ctx context.Context, schema string) (map[string][]models.FK, error) {
 	query := `
 		SELECT 
-			CONSTRAINT_NAME, COLUMN_NAME, 
-			REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
+			TABLE_NAME, CONSTRAINT_NAME, COLUMN_NAME, 
+			REFERENCED_TABLE_SCHEMA, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
 		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE
-		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? 
-			AND REFERENCED_TABLE_NAME IS NOT NULL`
+		WHERE TABLE_SCHEMA = ? 
+			AND REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY TABLE_NAME, CONSTRAINT_NAME, ORDINAL_POSITION`
 
-	rows, err := m.db.QueryContext(ctx, query, m.config.Database, table)
+	rows, err := m.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var fks []models.FK
+	fks := make(map[string][]models.FK)
 	for rows.Next() {
+		var table string
 		var fk models.FK
-		if err := rows.Scan(&fk.Name, &fk.Column, &fk.RefTable, &fk.RefColumn); err != nil {
+		if err := rows.Scan(&table, &fk.Name, &fk.Column, &fk.RefSchema, &fk.RefTable, &fk.RefColumn); err != nil {
 			return nil, err
 		}
-		fks = append(fks, fk)
+		fks[table] = append(fks[table], fk)
 	}
-	return fks, nil
+	return fks, rows.Err()
 }
 
-func (m *MySQLConnector) getPrimaryKeys(ctx context.Context, table string) ([]string, error) {
+// getChecks CHECK 제약조건 (CHECK_CONSTRAINTS가 없는 MySQL 8.0.16 이전 버전은 빈 목록)
+func (m *MySQLConnector) getChecks(ctx context.Context, schema string) (map[string][]models.Check, error) {
+	query := `
+		SELECT tc.TABLE_NAME, cc.CONSTRAINT_NAME, cc.CHECK_CLAUSE
+		FROM INFORMATION_SCHEMA.CHECK_CONSTRAINTS cc
+		JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+			ON tc.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA AND tc.CONSTRAINT_NAME = cc.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = ? AND tc.CONSTRAINT_TYPE = 'CHECK'
+		ORDER BY tc.TABLE_NAME, cc.CONSTRAINT_NAME`
+
+	rows, err := m.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		var myErr *mysql.MySQLError
+		if errors.As(err, &myErr) && myErr.Number == 1109 { // Unknown table
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string][]models.Check)
+	for rows.Next() {
+		var table string
+		var c models.Check
+		if err := rows.Scan(&table, &c.Name, &c.Expression); err != nil {
+			return nil, err
+		}
+		c.Expression = mysqlExpression(c.Expression)
+		checks[table] = append(checks[table], c)
+	}
+	return checks, rows.Err()
+}
+
+// mysqlExpression INFORMATION_SCHEMA의 표현식은 작은따옴표가 \'로 이스케이프되어 있음
+func mysqlExpression(expr string) string {
+	return strings.ReplaceAll(expr, `\'`, "'")
+}
+
+// getPrimaryKeys 데이터베이스의 테이블별 기본 키 컬럼
+func (m *MySQLConnector) getPrimaryKeys(ctx context.Context, schema string) (map[string][]string, error) {
+	query := `
+		SELECT TABLE_NAME, COLUMN_NAME
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = ? AND CONSTRAINT_NAME = 'PRIMARY'
+		ORDER BY TABLE_NAME, ORDINAL_POSITION`
+
+	rows, err := m.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pks := make(map[string][]string)
+	for rows.Next() {
+		var table, pk string
+		if err := rows.Scan(&table, &pk); err != nil {
+			return nil, err
+		}
+		pks[table] = append(pks[table], pk)
+	}
+	return pks, rows.Err()
+}
+
+// tablePrimaryKey 테이블 하나의 기본 키 컬럼 (schema가 비어 있으면 연결한 데이터베이스)
+func (m *MySQLConnector) tablePrimaryKey(ctx context.Context, schema, table string) ([]string, error) {
 	query := `
 		SELECT COLUMN_NAME
 		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE
-		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY'
+		WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY'
 		ORDER BY ORDINAL_POSITION`
 
-	rows, err := m.db.QueryContext(ctx, query, m.config.Database, table)
+	rows, err := m.db.QueryContext(ctx, query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -248,54 +495,249 @@ func (m *MySQLConnector) getPrimaryKeys(ctx context.Context, table string) ([]st
 		}
 		pks = append(pks, pk)
 	}
-	return pks, nil
+	return pks, rows.Err()
 }
 
-func (m *MySQLConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
-	start := time.Now()
+// ExtractStats TABLES의 추정 행 수와 크기, 인덱스 첫 컬럼의 CARDINALITY, MySQL 8.0 히스토그램
+// 값은 information_schema 캐시와 InnoDB 표본 통계이므로 오래됐으면 ANALYZE TABLE로 갱신해야 합니다.
+// 히스토그램은 ANALYZE TABLE ... UPDATE HISTOGRAM ON으로 만든 컬럼만 있습니다.
+func (m *MySQLConnector) ExtractStats(ctx context.Context, schema *models.Schema) error {
+	return extractStats(ctx, m, schema)
+}
 
-	rows, err := m.db.QueryContext(ctx, query)
+func (m *MySQLConnector) getTableStats(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT TABLE_NAME, TABLE_ROWS, DATA_LENGTH + INDEX_LENGTH
+		FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE'`
+
+	rows, err := m.db.QueryContext(ctx, query, schema)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
+	for rows.Next() {
+		var name string
+		var count, size sql.NullInt64
+		if err := rows.Scan(&name, &count, &size); err != nil {
+			return err
+		}
+		stats := models.TableStats{Rows: -1, SizeBytes: size.Int64}
+		if count.Valid {
+			stats.Rows = count.Int64
+		}
+		target.setTable(schema, name, stats)
+	}
+	return rows.Err()
+}
+
+// getColumnStats 인덱스 첫 컬럼의 CARDINALITY(고유 값 수)와 COLUMN_STATISTICS 히스토그램
+func (m *MySQLConnector) getColumnStats(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT TABLE_NAME, COLUMN_NAME, MAX(CARDINALITY)
+		FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE TABLE_SCHEMA = ? AND SEQ_IN_INDEX = 1 AND COLUMN_NAME IS NOT NULL AND CARDINALITY IS NOT NULL
+		GROUP BY TABLE_NAME, COLUMN_NAME`
+
+	rows, err := m.db.QueryContext(ctx, query, schema)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer rows.Close()
 
-	var resultRows [][]interface{}
 	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		valuePtrs := make([]interface{}, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
+		var table, column string
+		var cardinality int64
+		if err := rows.Scan(&table, &column, &cardinality); err != nil {
+			return err
 		}
+		if stats := target.column(schema, table, column); stats != nil {
+			stats.Distinct = cardinality
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, err
+	return m.getHistograms(ctx, target, schema)
+}
+
+// getHistograms MySQL 8.0 COLUMN_STATISTICS (MySQL 5.7과 MariaDB에는 없음)
+func (m *MySQLConnector) getHistograms(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT TABLE_NAME, COLUMN_NAME, HISTOGRAM
+		FROM INFORMATION_SCHEMA.COLUMN_STATISTICS
+		WHERE SCHEMA_NAME = ?`
+
+	rows, err := m.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		var myErr *mysql.MySQLError
+		if errors.As(err, &myErr) && myErr.Number == 1109 { // Unknown table
+			return nil
 		}
+		return err
+	}
+	defer rows.Close()
 
-		row := make([]interface{}, len(columns))
-		for i, v := range values {
-			if b, ok := v.([]byte); ok {
-				row[i] = string(b)
-			} else {
-				row[i] = v
+	for rows.Next() {
+		var table, column string
+		var raw []byte
+		if err := rows.Scan(&table, &column, &raw); err != nil {
+			return err
+		}
+		stats := target.column(schema, table, column)
+		if stats == nil {
+			continue
+		}
+
+		var histogram struct {
+			Type       string              `json:"histogram-type"`
+			NullValues float64             `json:"null-values"`
+			Buckets    [][]json.RawMessage `json:"buckets"`
+		}
+		if err := json.Unmarshal(raw, &histogram); err != nil {
+			continue
+		}
+		stats.NullFraction = histogram.NullValues
+
+		// singleton: [값, 누적 빈도], equi-height: [하한, 상한, 누적 빈도, 고유 값 수]
+		var distinct int64
+		var bounds []string
+		for i, bucket := range histogram.Buckets {
+			switch {
+			case histogram.Type == "singleton" && len(bucket) >= 2:
+				bounds = append(bounds, mysqlHistogramValue(bucket[0]))
+				distinct++
+			case histogram.Type == "equi-height" && len(bucket) >= 4:
+				if i == 0 {
+					bounds = append(bounds, mysqlHistogramValue(bucket[0]))
+				}
+				bounds = append(bounds, mysqlHistogramValue(bucket[1]))
+				var n int64
+				if json.Unmarshal(bucket[3], &n) == nil {
+					distinct += n
+				}
 			}
 		}
-		resultRows = append(resultRows, row)
+		stats.Histogram = bounds
+		if stats.Distinct == 0 {
+			stats.Distinct = distinct
+		}
 	}
+	return rows.Err()
+}
 
-	return &QueryResult{
-		Columns:  columns,
-		Rows:     resultRows,
-		Duration: time.Since(start).Milliseconds(),
-	}, nil
+// mysqlHistogramValue 히스토그램 값 (문자열 컬럼 값은 "base64:type254:..." 형태로 인코딩되어 있음)
+func mysqlHistogramValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return string(raw)
+	}
+	if rest, ok := strings.CutPrefix(s, "base64:"); ok {
+		if _, encoded, ok := strings.Cut(rest, ":"); ok {
+			if decoded, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+				return string(decoded)
+			}
+		}
+	}
+	return s
+}
+
+func (m *MySQLConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
+	return m.Execute(ctx, query, ExecOptions{})
+}
+
+func (m *MySQLConnector) Execute(ctx context.Context, query string, opts ExecOptions) (*QueryResult, error) {
+	return m.execute(ctx, query, opts, m.beginReadOnly)
+}
+
+// OpenCursor 결과 행을 하나씩 읽는 커서 열기
+func (m *MySQLConnector) OpenCursor(ctx context.Context, query string, opts ExecOptions) (*Cursor, error) {
+	return m.openCursor(ctx, query, opts, m.beginReadOnly)
+}
+
+// OpenPage offset개 행 다음부터 읽는 페이지 커서 열기
+func (m *MySQLConnector) OpenPage(ctx context.Context, query string, offset int, opts ExecOptions) (*Cursor, error) {
+	return m.openPage(ctx, query, offset, opts, m.beginReadOnly, false)
+}
+
+// DryRun 트랜잭션 안에서 DML을 실행해 영향을 받는 행을 확인한 뒤 롤백
+func (m *MySQLConnector) DryRun(ctx context.Context, query string, params Params) (*DryRunResult, error) {
+	return m.dryRun(ctx, query, params, func(ctx context.Context, target *schema.DMLTarget) ([]string, error) {
+		if err := m.checkTransactional(ctx, target); err != nil {
+			return nil, err
+		}
+		return m.tablePrimaryKey(ctx, target.Schema, target.Table)
+	})
+}
+
+// transactionalEngines 롤백할 수 있는 MySQL 엔진
+var transactionalEngines = map[string]bool{"INNODB": true, "NDB": true, "NDBCLUSTER": true}
+
+// checkTransactional MyISAM 등 트랜잭션을 지원하지 않는 엔진의 테이블이 바뀔 수 있으면 롤백되지 않으므로 거부
+// 대상이 뷰이거나, 여러 테이블 UPDATE/DELETE이거나, 트리거가 있으면 다른 테이블도 바뀔 수 있으므로
+// 서버에 트랜잭션을 지원하지 않는 테이블이 하나라도 있으면 거부합니다.
+func (m *MySQLConnector) checkTransactional(ctx context.Context, target *schema.DMLTarget) error {
+	var tableType, engine sql.NullString
+	var triggers int
+	err := m.db.QueryRowContext(ctx, `
+		SELECT t.TABLE_TYPE, t.ENGINE,
+			(SELECT COUNT(*) FROM INFORMATION_SCHEMA.TRIGGERS g
+			 WHERE g.EVENT_OBJECT_SCHEMA = t.TABLE_SCHEMA AND g.EVENT_OBJECT_TABLE = t.TABLE_NAME)
+		FROM INFORMATION_SCHEMA.TABLES t
+		WHERE t.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND t.TABLE_NAME = ?`,
+		target.Schema, target.Table).Scan(&tableType, &engine, &triggers)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("테이블을 찾을 수 없습니다: %s", target.Table)
+		}
+		return err
+	}
+
+	view := tableType.String == "VIEW" // 뷰는 ENGINE이 NULL
+	if !view && !transactionalEngines[strings.ToUpper(engine.String)] {
+		return fmt.Errorf("%s 테이블의 %s 엔진은 트랜잭션을 지원하지 않아 롤백할 수 없습니다", target.Table, engine.String)
+	}
+
+	var reason string
+	switch {
+	case view:
+		reason = fmt.Sprintf("%s은(는) 뷰여서", target.Table)
+	case target.Joined && target.Statement != "INSERT":
+		reason = "여러 테이블을 참조하는 " + target.Statement + " 문은"
+	case triggers > 0:
+		reason = fmt.Sprintf("%s 테이블에 트리거가 있어", target.Table)
+	default:
+		return nil
+	}
+
+	var other, otherEngine string
+	err = m.db.QueryRowContext(ctx, `
+		SELECT CONCAT(TABLE_SCHEMA, '.', TABLE_NAME), ENGINE
+		FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_TYPE = 'BASE TABLE' AND UPPER(ENGINE) NOT IN ('INNODB', 'NDB', 'NDBCLUSTER')
+			AND TABLE_SCHEMA NOT IN ('mysql', 'information_schema', 'performance_schema', 'sys')
+		LIMIT 1`).Scan(&other, &otherEngine)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%s 다른 테이블도 바꿀 수 있는데, 트랜잭션을 지원하지 않는 테이블(%s, %s 엔진)이 있어 롤백을 보장할 수 없습니다", reason, other, otherEngine)
+}
+
+// beginReadOnly START TRANSACTION READ ONLY
+func (m *MySQLConnector) beginReadOnly(ctx context.Context) (*sql.Tx, func(), error) {
+	return m.beginTx(ctx, &sql.TxOptions{ReadOnly: true}, "")
 }
 
 func (m *MySQLConnector) Explain(ctx context.Context, query string) (string, error) {
+	if err := m.singleStatement(query); err != nil {
+		return "", err
+	}
+
 	explainQuery := "EXPLAIN " + query
 	rows, err := m.db.QueryContext(ctx, explainQuery)
 	if err != nil {
@@ -4,16 +4,19 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
+	"sql-genius/internal/schema"
 	"sql-genius/pkg/models"
+	"strconv"
 	"strings"
-	"time"
 
-	_ "github.com/sijms/go-ora/v2"
+	goora "github.com/sijms/go-ora/v2"
 )
 
 // OracleConnector Oracle 연결자
 type OracleConnector struct {
 	BaseConnector
+	legacyPaging bool // 11g 이하: OFFSET ... FETCH가 없어 페이지를 ROWNUM으로 나눔
 }
 
 // NewOracleConnector Oracle 연결자 생성
@@ -24,215 +27,630 @@ func NewOracleConnector(config models.DBConfig) (*OracleConnector, error) {
 }
 
 func (o *OracleConnector) Connect(ctx context.Context) error {
-	dsn := fmt.Sprintf("oracle://%s:%s@%s:%d/%s",
-		o.config.User, o.config.Password, o.config.Host, o.config.Port, o.config.Database)
+	// go-ora는 클라이언트 인증서를 wallet에서만 읽음
+	if o.config.ClientCert != "" || o.config.ClientKey != "" {
+		return fmt.Errorf("Oracle 연결은 클라이언트 인증서 파일 대신 wallet 디렉터리(ca_cert)를 사용합니다")
+	}
 
-	db, err := sql.Open("oracle", dsn)
+	connector := goora.NewConnector(o.dsn()).(*goora.OracleConnector)
+	tunnel, err := o.openTunnel(ctx)
 	if err != nil {
-		return fmt.Errorf("Oracle 연결 실패: %w", err)
+		return err
 	}
-
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(time.Hour)
+	if tunnel != nil {
+		connector.Dialer(tunnel)
+	}
+	db := sql.OpenDB(connector)
+	configurePool(db, o.config)
 
 	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		tunnel.Close()
 		return fmt.Errorf("Oracle Ping 실패: %w", err)
 	}
 
-	o.db = db
+	o.db, o.tunnel = db, tunnel
+	o.legacyPaging = o.majorVersion(ctx) < 12
 	return nil
 }
 
-func (o *OracleConnector) ExtractSchema(ctx context.Context) (*models.Schema, error) {
+// majorVersion 서버 주 버전 (확인할 수 없으면 OFFSET ... FETCH를 쓸 수 있는 12로 가정)
+func (o *OracleConnector) majorVersion(ctx context.Context) int {
+	var version string
+	err := o.db.QueryRowContext(ctx, `SELECT version FROM product_component_version
+		WHERE product LIKE 'Oracle Database%' AND ROWNUM = 1`).Scan(&version)
+	if err != nil {
+		return 12
+	}
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 12
+	}
+	return major
+}
+
+// dsn oracle:// URL (서비스 이름은 ServiceName, 없으면 Database / SID를 지정하면 SID로 접속)
+// TLS 모드: require는 인증서 검증 없이, verify-ca/verify-full은 검증하며 TCPS로 접속
+// (prefer는 드라이버가 평문으로 되돌아가는 기능이 없어 disable과 같음). CA는 wallet 디렉터리로 지정합니다.
+func (o *OracleConnector) dsn() string {
+	options := map[string]string{}
+	service := o.config.ServiceName
+	if service == "" {
+		service = o.config.Database
+	}
+	if o.config.SID != "" {
+		service = ""
+		options["SID"] = o.config.SID
+	}
+
+	switch o.config.TLSMode {
+	case models.TLSRequire:
+		options["SSL"] = "true"
+		options["SSL VERIFY"] = "false"
+	case models.TLSVerifyCA, models.TLSVerifyFull:
+		options["SSL"] = "true"
+		options["SSL VERIFY"] = "true"
+	}
+	if o.config.CACert != "" {
+		options["WALLET"] = o.config.CACert
+	}
+	if o.config.ApplicationName != "" {
+		options["PROGRAM"] = o.config.ApplicationName
+	}
+	if o.config.ConnectTimeout > 0 {
+		options["CONNECTION TIMEOUT"] = strconv.Itoa(o.config.ConnectTimeout)
+	}
+	if o.config.ReadTimeout > 0 {
+		options["TIMEOUT"] = strconv.Itoa(o.config.ReadTimeout)
+	}
+	for k, v := range o.config.Params {
+		options[k] = v
+	}
+
+	if len(options) == 0 {
+		options = nil
+	}
+	return goora.BuildUrl(o.config.Host, o.config.Port, service, o.config.User, o.config.Password, options)
+}
+
+// ExtractSchema 스키마 추출 (스키마 패턴이 없으면 현재 스키마, 보통 접속 사용자)
+func (o *OracleConnector) ExtractSchema(ctx context.Context, opts ExtractOptions) (*models.Schema, error) {
 	schema := &models.Schema{
 		Database: o.config.Database,
 		DBType:   models.Oracle,
 		Tables:   []models.Table{},
 	}
 
-	tables, err := o.getTables(ctx)
+	if err := extractCatalog(ctx, o, schema, opts); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// TableVersions 추출 범위의 테이블과 LAST_DDL_TIME 버전
+func (o *OracleConnector) TableVersions(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	return o.getTables(ctx, opts)
+}
+
+// oracleSystemSchemas 스키마 패턴으로 전체를 조회할 때 제외하는 Oracle 관리 스키마
+var oracleSystemSchemas = make(map[string]bool)
+
+func init() {
+	for _, name := range strings.Fields(`SYS SYSTEM SYSBACKUP SYSDG SYSKM SYSRAC SYSMAN XDB MDSYS MDDATA CTXSYS ORDSYS ORDDATA
+	ORDPLUGINS OUTLN DBSNMP APPQOSSYS WMSYS OJVMSYS LBACSYS DVSYS DVF AUDSYS GSMADMIN_INTERNAL GSMCATUSER GSMUSER
+	OLAPSYS DBSFWUSER REMOTE_SCHEDULER_AGENT GGSYS ANONYMOUS XS$NULL SI_INFORMTN_SCHEMA ORACLE_OCM DIP EXFSYS FLOWS_FILES`) {
+		oracleSystemSchemas[name] = true
+	}
+}
+
+// oracleOwners 소유자 조건 바인드 값 (:1 = 1이면 전체, 아니면 현재 스키마만)
+func oracleOwners(opts ExtractOptions) int {
+	if opts.allSchemas() {
+		return 1
+	}
+	return 0
+}
+
+// skipOwner 전체 스키마를 조회할 때 관리 스키마의 객체는 건너뜀
+func skipOwner(opts ExtractOptions, owner string) bool {
+	return opts.allSchemas() && oracleSystemSchemas[owner]
+}
+
+// getTables 추출할 테이블 (구체화된 뷰의 저장 테이블은 뷰로 추출하므로 제외)
+// 버전은 테이블과 그 인덱스의 ALL_OBJECTS.LAST_DDL_TIME 중 가장 늦은 값입니다.
+func (o *OracleConnector) getTables(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	query := `
+		SELECT t.owner, t.table_name,
+			TO_CHAR(GREATEST(o.last_ddl_time, NVL((
+				SELECT MAX(io.last_ddl_time)
+				FROM all_indexes ai
+				JOIN all_objects io ON io.owner = ai.owner AND io.object_name = ai.index_name AND io.object_type = 'INDEX'
+				WHERE ai.table_owner = t.owner AND ai.table_name = t.table_name
+			), o.last_ddl_time)), 'YYYY-MM-DD HH24:MI:SS')
+		FROM all_tables t
+		JOIN all_objects o ON o.owner = t.owner AND o.object_name = t.table_name AND o.object_type = 'TABLE'
+		WHERE (:1 = 1 OR t.owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			AND t.nested = 'NO' AND t.secondary = 'N'
+			AND NOT EXISTS (SELECT 1 FROM all_mviews m WHERE m.owner = t.owner AND m.mview_name = t.table_name)
+		ORDER BY t.owner, t.table_name`
+
+	rows, err := o.db.QueryContext(ctx, query, oracleOwners(opts))
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	for _, tableName := range tables {
-		table := models.Table{Name: tableName}
+	var tables []TableVersion
+	for rows.Next() {
+		var t TableVersion
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Version); err != nil {
+			return nil, err
+		}
+		if skipOwner(opts, t.Schema) {
+			continue
+		}
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return opts.filterTables(tables), nil
+}
+
+// getViews 뷰와 구체화된 뷰 (LONG 컬럼은 UNION할 수 없어 따로 조회)
+func (o *OracleConnector) getViews(ctx context.Context, opts ExtractOptions) ([]models.View, error) {
+	queries := []struct {
+		sql          string
+		materialized bool
+	}{
+		{`SELECT owner, view_name, text FROM all_views
+			WHERE (:1 = 1 OR owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			ORDER BY owner, view_name`, false},
+		{`SELECT owner, mview_name, query FROM all_mviews
+			WHERE (:1 = 1 OR owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			ORDER BY owner, mview_name`, true},
+	}
 
-		columns, err := o.getColumns(ctx, tableName)
+	var views []models.View
+	for _, q := range queries {
+		rows, err := o.db.QueryContext(ctx, q.sql, oracleOwners(opts))
 		if err != nil {
 			return nil, err
 		}
-		table.Columns = columns
-
-		indexes, err := o.getIndexes(ctx, tableName)
+		for rows.Next() {
+			v := models.View{Materialized: q.materialized}
+			var definition sql.NullString
+			if err := rows.Scan(&v.Schema, &v.Name, &definition); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if skipOwner(opts, v.Schema) {
+				continue
+			}
+			v.Definition = strings.TrimSpace(definition.String)
+			views = append(views, v)
+		}
+		err = rows.Err()
+		rows.Close()
 		if err != nil {
 			return nil, err
 		}
-		table.Indexes = indexes
+	}
+	return views, nil
+}
 
-		fks, err := o.getForeignKeys(ctx, tableName)
-		if err != nil {
+// getSequences 시퀀스 (identity 컬럼의 ISEQ$$ 시퀀스 제외, 시작값 대신 last_number)
+func (o *OracleConnector) getSequences(ctx context.Context, opts ExtractOptions) ([]models.Sequence, error) {
+	query := `
+		SELECT sequence_owner, sequence_name, last_number, increment_by, cycle_flag
+		FROM all_sequences
+		WHERE (:1 = 1 OR sequence_owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			AND sequence_name NOT LIKE 'ISEQ$$%'
+		ORDER BY sequence_owner, sequence_name`
+
+	rows, err := o.db.QueryContext(ctx, query, oracleOwners(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sequences []models.Sequence
+	for rows.Next() {
+		var seq models.Sequence
+		var cycle string
+		if err := rows.Scan(&seq.Schema, &seq.Name, &seq.Start, &seq.Increment, &cycle); err != nil {
 			return nil, err
 		}
-		table.ForeignKeys = fks
+		if skipOwner(opts, seq.Schema) {
+			continue
+		}
+		seq.Cycle = cycle == "Y"
+		sequences = append(sequences, seq)
+	}
+	return sequences, rows.Err()
+}
 
-		pks, err := o.getPrimaryKeys(ctx, tableName)
-		if err != nil {
+// getRoutines 독립 함수와 프로시저 (패키지 내부 루틴은 제외)
+func (o *OracleConnector) getRoutines(ctx context.Context, opts ExtractOptions) ([]models.Routine, error) {
+	query := `
+		SELECT owner, object_name, object_type
+		FROM all_procedures
+		WHERE (:1 = 1 OR owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			AND object_type IN ('FUNCTION', 'PROCEDURE')
+		ORDER BY owner, object_name`
+
+	rows, err := o.db.QueryContext(ctx, query, oracleOwners(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routines []models.Routine
+	index := make(map[string]int)
+	for rows.Next() {
+		var r models.Routine
+		var kind string
+		if err := rows.Scan(&r.Schema, &r.Name, &kind); err != nil {
 			return nil, err
 		}
-		table.PrimaryKey = pks
+		if skipOwner(opts, r.Schema) {
+			continue
+		}
+		r.Kind = models.RoutineKind(kind)
+		r.Language = "PL/SQL"
+		index[r.Schema+"."+r.Name] = len(routines)
+		routines = append(routines, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if len(routines) == 0 {
+		return nil, nil
+	}
 
-		schema.Tables = append(schema.Tables, table)
+	// 인자 (position 0은 함수의 반환 값, data_level 0만 최상위 인자)
+	argQuery := `
+		SELECT owner, object_name, position, NVL(argument_name, ' '), in_out, NVL(data_type, ' ')
+		FROM all_arguments
+		WHERE (:1 = 1 OR owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			AND package_name IS NULL AND data_level = 0
+		ORDER BY owner, object_name, position`
+
+	rows, err = o.db.QueryContext(ctx, argQuery, oracleOwners(opts))
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return schema, nil
+	args := make(map[int][]string)
+	for rows.Next() {
+		var owner, name, argName, inOut, dataType string
+		var position int
+		if err := rows.Scan(&owner, &name, &position, &argName, &inOut, &dataType); err != nil {
+			return nil, err
+		}
+		i, ok := index[owner+"."+name]
+		if !ok || strings.TrimSpace(dataType) == "" {
+			continue // 인자가 없는 루틴도 data_type이 빈 행 하나가 있음
+		}
+		if position == 0 {
+			routines[i].Returns = dataType
+			continue
+		}
+		args[i] = append(args[i], fmt.Sprintf("%s %s %s", argName, strings.ReplaceAll(inOut, "/", " "), dataType))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	sources, err := o.getSource(ctx, opts, "FUNCTION", "PROCEDURE")
+	if err != nil {
+		return nil, err
+	}
+	for i := range routines {
+		routines[i].Arguments = strings.Join(args[i], ", ")
+		routines[i].Definition = sources[routines[i].Schema+"."+routines[i].Name]
+	}
+	return routines, nil
 }
 
-func (o *OracleConnector) getTables(ctx context.Context) ([]string, error) {
-	query := `SELECT table_name FROM user_tables ORDER BY table_name`
+// getTriggers 테이블·뷰 트리거 (스키마·데이터베이스 이벤트 트리거 제외)
+func (o *OracleConnector) getTriggers(ctx context.Context, opts ExtractOptions) ([]models.Trigger, error) {
+	query := `
+		SELECT owner, trigger_name, table_name, trigger_type, triggering_event
+		FROM all_triggers
+		WHERE (:1 = 1 OR owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			AND base_object_type IN ('TABLE', 'VIEW')
+		ORDER BY owner, table_name, trigger_name`
 
-	rows, err := o.db.QueryContext(ctx, query)
+	rows, err := o.db.QueryContext(ctx, query, oracleOwners(opts))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var tables []string
+	var triggers []models.Trigger
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var t models.Trigger
+		var triggerType, events string
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Table, &triggerType, &events); err != nil {
 			return nil, err
 		}
-		tables = append(tables, name)
+		if skipOwner(opts, t.Schema) {
+			continue
+		}
+		// trigger_type: BEFORE EACH ROW, AFTER STATEMENT, INSTEAD OF, COMPOUND
+		t.Timing = strings.TrimSuffix(strings.TrimSuffix(triggerType, " EACH ROW"), " STATEMENT")
+		t.Events = strings.Split(events, " OR ")
+		triggers = append(triggers, t)
 	}
-	return tables, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if len(triggers) == 0 {
+		return nil, nil
+	}
+
+	sources, err := o.getSource(ctx, opts, "TRIGGER")
+	if err != nil {
+		return nil, err
+	}
+	for i := range triggers {
+		triggers[i].Definition = sources[triggers[i].Schema+"."+triggers[i].Name]
+	}
+	return triggers, nil
 }
 
-func (o *OracleConnector) getColumns(ctx context.Context, table string) ([]models.Column, error) {
+// getSource all_source의 줄을 합쳐 CREATE OR REPLACE 문으로 (키: owner.name)
+func (o *OracleConnector) getSource(ctx context.Context, opts ExtractOptions, types ...string) (map[string]string, error) {
+	placeholders := make([]string, len(types))
+	args := []interface{}{oracleOwners(opts)}
+	for i, t := range types {
+		placeholders[i] = fmt.Sprintf(":%d", i+2)
+		args = append(args, t)
+	}
+	query := `
+		SELECT owner, name, text
+		FROM all_source
+		WHERE (:1 = 1 OR owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			AND type IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY owner, name, line`
+
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sources := make(map[string]*strings.Builder)
+	for rows.Next() {
+		var owner, name string
+		var text sql.NullString
+		if err := rows.Scan(&owner, &name, &text); err != nil {
+			return nil, err
+		}
+		key := owner + "." + name
+		sb, ok := sources[key]
+		if !ok {
+			sb = &strings.Builder{}
+			sb.WriteString("CREATE OR REPLACE ")
+			sources[key] = sb
+		}
+		sb.WriteString(text.String)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(sources))
+	for key, sb := range sources {
+		result[key] = strings.TrimSpace(sb.String())
+	}
+	return result, nil
+}
+
+// oracleNotNull NOT NULL 컬럼마다 만들어지는 "COL" IS NOT NULL 제약조건
+var oracleNotNull = regexp.MustCompile(`^"[^"]+" IS NOT NULL$`)
+
+// getChecks CHECK 제약조건 (NOT NULL 제약 제외, 시스템이 붙인 이름은 비움)
+func (o *OracleConnector) getChecks(ctx context.Context, schema string) (map[string][]models.Check, error) {
+	query := `
+		SELECT table_name, constraint_name, search_condition, generated
+		FROM all_constraints
+		WHERE owner = :1 AND constraint_type = 'C'
+		ORDER BY table_name, constraint_name`
+
+	rows, err := o.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string][]models.Check)
+	for rows.Next() {
+		var table string
+		var c models.Check
+		var condition sql.NullString
+		var generated string
+		if err := rows.Scan(&table, &c.Name, &condition, &generated); err != nil {
+			return nil, err
+		}
+		c.Expression = strings.TrimSpace(condition.String)
+		if generated == "GENERATED NAME" {
+			if oracleNotNull.MatchString(c.Expression) {
+				continue
+			}
+			c.Name = ""
+		}
+		checks[table] = append(checks[table], c)
+	}
+	return checks, rows.Err()
+}
+
+// getColumns 스키마의 테이블과 뷰 컬럼
+func (o *OracleConnector) getColumns(ctx context.Context, schema string) (map[string][]models.Column, error) {
 	query := `
 		SELECT 
-			c.column_name, c.data_type, c.nullable, c.data_default,
-			NVL((SELECT 'Y' FROM user_cons_columns ucc
-				JOIN user_constraints uc ON ucc.constraint_name = uc.constraint_name
-				WHERE uc.constraint_type = 'P' AND ucc.table_name = c.table_name 
-				AND ucc.column_name = c.column_name AND ROWNUM = 1), 'N') as is_pk
-		FROM user_tab_columns c
-		WHERE c.table_name = :1
-		ORDER BY c.column_id`
-
-	rows, err := o.db.QueryContext(ctx, query, strings.ToUpper(table))
+			c.table_name, c.column_name, c.data_type, c.nullable, c.data_default,
+			NVL2(pk.column_name, 'Y', 'N') as is_pk,
+			c.virtual_column
+		FROM all_tab_cols c
+		LEFT JOIN (
+			SELECT DISTINCT acc.table_name, acc.column_name
+			FROM all_cons_columns acc
+			JOIN all_constraints ac ON acc.owner = ac.owner AND acc.constraint_name = ac.constraint_name
+			WHERE ac.owner = :1 AND ac.constraint_type = 'P'
+		) pk ON pk.table_name = c.table_name AND pk.column_name = c.column_name
+		WHERE c.owner = :2 AND c.hidden_column = 'NO'
+		ORDER BY c.table_name, c.column_id`
+
+	rows, err := o.db.QueryContext(ctx, query, schema, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var columns []models.Column
+	columns := make(map[string][]models.Column)
 	for rows.Next() {
 		var col models.Column
-		var nullable, isPK string
+		var table, nullable, isPK, virtual string
 		var defaultVal sql.NullString
 
-		if err := rows.Scan(&col.Name, &col.Type, &nullable, &defaultVal, &isPK); err != nil {
+		if err := rows.Scan(&table, &col.Name, &col.Type, &nullable, &defaultVal, &isPK, &virtual); err != nil {
 			return nil, err
 		}
 
 		col.Nullable = nullable == "Y"
 		col.IsPK = isPK == "Y"
-		if defaultVal.Valid {
+		if virtual == "YES" {
+			// 가상 컬럼은 data_default에 계산식이 들어 있음
+			col.Generated = generatedExpr(defaultVal.String)
+		} else if defaultVal.Valid {
 			col.Default = defaultVal.String
 		}
 
-		columns = append(columns, col)
+		columns[table] = append(columns[table], col)
 	}
-	return columns, nil
+	return columns, rows.Err()
 }
 
-func (o *OracleConnector) getIndexes(ctx context.Context, table string) ([]models.Index, error) {
+func (o *OracleConnector) getIndexes(ctx context.Context, schema string) (map[string][]models.Index, error) {
 	query := `
-		SELECT ui.index_name, uic.column_name, ui.uniqueness, ui.index_type
-		FROM user_indexes ui
-		JOIN user_ind_columns uic ON ui.index_name = uic.index_name
-		WHERE ui.table_name = :1
-		ORDER BY ui.index_name, uic.column_position`
+		SELECT ai.table_name, ai.index_name, aic.column_name, ai.uniqueness, ai.index_type
+		FROM all_indexes ai
+		JOIN all_ind_columns aic ON ai.owner = aic.index_owner AND ai.index_name = aic.index_name
+		WHERE ai.table_owner = :1
+		ORDER BY ai.table_name, ai.index_name, aic.column_position`
 
-	rows, err := o.db.QueryContext(ctx, query, strings.ToUpper(table))
+	rows, err := o.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	indexMap := make(map[string]*models.Index)
+	indexes := make(map[string][]models.Index)
 	for rows.Next() {
-		var indexName, columnName, uniqueness, indexType string
+		var table, indexName, columnName, uniqueness, indexType string
 
-		if err := rows.Scan(&indexName, &columnName, &uniqueness, &indexType); err != nil {
+		if err := rows.Scan(&table, &indexName, &columnName, &uniqueness, &indexType); err != nil {
 			return nil, err
 		}
 
-		if idx, exists := indexMap[indexName]; exists {
-			idx.Columns = append(idx.Columns, columnName)
-		} else {
-			indexMap[indexName] = &models.Index{
-				Name:     indexName,
-				Columns:  []string{columnName},
-				IsUnique: uniqueness == "UNIQUE",
-				Type:     indexType,
-			}
+		list := indexes[table]
+		if n := len(list); n > 0 && list[n-1].Name == indexName {
+			list[n-1].Columns = append(list[n-1].Columns, columnName)
+			continue
 		}
+		indexes[table] = append(list, models.Index{
+			Name:     indexName,
+			Columns:  []string{columnName},
+			IsUnique: uniqueness == "UNIQUE",
+			Type:     indexType,
+		})
 	}
-
-	var indexes []models.Index
-	for _, idx := range indexMap {
-		indexes = append(indexes, *idx)
-	}
-	return indexes, nil
+	return indexes, rows.Err()
 }
 
-func (o *OracleConnector) getForeignKeys(ctx context.Context, table string) ([]models.FK, error) {
+// getForeignKeys 외래키 (참조 제약조건을 볼 권한이 없으면 참조 정보는 비어 있음)
+func (o *OracleConnector) getForeignKeys(ctx context.Context, schema string) (map[string][]models.FK, error) {
 	query := `
 		SELECT 
-			uc.constraint_name,
-			ucc.column_name,
-			(SELECT table_name FROM user_constraints WHERE constraint_name = uc.r_constraint_name) as ref_table,
-			(SELECT column_name FROM user_cons_columns WHERE constraint_name = uc.r_constraint_name AND ROWNUM = 1) as ref_column
-		FROM user_constraints uc
-		JOIN user_cons_columns ucc ON uc.constraint_name = ucc.constraint_name
-		WHERE uc.table_name = :1 AND uc.constraint_type = 'R'`
-
-	rows, err := o.db.QueryContext(ctx, query, strings.ToUpper(table))
+			ac.table_name,
+			ac.constraint_name,
+			acc.column_name,
+			ac.r_owner as ref_schema,
+			rc.table_name as ref_table,
+			rcc.column_name as ref_column
+		FROM all_constraints ac
+		JOIN all_cons_columns acc ON ac.owner = acc.owner AND ac.constraint_name = acc.constraint_name
+		LEFT JOIN all_constraints rc ON rc.owner = ac.r_owner AND rc.constraint_name = ac.r_constraint_name
+		LEFT JOIN all_cons_columns rcc ON rcc.owner = rc.owner AND rcc.constraint_name = rc.constraint_name
+			AND rcc.position = acc.position
+		WHERE ac.owner = :1 AND ac.constraint_type = 'R'
+		ORDER BY ac.table_name, ac.constraint_name, acc.position`
+
+	rows, err := o.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var fks []models.FK
+	fks := make(map[string][]models.FK)
 	for rows.Next() {
+		var table string
 		var fk models.FK
-		var refTable, refColumn sql.NullString
-		if err := rows.Scan(&fk.Name, &fk.Column, &refTable, &refColumn); err != nil {
+		var refSchema, refTable, refColumn sql.NullString
+		if err := rows.Scan(&table, &fk.Name, &fk.Column, &refSchema, &refTable, &refColumn); err != nil {
 			return nil, err
 		}
-		if refTable.Valid {
-			fk.RefTable = refTable.String
-		}
-		if refColumn.Valid {
-			fk.RefColumn = refColumn.String
-		}
-		fks = append(fks, fk)
+		fk.RefSchema = refSchema.String
+		fk.RefTable = refTable.String
+		fk.RefColumn = refColumn.String
+		fks[table] = append(fks[table], fk)
 	}
-	return fks, nil
+	return fks, rows.Err()
 }
 
-func (o *OracleConnector) getPrimaryKeys(ctx context.Context, table string) ([]string, error) {
+// getPrimaryKeys 스키마의 테이블별 기본 키 컬럼
+func (o *OracleConnector) getPrimaryKeys(ctx context.Context, schema string) (map[string][]string, error) {
 	query := `
-		SELECT ucc.column_name
-		FROM user_constraints uc
-		JOIN user_cons_columns ucc ON uc.constraint_name = ucc.constraint_name
-		WHERE uc.table_name = :1 AND uc.constraint_type = 'P'
-		ORDER BY ucc.position`
+		SELECT ac.table_name, acc.column_name
+		FROM all_constraints ac
+		JOIN all_cons_columns acc ON ac.owner = acc.owner AND ac.constraint_name = acc.constraint_name
+		WHERE ac.owner = :1 AND ac.constraint_type = 'P'
+		ORDER BY ac.table_name, acc.position`
+
+	rows, err := o.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	rows, err := o.db.QueryContext(ctx, query, strings.ToUpper(table))
+	pks := make(map[string][]string)
+	for rows.Next() {
+		var table, pk string
+		if err := rows.Scan(&table, &pk); err != nil {
+			return nil, err
+		}
+		pks[table] = append(pks[table], pk)
+	}
+	return pks, rows.Err()
+}
+
+// tablePrimaryKey 테이블 하나의 기본 키 컬럼 (schema가 비어 있으면 현재 스키마)
+func (o *OracleConnector) tablePrimaryKey(ctx context.Context, schema, table string) ([]string, error) {
+	query := `
+		SELECT acc.column_name
+		FROM all_constraints ac
+		JOIN all_cons_columns acc ON ac.owner = acc.owner AND ac.constraint_name = acc.constraint_name
+		WHERE ac.owner = NVL(:1, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+			AND ac.table_name = :2 AND ac.constraint_type = 'P'
+		ORDER BY acc.position`
+
+	rows, err := o.db.QueryContext(ctx, query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -246,54 +664,136 @@ func (o *OracleConnector) getPrimaryKeys(ctx context.Context, table string) ([]s
 		}
 		pks = append(pks, pk)
 	}
-	return pks, nil
+	return pks, rows.Err()
 }
 
-func (o *OracleConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
-	start := time.Now()
+// ExtractStats 옵티마이저 통계 (DBMS_STATS로 수집한 값, 수집하지 않은 테이블은 행 수를 모름)
+// 컬럼 통계는 DBA_TAB_COL_STATISTICS와 같은 내용을 권한 있는 테이블만 보여주는 ALL_ 뷰에서 읽습니다.
+func (o *OracleConnector) ExtractStats(ctx context.Context, schema *models.Schema) error {
+	return extractStats(ctx, o, schema)
+}
+
+// getTableStats num_rows와 블록 수 × 블록 크기 (파티션 테이블은 테이블스페이스가 없어 크기를 모름)
+func (o *OracleConnector) getTableStats(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT t.table_name, t.num_rows, t.blocks * ts.block_size
+		FROM all_tables t
+		LEFT JOIN user_tablespaces ts ON ts.tablespace_name = t.tablespace_name
+		WHERE t.owner = :1`
 
-	rows, err := o.db.QueryContext(ctx, query)
+	rows, err := o.db.QueryContext(ctx, query, schema)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
+	for rows.Next() {
+		var name string
+		var count, size sql.NullInt64
+		if err := rows.Scan(&name, &count, &size); err != nil {
+			return err
+		}
+		stats := models.TableStats{Rows: -1, SizeBytes: size.Int64}
+		if count.Valid {
+			stats.Rows = count.Int64
+		}
+		target.setTable(schema, name, stats)
+	}
+	return rows.Err()
+}
+
+// getColumnStats 고유 값 수와 NULL 수, 히스토그램 끝점 (히스토그램이 없는 컬럼은 최솟값과 최댓값 두 개)
+// 끝점 값은 문자 컬럼은 endpoint_actual_value를, 숫자 컬럼은 endpoint_value를 쓰고 날짜 등은 건너뜁니다.
+func (o *OracleConnector) getColumnStats(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT table_name, column_name, num_distinct, num_nulls
+		FROM all_tab_col_statistics
+		WHERE owner = :1`
+
+	rows, err := o.db.QueryContext(ctx, query, schema)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer rows.Close()
 
-	var resultRows [][]interface{}
 	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		valuePtrs := make([]interface{}, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
+		var table, column string
+		var distinct, nulls sql.NullInt64
+		if err := rows.Scan(&table, &column, &distinct, &nulls); err != nil {
+			return err
 		}
-
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, err
+		if stats := target.column(schema, table, column); stats != nil {
+			stats.Distinct = distinct.Int64
+			stats.NullFraction = nullFraction(nulls.Int64, target.rows(schema, table))
 		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
-		row := make([]interface{}, len(columns))
-		for i, v := range values {
-			if b, ok := v.([]byte); ok {
-				row[i] = string(b)
-			} else {
-				row[i] = v
-			}
+	query = `
+		SELECT h.table_name, h.column_name, NVL(h.endpoint_actual_value, TO_CHAR(h.endpoint_value))
+		FROM all_tab_histograms h
+		JOIN all_tab_cols c ON c.owner = h.owner AND c.table_name = h.table_name AND c.column_name = h.column_name
+		WHERE h.owner = :1
+			AND (h.endpoint_actual_value IS NOT NULL OR c.data_type IN ('NUMBER', 'FLOAT', 'BINARY_FLOAT', 'BINARY_DOUBLE'))
+		ORDER BY h.table_name, h.column_name, h.endpoint_number`
+
+	histRows, err := o.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return err
+	}
+	defer histRows.Close()
+
+	for histRows.Next() {
+		var table, column string
+		var value sql.NullString
+		if err := histRows.Scan(&table, &column, &value); err != nil {
+			return err
+		}
+		if stats := target.column(schema, table, column); stats != nil && value.Valid {
+			stats.Histogram = append(stats.Histogram, value.String)
 		}
-		resultRows = append(resultRows, row)
 	}
+	return histRows.Err()
+}
 
-	return &QueryResult{
-		Columns:  columns,
-		Rows:     resultRows,
-		Duration: time.Since(start).Milliseconds(),
-	}, nil
+func (o *OracleConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
+	return o.Execute(ctx, query, ExecOptions{})
+}
+
+func (o *OracleConnector) Execute(ctx context.Context, query string, opts ExecOptions) (*QueryResult, error) {
+	return o.execute(ctx, query, opts, o.beginReadOnly)
+}
+
+// OpenCursor 결과 행을 하나씩 읽는 커서 열기
+func (o *OracleConnector) OpenCursor(ctx context.Context, query string, opts ExecOptions) (*Cursor, error) {
+	return o.openCursor(ctx, query, opts, o.beginReadOnly)
+}
+
+// OpenPage offset개 행 다음부터 읽는 페이지 커서 열기
+func (o *OracleConnector) OpenPage(ctx context.Context, query string, offset int, opts ExecOptions) (*Cursor, error) {
+	return o.openPage(ctx, query, offset, opts, o.beginReadOnly, o.legacyPaging)
+}
+
+// DryRun 트랜잭션 안에서 DML을 실행해 영향을 받는 행을 확인한 뒤 롤백
+func (o *OracleConnector) DryRun(ctx context.Context, query string, params Params) (*DryRunResult, error) {
+	// 따옴표 없이 쓴 이름은 대문자로 저장되어 있음
+	return o.dryRun(ctx, query, params, func(ctx context.Context, target *schema.DMLTarget) ([]string, error) {
+		return o.tablePrimaryKey(ctx, strings.ToUpper(target.Schema), strings.ToUpper(target.Table))
+	})
+}
+
+// beginReadOnly SET TRANSACTION READ ONLY (트랜잭션의 첫 문장이어야 함)
+func (o *OracleConnector) beginReadOnly(ctx context.Context) (*sql.Tx, func(), error) {
+	return o.beginTx(ctx, nil, "SET TRANSACTION READ ONLY")
 }
 
 func (o *OracleConnector) Explain(ctx context.Context, query string) (string, error) {
+	if err := o.singleStatement(query); err != nil {
+		return "", err
+	}
+
 	// Oracle EXPLAIN PLAN
 	explainQuery := fmt.Sprintf("EXPLAIN PLAN FOR %s", query)
 	_, err := o.db.ExecContext(ctx, explainQuery)
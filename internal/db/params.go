@@ -0,0 +1,185 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sql-genius/internal/schema"
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// Params 바인드 파라미터 값 (위치 또는 이름 중 하나만 사용)
+type Params struct {
+	Args  []interface{}          `json:"args,omitempty"`  // ?, $1, :1, @p1 순서의 값
+	Named map[string]interface{} `json:"named,omitempty"` // :name, @name 값
+}
+
+// Empty 전달된 값이 없는지
+func (p Params) Empty() bool {
+	return len(p.Args) == 0 && len(p.Named) == 0
+}
+
+// lookup 이름 있는 파라미터 값 (대소문자 무시, :name/@name 키 허용)
+func (p Params) lookup(name string) (interface{}, bool) {
+	if v, ok := p.Named[name]; ok {
+		return v, true
+	}
+	for key, v := range p.Named {
+		if strings.EqualFold(strings.TrimLeft(key, ":@"), name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// bind 쿼리의 바인드 파라미터를 방언 형식으로 바꾸고 드라이버에 넘길 인자 생성
+// MySQL·SQLite ?, PostgreSQL $1, Oracle :name, SQL Server @p1
+// 같은 이름이 여러 번 나오면 같은 값을 사용합니다. 값이 없으면 쿼리를 그대로 둡니다.
+func (b *BaseConnector) bind(query string, params Params) (string, []interface{}, error) {
+	if params.Empty() {
+		return query, nil, nil
+	}
+	if len(params.Args) > 0 && len(params.Named) > 0 {
+		return "", nil, fmt.Errorf("위치 파라미터와 이름 있는 파라미터를 함께 사용할 수 없습니다")
+	}
+
+	var (
+		bindErr error
+		values  []interface{}
+		names   []string
+		args    []interface{}
+		slots   = make(map[string]int)
+	)
+	bound, _, err := schema.NewParser().RewritePlaceholders(query, b.config.Type, func(ph schema.Placeholder) string {
+		key := ph.Name
+		if ph.Positional() {
+			key = fmt.Sprintf("#%d", ph.Index)
+		}
+		slot, ok := slots[key]
+		if !ok {
+			var v interface{}
+			switch {
+			case ph.Positional() && len(params.Args) == 0:
+				bindErr = fmt.Errorf("위치 파라미터 %s에는 이름 있는 값을 사용할 수 없습니다", ph.Text)
+			case ph.Positional() && ph.Index > len(params.Args):
+				bindErr = fmt.Errorf("파라미터 %s의 값이 없습니다 (%d개 전달)", paramLabel(ph), len(params.Args))
+			case ph.Positional():
+				v = params.Args[ph.Index-1]
+			case len(params.Named) == 0:
+				bindErr = fmt.Errorf("이름 있는 파라미터 %s에는 위치 값을 사용할 수 없습니다", ph.Text)
+			default:
+				if v, ok = params.lookup(ph.Name); !ok {
+					bindErr = fmt.Errorf("파라미터 %s의 값이 없습니다", ph.Text)
+				}
+			}
+			values = append(values, v)
+			names = append(names, ph.Name)
+			slot = len(values)
+			slots[key] = slot
+		}
+
+		switch b.config.Type {
+		case models.MySQL, models.SQLite:
+			// ?는 등장 순서대로 값을 전달
+			args = append(args, values[slot-1])
+			return "?"
+		case models.Oracle:
+			if ph.Name != "" {
+				return ":" + ph.Name
+			}
+			return fmt.Sprintf(":p%d", slot)
+		default:
+			return b.placeholder(slot)
+		}
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if bindErr != nil {
+		return "", nil, bindErr
+	}
+
+	switch b.config.Type {
+	case models.MySQL, models.SQLite:
+	case models.Oracle:
+		// 같은 이름이 반복되면 위치 바인딩이 어긋나므로 이름으로 바인딩
+		for i, v := range values {
+			name := names[i]
+			if name == "" {
+				name = fmt.Sprintf("p%d", i+1)
+			}
+			args = append(args, sql.Named(name, v))
+		}
+	default:
+		args = values
+	}
+	return bound, args, nil
+}
+
+// namedParams 위치 파라미터를 :p1 형태의 이름 있는 파라미터로 통일
+// 쿼리 일부(DML 미리보기 SELECT 등)만 실행해도 같은 값을 찾을 수 있습니다.
+func (b *BaseConnector) namedParams(query string, params Params) (string, Params, error) {
+	if len(params.Args) == 0 {
+		return query, params, nil
+	}
+	if len(params.Named) > 0 {
+		return "", Params{}, fmt.Errorf("위치 파라미터와 이름 있는 파라미터를 함께 사용할 수 없습니다")
+	}
+
+	named := make(map[string]interface{})
+	var bindErr error
+	rewritten, _, err := schema.NewParser().RewritePlaceholders(query, b.config.Type, func(ph schema.Placeholder) string {
+		if !ph.Positional() {
+			bindErr = fmt.Errorf("이름 있는 파라미터 %s에는 위치 값을 사용할 수 없습니다", ph.Text)
+			return ph.Text
+		}
+		if ph.Index > len(params.Args) {
+			bindErr = fmt.Errorf("파라미터 %s의 값이 없습니다 (%d개 전달)", paramLabel(ph), len(params.Args))
+			return ph.Text
+		}
+		name := fmt.Sprintf("p%d", ph.Index)
+		named[name] = params.Args[ph.Index-1]
+		return ":" + name
+	})
+	if err != nil {
+		return "", Params{}, err
+	}
+	if bindErr != nil {
+		return "", Params{}, bindErr
+	}
+	return rewritten, Params{Named: named}, nil
+}
+
+// paramLabel 오류 메시지용 파라미터 표기 (?는 순서 포함)
+func paramLabel(ph schema.Placeholder) string {
+	if ph.Text == "?" {
+		return fmt.Sprintf("%d번째 ?", ph.Index)
+	}
+	return ph.Text
+}
+
+// placeholder 방언별 n번째 바인드 변수
+func (b *BaseConnector) placeholder(n int) string {
+	switch b.config.Type {
+	case models.PostgreSQL:
+		return fmt.Sprintf("$%d", n)
+	case models.Oracle:
+		return fmt.Sprintf(":%d", n)
+	case models.SQLServer:
+		return fmt.Sprintf("@p%d", n)
+	default:
+		return "?"
+	}
+}
+
+// QuoteIdent 방언별 식별자 인용
+func QuoteIdent(dbType models.DBType, name string) string {
+	switch dbType {
+	case models.MySQL:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case models.SQLServer:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
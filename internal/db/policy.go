@@ -0,0 +1,229 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sql-genius/internal/schema"
+	"sql-genius/pkg/models"
+	"time"
+)
+
+// ExecOptions 쿼리 실행 옵션
+type ExecOptions struct {
+	Confirmed bool   // confirm-dml 정책에서 사용자가 DML 실행을 확인함
+	Params    Params // 바인드 파라미터 값
+	Limits           // 결과 집합마다 읽을 최대 크기
+}
+
+// Limits 결과 크기 제한 (0이면 제한 없음)
+type Limits struct {
+	MaxRows  int   // 최대 행 수
+	MaxBytes int64 // 최대 바이트 수 (값 크기 추정치)
+}
+
+// PolicyError 실행 정책에 의해 차단된 쿼리
+type PolicyError struct {
+	Policy            models.ExecPolicy
+	Kind              schema.StatementKind
+	NeedsConfirmation bool // 확인하면 실행할 수 있음 (confirm-dml 정책의 DML)
+}
+
+func (e *PolicyError) Error() string {
+	if e.NeedsConfirmation {
+		return fmt.Sprintf("%s 정책: %s 쿼리는 확인 후 실행할 수 있습니다", e.Policy, kindLabel(e.Kind))
+	}
+	return fmt.Sprintf("%s 정책: %s 쿼리는 실행할 수 없습니다", e.Policy, kindLabel(e.Kind))
+}
+
+func kindLabel(kind schema.StatementKind) string {
+	switch kind {
+	case schema.StatementRead:
+		return "조회"
+	case schema.StatementDML:
+		return "데이터 변경(DML)"
+	case schema.StatementDDL:
+		return "스키마 변경(DDL)"
+	case schema.StatementMulti:
+		return "여러 문장으로 된"
+	default:
+		return "영향을 알 수 없는"
+	}
+}
+
+// policyRank 정책의 허용 범위 (클수록 느슨함)
+var policyRank = map[models.ExecPolicy]int{
+	models.PolicyReadOnly:     0,
+	models.PolicyConfirmDML:   1,
+	models.PolicyUnrestricted: 2,
+}
+
+// ParsePolicy 문자열을 실행 정책으로 변환 (빈 문자열은 read-only)
+func ParsePolicy(s string) (models.ExecPolicy, error) {
+	if s == "" {
+		return models.PolicyReadOnly, nil
+	}
+	policy := models.ExecPolicy(s)
+	if _, ok := policyRank[policy]; !ok {
+		return "", fmt.Errorf("알 수 없는 실행 정책: %s (read-only, confirm-dml, unrestricted)", s)
+	}
+	return policy, nil
+}
+
+// PolicyWithin policy가 limit보다 느슨하지 않은지 확인
+func PolicyWithin(policy, limit models.ExecPolicy) bool {
+	return policyRank[policy] <= policyRank[limit]
+}
+
+// Policy 연결의 실행 정책 (미지정 시 read-only)
+func (b *BaseConnector) Policy() models.ExecPolicy {
+	if b.config.Policy == "" {
+		return models.PolicyReadOnly
+	}
+	return b.config.Policy
+}
+
+// authorize 실행 정책에 따라 쿼리 허용 여부 판단
+// readOnly가 true면 읽기 전용 트랜잭션 안에서 실행해야 합니다.
+func (b *BaseConnector) authorize(query string, opts ExecOptions) (c *schema.Classification, readOnly bool, err error) {
+	c, err = schema.NewParser().Classify(query, b.config.Type)
+	if err != nil {
+		return nil, false, err
+	}
+
+	policy := b.Policy()
+	if policy == models.PolicyUnrestricted {
+		return c, false, nil
+	}
+
+	switch c.Kind {
+	case schema.StatementRead:
+		return c, true, nil
+	case schema.StatementDML:
+		if policy == models.PolicyConfirmDML {
+			if opts.Confirmed {
+				return c, false, nil
+			}
+			return nil, false, &PolicyError{Policy: policy, Kind: c.Kind, NeedsConfirmation: true}
+		}
+	}
+	return nil, false, &PolicyError{Policy: policy, Kind: c.Kind}
+}
+
+// singleStatement EXPLAIN 등에 여러 문장이 붙어 뒤 문장이 실행되지 않도록 확인
+func (b *BaseConnector) singleStatement(query string) error {
+	c, err := schema.NewParser().Classify(query, b.config.Type)
+	if err != nil {
+		return err
+	}
+	if c.Kind == schema.StatementMulti {
+		return fmt.Errorf("한 번에 한 문장만 사용할 수 있습니다 (%d개 문장)", len(c.Statements))
+	}
+	return nil
+}
+
+// readOnlyBegin 방언별 읽기 전용 트랜잭션 시작 (done은 롤백과 정리를 수행)
+type readOnlyBegin func(ctx context.Context) (tx *sql.Tx, done func(), err error)
+
+// execute 정책 확인 후 쿼리 실행 (조회는 읽기 전용 트랜잭션 안에서 실행하고 항상 롤백)
+// 결과 집합이 없는 문장은 ExecContext로 실행해 영향 행 수를 반환합니다.
+func (b *BaseConnector) execute(ctx context.Context, query string, opts ExecOptions, begin readOnlyBegin) (*QueryResult, error) {
+	c, readOnly, err := b.authorize(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	units, err := b.executionUnits(query, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var r runner = b.db
+	if readOnly {
+		tx, done, err := begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("읽기 전용 트랜잭션 시작 실패: %w", err)
+		}
+		defer done()
+		r = tx
+	} else if len(units) > 1 {
+		// 임시 테이블, 세션 변수가 이어지도록 한 연결에서 차례로 실행
+		conn, err := b.db.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		r = conn
+	} else if c.Kind == schema.StatementMulti && b.multi != nil {
+		r = b.multi
+	}
+
+	start := time.Now()
+	var result *QueryResult
+	for _, unit := range units {
+		uc := c
+		if len(units) > 1 {
+			if uc, err = schema.NewParser().Classify(unit, b.config.Type); err != nil {
+				return nil, err
+			}
+		}
+		bound, args, err := b.bind(unit, opts.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		var part *QueryResult
+		if uc.ReturnsRows {
+			part, err = b.runQueryLimit(ctx, r, opts.Limits, bound, args...)
+			if err == nil && uc.Kind == schema.StatementDML {
+				part.RowsAffected = int64(len(part.Rows)) // RETURNING/OUTPUT 행 수
+				if part.Truncated {
+					part.RowsAffected = -1 // 제한 때문에 다 읽지 않아 알 수 없음
+				}
+			}
+		} else {
+			part, err = b.runExec(ctx, r, uc.Command == "INSERT" || uc.Command == "REPLACE", bound, args...)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = mergeResults(result, part)
+	}
+	result.Duration = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// executionUnits 드라이버에 한 번에 보낼 단위로 쿼리를 나눔
+// SQL Server는 GO 배치, MySQL(multiStatements 전용 연결)·PostgreSQL은 여러 문장을 그대로 보내 결과 집합을 차례로 받고,
+// 여러 문장을 지원하지 않는 SQLite·Oracle은 문장별로 실행합니다.
+func (b *BaseConnector) executionUnits(query string, c *schema.Classification) ([]string, error) {
+	parser := schema.NewParser()
+	switch b.config.Type {
+	case models.SQLServer:
+		return parser.SplitBatches(query, b.config.Type)
+	case models.Oracle:
+		if c.Block {
+			return []string{query}, nil // PL/SQL 블록은 내부 ';'까지 한 번에 보냄
+		}
+		return parser.SplitStatements(query, b.config.Type) // Oracle은 문장 끝 ';'도 허용하지 않음
+	case models.SQLite:
+		if c.Kind == schema.StatementMulti {
+			return parser.SplitStatements(query, b.config.Type)
+		}
+	}
+	return []string{query}, nil
+}
+
+// beginTx 트랜잭션을 시작하고 setup 문(SET TRANSACTION READ ONLY 등)을 실행
+func (b *BaseConnector) beginTx(ctx context.Context, opts *sql.TxOptions, setup string) (*sql.Tx, func(), error) {
+	tx, err := b.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if setup != "" {
+		if _, err := tx.ExecContext(ctx, setup); err != nil {
+			tx.Rollback()
+			return nil, nil, err
+		}
+	}
+	return tx, func() { tx.Rollback() }, nil
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"sql-genius/internal/schema"
 	"sql-genius/pkg/models"
+	"strconv"
 	"strings"
-	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // PostgresConnector PostgreSQL 연결자
@@ -24,133 +26,191 @@ func NewPostgresConnector(config models.DBConfig) (*PostgresConnector, error) {
 }
 
 func (p *PostgresConnector) Connect(ctx context.Context) error {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		p.config.Host, p.config.Port, p.config.User, p.config.Password, p.config.Database)
-
-	db, err := sql.Open("postgres", dsn)
+	connector, err := pq.NewConnector(p.dsn())
 	if err != nil {
 		return fmt.Errorf("PostgreSQL 연결 실패: %w", err)
 	}
-
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(time.Hour)
+	tunnel, err := p.openTunnel(ctx)
+	if err != nil {
+		return err
+	}
+	if tunnel != nil {
+		connector.Dialer(tunnel)
+	}
+	db := sql.OpenDB(connector)
+	configurePool(db, p.config)
 
 	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		tunnel.Close()
 		return fmt.Errorf("PostgreSQL Ping 실패: %w", err)
 	}
 
-	p.db = db
+	p.db, p.tunnel = db, tunnel
 	return nil
 }
 
-func (p *PostgresConnector) ExtractSchema(ctx context.Context) (*models.Schema, error) {
-	schema := &models.Schema{
-		Database: p.config.Database,
-		DBType:   models.PostgreSQL,
-		Tables:   []models.Table{},
+// dsn 키=값 형식 연결 문자열 (TLS 모드를 지정하지 않으면 sslmode=disable)
+// 읽기 타임아웃은 lib/pq가 지원하지 않으므로 컨텍스트 시간 제한을 사용합니다.
+func (p *PostgresConnector) dsn() string {
+	sslmode := string(p.config.TLSMode)
+	if sslmode == "" {
+		sslmode = string(models.TLSDisable)
 	}
 
-	tables, err := p.getTables(ctx)
-	if err != nil {
-		return nil, err
+	values := map[string]string{
+		"host":             p.config.Host,
+		"port":             strconv.Itoa(p.config.Port),
+		"user":             p.config.User,
+		"password":         p.config.Password,
+		"dbname":           p.config.Database,
+		"sslmode":          sslmode,
+		"sslrootcert":      p.config.CACert,
+		"sslcert":          p.config.ClientCert,
+		"sslkey":           p.config.ClientKey,
+		"application_name": p.config.ApplicationName,
+	}
+	if p.config.ConnectTimeout > 0 {
+		values["connect_timeout"] = strconv.Itoa(p.config.ConnectTimeout)
+	}
+	for k, v := range p.config.Params {
+		values[k] = v
 	}
 
-	for _, tableName := range tables {
-		table := models.Table{Name: tableName}
-
-		columns, err := p.getColumns(ctx, tableName)
-		if err != nil {
-			return nil, err
-		}
-		table.Columns = columns
-
-		indexes, err := p.getIndexes(ctx, tableName)
-		if err != nil {
-			return nil, err
+	keys := make([]string, 0, len(values))
+	for k, v := range values {
+		if v != "" {
+			keys = append(keys, k)
 		}
-		table.Indexes = indexes
-
-		fks, err := p.getForeignKeys(ctx, tableName)
-		if err != nil {
-			return nil, err
-		}
-		table.ForeignKeys = fks
+	}
+	sort.Strings(keys)
 
-		pks, err := p.getPrimaryKeys(ctx, tableName)
-		if err != nil {
-			return nil, err
-		}
-		table.PrimaryKey = pks
+	// 값은 작은따옴표로 감싸고 \와 '를 이스케이프
+	quoter := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "='" + quoter.Replace(values[k]) + "'"
+	}
+	return strings.Join(parts, " ")
+}
 
-		schema.Tables = append(schema.Tables, table)
+func (p *PostgresConnector) ExtractSchema(ctx context.Context, opts ExtractOptions) (*models.Schema, error) {
+	schema := &models.Schema{
+		Database: p.config.Database,
+		DBType:   models.PostgreSQL,
+		Tables:   []models.Table{},
 	}
 
+	if err := extractCatalog(ctx, p, schema, opts); err != nil {
+		return nil, err
+	}
 	return schema, nil
 }
 
-func (p *PostgresConnector) getTables(ctx context.Context) ([]string, error) {
-	query := `
-		SELECT table_name 
-		FROM information_schema.tables 
-		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
-		ORDER BY table_name`
+// TableVersions 추출 범위의 테이블과 카탈로그 xmin으로 만든 버전
+func (p *PostgresConnector) TableVersions(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	return p.getTables(ctx, opts)
+}
+
+// pgSchemaFilter 스키마 컬럼 조건 ($1이 false면 현재 스키마만, 시스템 스키마는 항상 제외)
+func pgSchemaFilter(column string) string {
+	return fmt.Sprintf(`($1 OR %[1]s = current_schema())
+			AND %[1]s NOT IN ('pg_catalog', 'information_schema')
+			AND %[1]s NOT LIKE 'pg\_toast%%' AND %[1]s NOT LIKE 'pg\_temp%%'`, column)
+}
 
-	rows, err := p.db.QueryContext(ctx, query)
+// getTables 추출할 테이블 (스키마 패턴이 없으면 현재 스키마만, 있으면 시스템 스키마를 뺀 전체에서 선택)
+// PostgreSQL에는 DDL 시각이 없어 테이블과 컬럼, 제약조건, 인덱스 카탈로그 행의 xmin(마지막으로 바꾼 트랜잭션)을 버전으로 씁니다.
+func (p *PostgresConnector) getTables(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	query := `
+		SELECT t.table_schema, t.table_name,
+			md5(concat_ws(':', c.xmin::text,
+				(SELECT string_agg(a.xmin::text, ',' ORDER BY a.attnum) FROM pg_attribute a WHERE a.attrelid = c.oid),
+				(SELECT string_agg(k.xmin::text, ',' ORDER BY k.oid) FROM pg_constraint k WHERE k.conrelid = c.oid),
+				(SELECT string_agg(i.xmin::text, ',' ORDER BY i.indexrelid) FROM pg_index i WHERE i.indrelid = c.oid)))
+		FROM information_schema.tables t
+		JOIN pg_namespace n ON n.nspname = t.table_schema
+		JOIN pg_class c ON c.relnamespace = n.oid AND c.relname = t.table_name
+		WHERE t.table_type = 'BASE TABLE' AND ` + pgSchemaFilter("t.table_schema") + `
+		ORDER BY t.table_schema, t.table_name`
+
+	rows, err := p.db.QueryContext(ctx, query, opts.allSchemas())
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var tables []string
+	var tables []TableVersion
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var t TableVersion
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Version); err != nil {
 			return nil, err
 		}
-		tables = append(tables, name)
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return tables, nil
+	return opts.filterTables(tables), nil
 }
 
-func (p *PostgresConnector) getColumns(ctx context.Context, table string) ([]models.Column, error) {
+// getColumns 스키마의 테이블과 뷰 컬럼 (구체화된 뷰는 getViews가 따로 읽음)
+func (p *PostgresConnector) getColumns(ctx context.Context, schema string) (map[string][]models.Column, error) {
 	query := `
 		SELECT 
-			c.column_name, c.data_type, c.is_nullable, c.column_default,
+			c.table_name, c.column_name, c.data_type, c.is_nullable, c.column_default,
 			CASE WHEN pk.column_name IS NOT NULL THEN true ELSE false END as is_pk,
-			CASE WHEN fk.column_name IS NOT NULL THEN true ELSE false END as is_fk
+			CASE WHEN fk.column_name IS NOT NULL THEN true ELSE false END as is_fk,
+			c.udt_schema, c.udt_name, c.domain_schema, c.domain_name, c.collation_name,
+			c.is_generated, c.generation_expression
 		FROM information_schema.columns c
 		LEFT JOIN (
-			SELECT kcu.column_name 
+			SELECT DISTINCT tc.table_name, kcu.column_name 
 			FROM information_schema.table_constraints tc
-			JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name
-			WHERE tc.table_name = $1 AND tc.constraint_type = 'PRIMARY KEY'
-		) pk ON c.column_name = pk.column_name
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_schema = kcu.constraint_schema AND tc.constraint_name = kcu.constraint_name
+			WHERE tc.table_schema = $1 AND tc.constraint_type = 'PRIMARY KEY'
+		) pk ON c.table_name = pk.table_name AND c.column_name = pk.column_name
 		LEFT JOIN (
-			SELECT kcu.column_name 
+			SELECT DISTINCT tc.table_name, kcu.column_name 
 			FROM information_schema.table_constraints tc
-			JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name
-			WHERE tc.table_name = $1 AND tc.constraint_type = 'FOREIGN KEY'
-		) fk ON c.column_name = fk.column_name
-		WHERE c.table_name = $1 AND c.table_schema = 'public'
-		ORDER BY c.ordinal_position`
-
-	rows, err := p.db.QueryContext(ctx, query, table)
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_schema = kcu.constraint_schema AND tc.constraint_name = kcu.constraint_name
+			WHERE tc.table_schema = $1 AND tc.constraint_type = 'FOREIGN KEY'
+		) fk ON c.table_name = fk.table_name AND c.column_name = fk.column_name
+		WHERE c.table_schema = $1
+		ORDER BY c.table_name, c.ordinal_position`
+
+	rows, err := p.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var columns []models.Column
+	columns := make(map[string][]models.Column)
 	for rows.Next() {
 		var col models.Column
-		var nullable string
-		var defaultVal sql.NullString
+		var table, nullable, udtSchema, udtName, generated string
+		var defaultVal, domainSchema, domainName, collation, expression sql.NullString
 
-		if err := rows.Scan(&col.Name, &col.Type, &nullable, &defaultVal, &col.IsPK, &col.IsFK); err != nil {
+		if err := rows.Scan(&table, &col.Name, &col.Type, &nullable, &defaultVal, &col.IsPK, &col.IsFK,
+			&udtSchema, &udtName, &domainSchema, &domainName, &collation, &generated, &expression); err != nil {
 			return nil, err
 		}
 
+		// enum 등 사용자 정의 타입은 타입 이름으로 (값 목록은 resolveTypes가 채움)
+		if col.Type == "USER-DEFINED" {
+			col.Type = pgTypeName(schema, udtSchema, udtName)
+		}
+		if domainName.Valid {
+			col.Domain = pgTypeName(schema, domainSchema.String, domainName.String)
+		}
+		col.Collation = collation.String
+		if generated == "ALWAYS" {
+			col.Generated = generatedExpr(expression.String)
+			col.Stored = true // PostgreSQL은 STORED만 지원
+		}
+
 		col.Nullable = nullable == "YES"
 		if defaultVal.Valid {
 			col.Default = defaultVal.String
@@ -159,101 +219,409 @@ func (p *PostgresConnector) getColumns(ctx context.Context, table string) ([]mod
 			}
 		}
 
-		columns = append(columns, col)
+		columns[table] = append(columns[table], col)
+	}
+	return columns, rows.Err()
+}
+
+// pgTypeName 테이블과 다른 스키마의 타입은 schema.name으로
+func pgTypeName(tableSchema, typeSchema, name string) string {
+	if typeSchema == tableSchema || typeSchema == "pg_catalog" {
+		return name
+	}
+	return models.QualifiedName(typeSchema, name)
+}
+
+// getChecks CHECK 제약조건과 참조 컬럼
+func (p *PostgresConnector) getChecks(ctx context.Context, schema string) (map[string][]models.Check, error) {
+	query := `
+		SELECT c.relname, con.conname, pg_get_constraintdef(con.oid),
+			ARRAY(SELECT a.attname::text FROM pg_attribute a
+				WHERE a.attrelid = con.conrelid AND a.attnum = ANY (con.conkey)
+				ORDER BY a.attnum)
+		FROM pg_constraint con
+		JOIN pg_class c ON c.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE con.contype = 'c' AND n.nspname = $1
+		ORDER BY c.relname, con.conname`
+
+	rows, err := p.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string][]models.Check)
+	for rows.Next() {
+		var table string
+		var c models.Check
+		if err := rows.Scan(&table, &c.Name, &c.Expression, pq.Array(&c.Columns)); err != nil {
+			return nil, err
+		}
+		checks[table] = append(checks[table], c)
+	}
+	return checks, rows.Err()
+}
+
+// getTypes enum 타입과 도메인 (확장 모듈이 만든 타입 제외)
+func (p *PostgresConnector) getTypes(ctx context.Context, opts ExtractOptions) ([]models.UserType, error) {
+	query := `
+		SELECT n.nspname, t.typname, t.typtype,
+			ARRAY(SELECT e.enumlabel::text FROM pg_enum e WHERE e.enumtypid = t.oid ORDER BY e.enumsortorder),
+			COALESCE(format_type(NULLIF(t.typbasetype, 0), t.typtypmod), ''),
+			t.typnotnull, COALESCE(t.typdefault, ''),
+			ARRAY(SELECT pg_get_constraintdef(c.oid) FROM pg_constraint c
+				WHERE c.contypid = t.oid AND c.contype = 'c' ORDER BY c.conname)
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE t.typtype IN ('e', 'd') AND ` + pgSchemaFilter("n.nspname") + `
+			AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.classid = 'pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')
+		ORDER BY n.nspname, t.typname`
+
+	rows, err := p.db.QueryContext(ctx, query, opts.allSchemas())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []models.UserType
+	for rows.Next() {
+		var t models.UserType
+		var kind string
+		var checks []string
+		if err := rows.Scan(&t.Schema, &t.Name, &kind, pq.Array(&t.Values), &t.BaseType,
+			&t.NotNull, &t.Default, pq.Array(&checks)); err != nil {
+			return nil, err
+		}
+		if !opts.includeObject(t.Schema) {
+			continue
+		}
+		if kind == "e" {
+			t.Kind = models.TypeEnum
+		} else {
+			t.Kind = models.TypeDomain
+			t.Values = nil
+			t.Check = joinChecks(checks)
+		}
+		types = append(types, t)
 	}
-	return columns, nil
+	return types, rows.Err()
 }
 
-func (p *PostgresConnector) getIndexes(ctx context.Context, table string) ([]models.Index, error) {
+func (p *PostgresConnector) getIndexes(ctx context.Context, schema string) (map[string][]models.Index, error) {
 	query := `
 		SELECT 
+			t.relname as table_name,
 			i.relname as index_name,
 			a.attname as column_name,
 			ix.indisunique as is_unique,
 			am.amname as index_type
 		FROM pg_class t
+		JOIN pg_namespace n ON n.oid = t.relnamespace
 		JOIN pg_index ix ON t.oid = ix.indrelid
 		JOIN pg_class i ON i.oid = ix.indexrelid
 		JOIN pg_am am ON i.relam = am.oid
 		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey)
-		WHERE t.relname = $1 AND t.relkind = 'r'
-		ORDER BY i.relname, a.attnum`
+		WHERE n.nspname = $1 AND t.relkind = 'r'
+		ORDER BY t.relname, i.relname, a.attnum`
 
-	rows, err := p.db.QueryContext(ctx, query, table)
+	rows, err := p.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	indexMap := make(map[string]*models.Index)
+	indexes := make(map[string][]models.Index)
 	for rows.Next() {
-		var indexName, columnName, indexType string
+		var table, indexName, columnName, indexType string
 		var isUnique bool
 
-		if err := rows.Scan(&indexName, &columnName, &isUnique, &indexType); err != nil {
+		if err := rows.Scan(&table, &indexName, &columnName, &isUnique, &indexType); err != nil {
 			return nil, err
 		}
 
-		if idx, exists := indexMap[indexName]; exists {
-			idx.Columns = append(idx.Columns, columnName)
-		} else {
-			indexMap[indexName] = &models.Index{
-				Name:     indexName,
-				Columns:  []string{columnName},
-				IsUnique: isUnique,
-				Type:     indexType,
-			}
+		list := indexes[table]
+		if n := len(list); n > 0 && list[n-1].Name == indexName {
+			list[n-1].Columns = append(list[n-1].Columns, columnName)
+			continue
 		}
+		indexes[table] = append(list, models.Index{
+			Name:     indexName,
+			Columns:  []string{columnName},
+			IsUnique: isUnique,
+			Type:     indexType,
+		})
 	}
-
-	var indexes []models.Index
-	for _, idx := range indexMap {
-		indexes = append(indexes, *idx)
-	}
-	return indexes, nil
+	return indexes, rows.Err()
 }
 
-func (p *PostgresConnector) getForeignKeys(ctx context.Context, table string) ([]models.FK, error) {
+func (p *PostgresConnector) getForeignKeys(ctx context.Context, schema string) (map[string][]models.FK, error) {
 	query := `
 		SELECT
+			tc.table_name,
 			tc.constraint_name,
 			kcu.column_name,
+			ccu.table_schema AS ref_schema,
 			ccu.table_name AS ref_table,
 			ccu.column_name AS ref_column
 		FROM information_schema.table_constraints tc
 		JOIN information_schema.key_column_usage kcu 
-			ON tc.constraint_name = kcu.constraint_name
+			ON tc.constraint_schema = kcu.constraint_schema AND tc.constraint_name = kcu.constraint_name
 		JOIN information_schema.constraint_column_usage ccu 
-			ON ccu.constraint_name = tc.constraint_name
-		WHERE tc.table_name = $1 AND tc.constraint_type = 'FOREIGN KEY'`
+			ON ccu.constraint_schema = tc.constraint_schema AND ccu.constraint_name = tc.constraint_name
+		WHERE tc.table_schema = $1 AND tc.constraint_type = 'FOREIGN KEY'
+		ORDER BY tc.table_name, tc.constraint_name, kcu.ordinal_position`
 
-	rows, err := p.db.QueryContext(ctx, query, table)
+	rows, err := p.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var fks []models.FK
+	fks := make(map[string][]models.FK)
 	for rows.Next() {
+		var table string
 		var fk models.FK
-		if err := rows.Scan(&fk.Name, &fk.Column, &fk.RefTable, &fk.RefColumn); err != nil {
+		if err := rows.Scan(&table, &fk.Name, &fk.Column, &fk.RefSchema, &fk.RefTable, &fk.RefColumn); err != nil {
+			return nil, err
+		}
+		fks[table] = append(fks[table], fk)
+	}
+	return fks, rows.Err()
+}
+
+// getViews 뷰와 구체화된 뷰 (컬럼은 pg_attribute에서 한 번에 읽음)
+func (p *PostgresConnector) getViews(ctx context.Context, opts ExtractOptions) ([]models.View, error) {
+	query := `
+		SELECT c.oid, n.nspname, c.relname, c.relkind = 'm', pg_get_viewdef(c.oid, true)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('v', 'm') AND ` + pgSchemaFilter("n.nspname") + `
+		ORDER BY n.nspname, c.relname`
+
+	rows, err := p.db.QueryContext(ctx, query, opts.allSchemas())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []models.View
+	var oids []int64
+	for rows.Next() {
+		var v models.View
+		var oid int64
+		var definition sql.NullString
+		if err := rows.Scan(&oid, &v.Schema, &v.Name, &v.Materialized, &definition); err != nil {
+			return nil, err
+		}
+		v.Definition = strings.TrimSuffix(strings.TrimSpace(definition.String), ";")
+		views = append(views, v)
+		oids = append(oids, oid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(views) == 0 {
+		return nil, nil
+	}
+	columns, err := p.getRelationColumns(ctx, oids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range views {
+		views[i].Columns = columns[oids[i]]
+	}
+	return views, nil
+}
+
+// getRelationColumns 뷰 컬럼을 oid별로 (information_schema.columns에는 구체화된 뷰가 없음)
+func (p *PostgresConnector) getRelationColumns(ctx context.Context, oids []int64) (map[int64][]models.Column, error) {
+	query := `
+		SELECT a.attrelid, a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull
+		FROM pg_attribute a
+		WHERE a.attrelid = ANY ($1) AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attrelid, a.attnum`
+
+	rows, err := p.db.QueryContext(ctx, query, pq.Array(oids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[int64][]models.Column)
+	for rows.Next() {
+		var oid int64
+		var col models.Column
+		if err := rows.Scan(&oid, &col.Name, &col.Type, &col.Nullable); err != nil {
+			return nil, err
+		}
+		columns[oid] = append(columns[oid], col)
+	}
+	return columns, rows.Err()
+}
+
+// getSequences 컬럼(serial, identity)에 속하지 않은 시퀀스
+func (p *PostgresConnector) getSequences(ctx context.Context, opts ExtractOptions) ([]models.Sequence, error) {
+	query := `
+		SELECT n.nspname, c.relname, format_type(s.seqtypid, NULL), s.seqstart, s.seqincrement, s.seqcycle
+		FROM pg_sequence s
+		JOIN pg_class c ON c.oid = s.seqrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE ` + pgSchemaFilter("n.nspname") + `
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_depend d
+				WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype IN ('a', 'i')
+			)
+		ORDER BY n.nspname, c.relname`
+
+	rows, err := p.db.QueryContext(ctx, query, opts.allSchemas())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sequences []models.Sequence
+	for rows.Next() {
+		var seq models.Sequence
+		if err := rows.Scan(&seq.Schema, &seq.Name, &seq.DataType, &seq.Start, &seq.Increment, &seq.Cycle); err != nil {
+			return nil, err
+		}
+		sequences = append(sequences, seq)
+	}
+	return sequences, rows.Err()
+}
+
+// getRoutines 함수와 프로시저 (확장이 설치한 것과 집계 함수는 제외)
+func (p *PostgresConnector) getRoutines(ctx context.Context, opts ExtractOptions) ([]models.Routine, error) {
+	query := `
+		SELECT n.nspname, p.proname, p.prokind = 'p',
+			pg_get_function_arguments(p.oid), COALESCE(pg_get_function_result(p.oid), ''),
+			l.lanname, pg_get_functiondef(p.oid)
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		JOIN pg_language l ON l.oid = p.prolang
+		WHERE p.prokind IN ('f', 'p') AND ` + pgSchemaFilter("n.nspname") + `
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_depend d
+				WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e'
+			)
+		ORDER BY n.nspname, p.proname, p.oid`
+
+	rows, err := p.db.QueryContext(ctx, query, opts.allSchemas())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routines []models.Routine
+	for rows.Next() {
+		var r models.Routine
+		var procedure bool
+		if err := rows.Scan(&r.Schema, &r.Name, &procedure, &r.Arguments, &r.Returns, &r.Language, &r.Definition); err != nil {
+			return nil, err
+		}
+		r.Kind = models.RoutineFunction
+		if procedure {
+			r.Kind = models.RoutineProcedure
+		}
+		r.Definition = strings.TrimSpace(r.Definition)
+		routines = append(routines, r)
+	}
+	return routines, rows.Err()
+}
+
+// getTriggers 사용자 트리거 (제약조건용 내부 트리거 제외)
+func (p *PostgresConnector) getTriggers(ctx context.Context, opts ExtractOptions) ([]models.Trigger, error) {
+	query := `
+		SELECT n.nspname, t.tgname, c.relname, t.tgtype, pg_get_triggerdef(t.oid, true)
+		FROM pg_trigger t
+		JOIN pg_class c ON c.oid = t.tgrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE NOT t.tgisinternal AND ` + pgSchemaFilter("n.nspname") + `
+		ORDER BY n.nspname, c.relname, t.tgname`
+
+	rows, err := p.db.QueryContext(ctx, query, opts.allSchemas())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []models.Trigger
+	for rows.Next() {
+		var t models.Trigger
+		var tgtype int64
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Table, &tgtype, &t.Definition); err != nil {
+			return nil, err
+		}
+		t.Timing, t.Events = pgTriggerType(tgtype)
+		triggers = append(triggers, t)
+	}
+	return triggers, rows.Err()
+}
+
+// pgTriggerType pg_trigger.tgtype 비트에서 시점과 이벤트
+func pgTriggerType(tgtype int64) (string, []string) {
+	timing := "AFTER"
+	switch {
+	case tgtype&(1<<1) != 0:
+		timing = "BEFORE"
+	case tgtype&(1<<6) != 0:
+		timing = "INSTEAD OF"
+	}
+
+	var events []string
+	for _, e := range []struct {
+		bit  int64
+		name string
+	}{{1 << 2, "INSERT"}, {1 << 4, "UPDATE"}, {1 << 3, "DELETE"}, {1 << 5, "TRUNCATE"}} {
+		if tgtype&e.bit != 0 {
+			events = append(events, e.name)
+		}
+	}
+	return timing, events
+}
+
+// getPrimaryKeys 스키마의 테이블별 기본 키 컬럼
+func (p *PostgresConnector) getPrimaryKeys(ctx context.Context, schema string) (map[string][]string, error) {
+	query := `
+		SELECT tc.table_name, kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu 
+			ON tc.constraint_schema = kcu.constraint_schema AND tc.constraint_name = kcu.constraint_name
+		WHERE tc.table_schema = $1 AND tc.constraint_type = 'PRIMARY KEY'
+		ORDER BY tc.table_name, kcu.ordinal_position`
+
+	rows, err := p.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pks := make(map[string][]string)
+	for rows.Next() {
+		var table, pk string
+		if err := rows.Scan(&table, &pk); err != nil {
 			return nil, err
 		}
-		fks = append(fks, fk)
+		pks[table] = append(pks[table], pk)
 	}
-	return fks, nil
+	return pks, rows.Err()
 }
 
-func (p *PostgresConnector) getPrimaryKeys(ctx context.Context, table string) ([]string, error) {
+// tablePrimaryKey 테이블 하나의 기본 키 컬럼 (schema가 비어 있으면 현재 스키마)
+func (p *PostgresConnector) tablePrimaryKey(ctx context.Context, schema, table string) ([]string, error) {
 	query := `
 		SELECT kcu.column_name
 		FROM information_schema.table_constraints tc
 		JOIN information_schema.key_column_usage kcu 
-			ON tc.constraint_name = kcu.constraint_name
-		WHERE tc.table_name = $1 AND tc.constraint_type = 'PRIMARY KEY'
+			ON tc.constraint_schema = kcu.constraint_schema AND tc.constraint_name = kcu.constraint_name
+		WHERE tc.table_schema = COALESCE(NULLIF($1, ''), current_schema())
+			AND tc.table_name = $2 AND tc.constraint_type = 'PRIMARY KEY'
 		ORDER BY kcu.ordinal_position`
 
-	rows, err := p.db.QueryContext(ctx, query, table)
+	rows, err := p.db.QueryContext(ctx, query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -267,55 +635,118 @@ func (p *PostgresConnector) getPrimaryKeys(ctx context.Context, table string) ([
 		}
 		pks = append(pks, pk)
 	}
-	return pks, nil
+	return pks, rows.Err()
 }
 
-func (p *PostgresConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
-	start := time.Now()
+// ExtractStats pg_class의 추정 행 수와 크기, pg_stats의 컬럼 통계 (ANALYZE 전의 테이블은 행 수를 모름)
+func (p *PostgresConnector) ExtractStats(ctx context.Context, schema *models.Schema) error {
+	return extractStats(ctx, p, schema)
+}
+
+func (p *PostgresConnector) getTableStats(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT c.relname, c.reltuples::bigint, pg_total_relation_size(c.oid)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relkind IN ('r', 'p')`
 
-	rows, err := p.db.QueryContext(ctx, query)
+	rows, err := p.db.QueryContext(ctx, query, schema)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
+	for rows.Next() {
+		var name string
+		var stats models.TableStats
+		if err := rows.Scan(&name, &stats.Rows, &stats.SizeBytes); err != nil {
+			return err
+		}
+		// PostgreSQL 14부터 한 번도 ANALYZE하지 않은 테이블은 reltuples가 -1
+		if stats.Rows < 0 {
+			stats.Rows = -1
+		}
+		target.setTable(schema, name, stats)
+	}
+	return rows.Err()
+}
+
+// getColumnStats pg_stats (상속 통계가 아닌 행을 우선, n_distinct가 음수면 행 수에 대한 비율)
+func (p *PostgresConnector) getColumnStats(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT DISTINCT ON (tablename, attname)
+			tablename, attname, null_frac, n_distinct, histogram_bounds::text
+		FROM pg_stats
+		WHERE schemaname = $1
+		ORDER BY tablename, attname, inherited`
+
+	rows, err := p.db.QueryContext(ctx, query, schema)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer rows.Close()
 
-	var resultRows [][]interface{}
 	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		valuePtrs := make([]interface{}, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
+		var table, column string
+		var nullFrac, distinct float64
+		var bounds []string
+		if err := rows.Scan(&table, &column, &nullFrac, &distinct, pq.Array(&bounds)); err != nil {
+			return err
 		}
-
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, err
+		stats := target.column(schema, table, column)
+		if stats == nil {
+			continue
 		}
-
-		row := make([]interface{}, len(columns))
-		for i, v := range values {
-			if b, ok := v.([]byte); ok {
-				row[i] = string(b)
-			} else {
-				row[i] = v
+		stats.NullFraction = nullFrac
+		if distinct < 0 {
+			if n := target.rows(schema, table); n > 0 {
+				stats.Distinct = int64(-distinct * float64(n))
 			}
+		} else {
+			stats.Distinct = int64(distinct)
 		}
-		resultRows = append(resultRows, row)
+		stats.Histogram = bounds
 	}
+	return rows.Err()
+}
 
-	return &QueryResult{
-		Columns:  columns,
-		Rows:     resultRows,
-		Duration: time.Since(start).Milliseconds(),
-	}, nil
+func (p *PostgresConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
+	return p.Execute(ctx, query, ExecOptions{})
+}
+
+func (p *PostgresConnector) Execute(ctx context.Context, query string, opts ExecOptions) (*QueryResult, error) {
+	return p.execute(ctx, query, opts, p.beginReadOnly)
+}
+
+// OpenCursor 결과 행을 하나씩 읽는 커서 열기
+func (p *PostgresConnector) OpenCursor(ctx context.Context, query string, opts ExecOptions) (*Cursor, error) {
+	return p.openCursor(ctx, query, opts, p.beginReadOnly)
+}
+
+// OpenPage offset개 행 다음부터 읽는 페이지 커서 열기
+func (p *PostgresConnector) OpenPage(ctx context.Context, query string, offset int, opts ExecOptions) (*Cursor, error) {
+	return p.openPage(ctx, query, offset, opts, p.beginReadOnly, false)
+}
+
+// DryRun 트랜잭션 안에서 DML을 실행해 영향을 받는 행을 확인한 뒤 롤백
+func (p *PostgresConnector) DryRun(ctx context.Context, query string, params Params) (*DryRunResult, error) {
+	return p.dryRun(ctx, query, params, func(ctx context.Context, target *schema.DMLTarget) ([]string, error) {
+		return p.tablePrimaryKey(ctx, target.Schema, target.Table)
+	})
+}
+
+// beginReadOnly BEGIN; SET TRANSACTION READ ONLY
+func (p *PostgresConnector) beginReadOnly(ctx context.Context) (*sql.Tx, func(), error) {
+	return p.beginTx(ctx, nil, "SET TRANSACTION READ ONLY")
 }
 
 func (p *PostgresConnector) Explain(ctx context.Context, query string) (string, error) {
-	explainQuery := "EXPLAIN ANALYZE " + query
+	if err := p.singleStatement(query); err != nil {
+		return "", err
+	}
+
+	// ANALYZE는 쿼리를 실제로 실행하므로 사용하지 않음 (생성 쿼리 검증에도 쓰임)
+	explainQuery := "EXPLAIN " + query
 	rows, err := p.db.QueryContext(ctx, explainQuery)
 	if err != nil {
 		return "", err
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sql-genius/pkg/models"
+	"time"
+)
+
+// TableVersion 테이블과 그 정의의 버전
+// 버전 값은 DB마다 다르며(마지막 DDL 시각, 카탈로그 xmin, CREATE 문 해시) 같은지만 비교합니다.
+// 비어 있으면 버전을 알 수 없는 것으로 보고 갱신할 때마다 다시 추출합니다.
+type TableVersion struct {
+	Schema  string `json:"schema,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func (t TableVersion) ref() tableRef {
+	return tableRef{Schema: t.Schema, Name: t.Name}
+}
+
+// Snapshot 추출한 스키마와 추출할 때의 테이블 버전 (스키마 캐시에 저장)
+type Snapshot struct {
+	Schema      *models.Schema `json:"schema"`
+	ExtractedAt time.Time      `json:"extracted_at"`
+	Tables      []TableVersion `json:"tables"`
+}
+
+// RefreshResult 스냅숏 갱신 결과
+type RefreshResult struct {
+	Full        bool      `json:"full"`              // 이전 스냅숏이 없어 전체를 추출함
+	Changed     []string  `json:"changed,omitempty"` // 다시 추출한 테이블 (새 테이블 포함)
+	Dropped     []string  `json:"dropped,omitempty"` // 없어진 테이블
+	Unchanged   int       `json:"unchanged"`         // 이전 스냅숏에서 그대로 가져온 테이블 수
+	ExtractedAt time.Time `json:"extracted_at"`
+}
+
+// RefreshSnapshot 이전 스냅숏과 테이블 버전을 비교해 바뀐 테이블만 다시 추출
+// 새 테이블과 버전이 달라진 테이블은 다시 추출하고 없어진 테이블은 빼며, 나머지는 이전 스냅숏의
+// 테이블을 그대로 씁니다. 뷰, 시퀀스, 함수/프로시저, 트리거, 사용자 정의 타입은 버전이 없어 항상 새로 조회합니다.
+// previous가 nil이거나 DB 종류가 다르면 전체를 추출합니다. 통계는 채우지 않으므로 필요하면 ExtractStats를 호출합니다.
+func RefreshSnapshot(ctx context.Context, conn Connector, previous *Snapshot, opts ExtractOptions) (*Snapshot, *RefreshResult, error) {
+	extractedAt := time.Now()
+	versions, err := conn.TableVersions(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("테이블 버전 조회 실패: %w", err)
+	}
+
+	full := previous == nil || previous.Schema == nil || previous.Schema.DBType != conn.Type()
+	result := &RefreshResult{Full: full, ExtractedAt: extractedAt}
+
+	cached := make(map[tableRef]models.Table)
+	known := make(map[tableRef]string)
+	if !full {
+		for _, t := range previous.Schema.Tables {
+			cached[tableRef{Schema: t.Schema, Name: t.Name}] = t
+		}
+		for _, v := range previous.Tables {
+			known[v.ref()] = v.Version
+		}
+	}
+
+	changed := make(map[tableRef]bool)
+	current := make(map[tableRef]bool)
+	for _, v := range versions {
+		ref := v.ref()
+		current[ref] = true
+		_, ok := cached[ref]
+		if version, seen := known[ref]; !ok || !seen || v.Version == "" || version != v.Version {
+			changed[ref] = true
+			if !full {
+				result.Changed = append(result.Changed, models.QualifiedName(v.Schema, v.Name))
+			}
+		}
+	}
+	if !full {
+		for _, t := range previous.Schema.Tables {
+			if !current[tableRef{Schema: t.Schema, Name: t.Name}] {
+				result.Dropped = append(result.Dropped, t.QualifiedName())
+			}
+		}
+		opts.only = changed
+	}
+
+	schema, err := conn.ExtractSchema(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !full {
+		extracted := make(map[tableRef]models.Table)
+		for _, t := range schema.Tables {
+			extracted[tableRef{Schema: t.Schema, Name: t.Name}] = t
+		}
+		// 버전 조회 순서(스키마, 이름 순)로 다시 추출한 테이블과 이전 테이블을 합침
+		// (버전 조회 뒤에 삭제되어 추출되지 않은 테이블은 다음 갱신에서 다시 확인)
+		tables := make([]models.Table, 0, len(versions))
+		for _, v := range versions {
+			ref := v.ref()
+			if t, ok := extracted[ref]; ok {
+				tables = append(tables, t)
+			} else if !changed[ref] {
+				tables = append(tables, cached[ref])
+				result.Unchanged++
+			}
+		}
+		schema.Tables = tables
+	}
+
+	return &Snapshot{Schema: schema, ExtractedAt: extractedAt, Tables: versions}, result, nil
+}
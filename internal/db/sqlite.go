@@ -0,0 +1,658 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sql-genius/internal/schema"
+	"sql-genius/pkg/models"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteConnector SQLite 연결자 (서버 없이 로컬 파일 사용)
+type SQLiteConnector struct {
+	BaseConnector
+}
+
+// NewSQLiteConnector SQLite 연결자 생성
+func NewSQLiteConnector(config models.DBConfig) (*SQLiteConnector, error) {
+	if config.Database == "" {
+		return nil, fmt.Errorf("SQLite 데이터베이스 파일 경로가 필요합니다")
+	}
+	if config.SSH != nil {
+		return nil, fmt.Errorf("SQLite는 로컬 파일이므로 SSH 터널을 사용할 수 없습니다")
+	}
+	return &SQLiteConnector{
+		BaseConnector: BaseConnector{config: config},
+	}, nil
+}
+
+func (s *SQLiteConnector) Connect(ctx context.Context) error {
+	// Database 필드를 파일 경로로 사용 (":memory:" 가능), Params는 드라이버 옵션으로 추가 (예: _txlock=immediate)
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", sqliteURIPath(s.config.Database))
+	if len(s.config.Params) > 0 {
+		params := url.Values{}
+		for k, v := range s.config.Params {
+			params.Set(k, v)
+		}
+		dsn += "&" + params.Encode()
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return fmt.Errorf("SQLite 연결 실패: %w", err)
+	}
+
+	// SQLite는 쓰기 잠금이 파일 단위이므로 연결 수를 제한
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(time.Hour)
+
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("SQLite Ping 실패: %w", err)
+	}
+
+	s.db = db
+	return nil
+}
+
+// sqliteURIPath 파일 경로를 SQLite URI의 경로 부분으로 (경로 조각마다 ?, #, % 등을 퍼센트 인코딩)
+func sqliteURIPath(path string) string {
+	segments := strings.Split(filepath.ToSlash(path), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// ExtractSchema 스키마 추출 (SQLite는 스키마 구분이 없어 테이블 패턴만 적용)
+func (s *SQLiteConnector) ExtractSchema(ctx context.Context, opts ExtractOptions) (*models.Schema, error) {
+	schema := &models.Schema{
+		Database: s.config.Database,
+		DBType:   models.SQLite,
+		Tables:   []models.Table{},
+	}
+
+	if err := extractCatalog(ctx, s, schema, opts); err != nil {
+		return nil, err
+	}
+
+	// pragma_table_info에는 UNIQUE/FK 정보가 없으므로 인덱스와 FK로 표시
+	for t := range schema.Tables {
+		table := &schema.Tables[t]
+		for i := range table.Columns {
+			for _, idx := range table.Indexes {
+				if idx.IsUnique && len(idx.Columns) == 1 && idx.Columns[0] == table.Columns[i].Name {
+					table.Columns[i].IsUnique = true
+				}
+			}
+			for _, fk := range table.ForeignKeys {
+				if fk.Column == table.Columns[i].Name {
+					table.Columns[i].IsFK = true
+				}
+			}
+		}
+	}
+	return schema, nil
+}
+
+// TableVersions 테이블과 CREATE 문 해시 버전
+func (s *SQLiteConnector) TableVersions(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	return s.getTables(ctx, opts)
+}
+
+// getViews 뷰 (sqlite_master의 CREATE VIEW 문에서 SELECT 부분만 기록)
+func (s *SQLiteConnector) getViews(ctx context.Context, opts ExtractOptions) ([]models.View, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT name, sql FROM sqlite_master WHERE type = 'view' ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []models.View
+	for rows.Next() {
+		var v models.View
+		var definition sql.NullString
+		if err := rows.Scan(&v.Name, &definition); err != nil {
+			return nil, err
+		}
+		v.Definition = viewQuery(definition.String)
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
+// getSequences SQLite는 시퀀스가 없음
+func (s *SQLiteConnector) getSequences(ctx context.Context, opts ExtractOptions) ([]models.Sequence, error) {
+	return nil, nil
+}
+
+// getRoutines SQLite는 저장 함수/프로시저가 없음
+func (s *SQLiteConnector) getRoutines(ctx context.Context, opts ExtractOptions) ([]models.Routine, error) {
+	return nil, nil
+}
+
+// sqliteTriggerHead CREATE TRIGGER 문의 시점과 이벤트 (시점을 생략하면 BEFORE)
+var sqliteTriggerHead = regexp.MustCompile(`(?is)\bTRIGGER\b.*?\b(BEFORE|AFTER|INSTEAD\s+OF)?\s*\b(DELETE|INSERT|UPDATE)\b`)
+
+func (s *SQLiteConnector) getTriggers(ctx context.Context, opts ExtractOptions) ([]models.Trigger, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT name, tbl_name, sql FROM sqlite_master WHERE type = 'trigger' ORDER BY tbl_name, name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []models.Trigger
+	for rows.Next() {
+		var t models.Trigger
+		var definition sql.NullString
+		if err := rows.Scan(&t.Name, &t.Table, &definition); err != nil {
+			return nil, err
+		}
+		t.Definition = strings.TrimSpace(definition.String)
+		t.Timing = "BEFORE"
+		if m := sqliteTriggerHead.FindStringSubmatch(t.Definition); m != nil {
+			if m[1] != "" {
+				t.Timing = strings.ToUpper(strings.Join(strings.Fields(m[1]), " "))
+			}
+			t.Events = []string{strings.ToUpper(m[2])}
+		}
+		triggers = append(triggers, t)
+	}
+	return triggers, rows.Err()
+}
+
+// getTables 추출할 테이블 (버전은 테이블과 그 인덱스·트리거의 CREATE 문 해시)
+func (s *SQLiteConnector) getTables(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	query := `
+		SELECT t.name,
+			(SELECT group_concat(COALESCE(m.sql, m.name), ';') FROM sqlite_master m WHERE m.tbl_name = t.name)
+		FROM sqlite_master t
+		WHERE t.type = 'table' AND t.name NOT LIKE 'sqlite_%'
+		ORDER BY t.name`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableVersion
+	for rows.Next() {
+		var t TableVersion
+		var ddl string
+		if err := rows.Scan(&t.Name, &ddl); err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256([]byte(ddl))
+		t.Version = hex.EncodeToString(sum[:8])
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return opts.filterTables(tables), nil
+}
+
+// tableDefinitions 카탈로그에 없는 CHECK, COLLATE, 계산식을 읽기 위해 CREATE TABLE 원문을 파싱
+// 파싱할 수 없는 테이블은 빠지며, 그 테이블은 카탈로그 정보만 사용합니다.
+func (s *SQLiteConnector) tableDefinitions(ctx context.Context) (map[string]models.Table, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT name, sql FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parser := schema.NewParser()
+	defs := make(map[string]models.Table)
+	for rows.Next() {
+		var name string
+		var ddl sql.NullString
+		if err := rows.Scan(&name, &ddl); err != nil {
+			return nil, err
+		}
+		parsed, err := parser.ParseDDL(ddl.String, models.SQLite)
+		if err != nil || len(parsed.Tables) != 1 {
+			continue
+		}
+		defs[name] = parsed.Tables[0]
+	}
+	return defs, rows.Err()
+}
+
+// getChecks CREATE TABLE 원문의 CHECK 제약조건 (SQLite 카탈로그에는 CHECK 정보가 없음)
+func (s *SQLiteConnector) getChecks(ctx context.Context, _ string) (map[string][]models.Check, error) {
+	defs, err := s.tableDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make(map[string][]models.Check)
+	for name, def := range defs {
+		if len(def.Checks) > 0 {
+			checks[name] = def.Checks
+		}
+	}
+	return checks, nil
+}
+
+// getColumns 테이블과 뷰 컬럼 (COLLATE와 계산식은 CREATE TABLE 원문에서)
+func (s *SQLiteConnector) getColumns(ctx context.Context, _ string) (map[string][]models.Column, error) {
+	// table_xinfo는 계산 컬럼(hidden 2: VIRTUAL, 3: STORED)도 포함
+	query := `
+		SELECT m.name, p.name, p.type, p."notnull", p.dflt_value, p.pk, p.hidden
+		FROM sqlite_master m
+		JOIN pragma_table_xinfo(m.name) p
+		WHERE m.type IN ('table', 'view') AND m.name NOT LIKE 'sqlite_%' AND p.hidden <> 1
+		ORDER BY m.name, p.cid`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string][]models.Column)
+	pkCount := make(map[string]int)
+	for rows.Next() {
+		var table string
+		var col models.Column
+		var notNull, pk, hidden int
+		var defaultVal sql.NullString
+
+		if err := rows.Scan(&table, &col.Name, &col.Type, &notNull, &defaultVal, &pk, &hidden); err != nil {
+			return nil, err
+		}
+		col.Stored = hidden == 3
+
+		col.Nullable = notNull == 0
+		col.IsPK = pk > 0
+		if col.IsPK {
+			pkCount[table]++
+		}
+		if defaultVal.Valid {
+			col.Default = defaultVal.String
+		}
+
+		columns[table] = append(columns[table], col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	defs, err := s.tableDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for table, cols := range columns {
+		// 단일 INTEGER PRIMARY KEY 컬럼은 rowid 별칭으로 자동 증가
+		autoIncr := pkCount[table] == 1
+		def, hasDef := defs[table]
+
+		for i := range cols {
+			col := &cols[i]
+			if autoIncr && col.IsPK && strings.EqualFold(col.Type, "INTEGER") {
+				col.IsAutoIncr = true
+				col.Nullable = false
+			}
+			if !hasDef {
+				continue
+			}
+			for _, c := range def.Columns {
+				if strings.EqualFold(c.Name, col.Name) {
+					col.Collation = c.Collation
+					col.Generated = c.Generated
+					break
+				}
+			}
+		}
+	}
+
+	return columns, nil
+}
+
+func (s *SQLiteConnector) getIndexes(ctx context.Context, _ string) (map[string][]models.Index, error) {
+	query := `
+		SELECT m.name, il.name, ii.name, il."unique", il.origin
+		FROM sqlite_master m
+		JOIN pragma_index_list(m.name) il
+		JOIN pragma_index_info(il.name) ii
+		WHERE m.type = 'table'
+		ORDER BY m.name, il.name, ii.seqno`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := make(map[string][]models.Index)
+	for rows.Next() {
+		var table, indexName, origin string
+		var columnName sql.NullString
+		var isUnique int
+
+		if err := rows.Scan(&table, &indexName, &columnName, &isUnique, &origin); err != nil {
+			return nil, err
+		}
+		// 표현식 인덱스는 컬럼 이름이 없음
+		if !columnName.Valid {
+			continue
+		}
+
+		list := indexes[table]
+		if n := len(list); n > 0 && list[n-1].Name == indexName {
+			list[n-1].Columns = append(list[n-1].Columns, columnName.String)
+			continue
+		}
+		// origin: c = CREATE INDEX, u = UNIQUE 제약, pk = PRIMARY KEY
+		indexType := "BTREE"
+		if origin == "pk" {
+			indexType = "PRIMARY"
+		}
+		indexes[table] = append(list, models.Index{
+			Name:     indexName,
+			Columns:  []string{columnName.String},
+			IsUnique: isUnique == 1,
+			Type:     indexType,
+		})
+	}
+	return indexes, rows.Err()
+}
+
+func (s *SQLiteConnector) getForeignKeys(ctx context.Context, _ string) (map[string][]models.FK, error) {
+	query := `
+		SELECT m.name, f.id, f."from", f."table", f."to", f.on_update, f.on_delete
+		FROM sqlite_master m
+		JOIN pragma_foreign_key_list(m.name) f
+		WHERE m.type = 'table'
+		ORDER BY m.name, f.id, f.seq`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make(map[string][]models.FK)
+	for rows.Next() {
+		var table string
+		var fk models.FK
+		var id int
+		var refColumn sql.NullString
+
+		if err := rows.Scan(&table, &id, &fk.Column, &fk.RefTable, &refColumn, &fk.OnUpdate, &fk.OnDelete); err != nil {
+			return nil, err
+		}
+		// 참조 컬럼 생략 시 참조 테이블의 기본키를 가리킴
+		if refColumn.Valid {
+			fk.RefColumn = refColumn.String
+		}
+		// SQLite FK 제약에는 이름이 저장되지 않음
+		fk.Name = fmt.Sprintf("fk_%s_%d", table, id)
+
+		fks[table] = append(fks[table], fk)
+	}
+	return fks, rows.Err()
+}
+
+func (s *SQLiteConnector) getPrimaryKeys(ctx context.Context, _ string) (map[string][]string, error) {
+	query := `
+		SELECT m.name, p.name
+		FROM sqlite_master m
+		JOIN pragma_table_info(m.name) p
+		WHERE m.type = 'table' AND p.pk > 0
+		ORDER BY m.name, p.pk`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pks := make(map[string][]string)
+	for rows.Next() {
+		var table, pk string
+		if err := rows.Scan(&table, &pk); err != nil {
+			return nil, err
+		}
+		pks[table] = append(pks[table], pk)
+	}
+	return pks, rows.Err()
+}
+
+// tablePrimaryKey 테이블 하나의 기본 키 컬럼
+func (s *SQLiteConnector) tablePrimaryKey(ctx context.Context, table string) ([]string, error) {
+	query := `SELECT name FROM pragma_table_info(?) WHERE pk > 0 ORDER BY pk`
+
+	rows, err := s.db.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pks []string
+	for rows.Next() {
+		var pk string
+		if err := rows.Scan(&pk); err != nil {
+			return nil, err
+		}
+		pks = append(pks, pk)
+	}
+	return pks, rows.Err()
+}
+
+// ExtractStats sqlite_stat1(ANALYZE 결과)의 행 수와 인덱스 첫 컬럼의 고유 값 수, dbstat의 크기
+// ANALYZE를 실행하지 않은 DB는 행 수를 알 수 없습니다.
+func (s *SQLiteConnector) ExtractStats(ctx context.Context, schema *models.Schema) error {
+	return extractStats(ctx, s, schema)
+}
+
+func (s *SQLiteConnector) getTableStats(ctx context.Context, target *statsTarget, _ string) error {
+	stats, err := s.getStat1(ctx)
+	if err != nil {
+		return err
+	}
+	rows := make(map[string]int64)
+	for _, st := range stats {
+		// 테이블 행(idx 없음)과 인덱스 행의 첫 값이 행 수 (부분 인덱스는 더 작으므로 최댓값)
+		if n, ok := rows[st.table]; !ok || st.counts[0] > n {
+			rows[st.table] = st.counts[0]
+		}
+	}
+
+	// dbstat 가상 테이블은 SQLITE_ENABLE_DBSTAT_VTAB으로 빌드한 경우만 있음
+	sizes := make(map[string]int64)
+	sizeRows, err := s.db.QueryContext(ctx, `
+		SELECT m.tbl_name, SUM(d.pgsize)
+		FROM dbstat d
+		JOIN sqlite_master m ON m.name = d.name
+		GROUP BY m.tbl_name`)
+	if err == nil {
+		defer sizeRows.Close()
+		for sizeRows.Next() {
+			var name string
+			var size int64
+			if err := sizeRows.Scan(&name, &size); err != nil {
+				return err
+			}
+			sizes[name] = size
+		}
+		if err := sizeRows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for ref := range target.tables {
+		n, hasRows := rows[ref.Name]
+		size, hasSize := sizes[ref.Name]
+		if !hasRows && !hasSize {
+			continue
+		}
+		if !hasRows {
+			n = -1
+		}
+		target.setTable(ref.Schema, ref.Name, models.TableStats{Rows: n, SizeBytes: size})
+	}
+	return nil
+}
+
+// getColumnStats 인덱스 통계의 두 번째 값(첫 컬럼 값 하나당 평균 행 수)으로 첫 컬럼의 고유 값 수를 추정
+func (s *SQLiteConnector) getColumnStats(ctx context.Context, target *statsTarget, _ string) error {
+	stats, err := s.getStat1(ctx)
+	if err != nil {
+		return err
+	}
+	for _, st := range stats {
+		table := target.table("", st.table)
+		if table == nil || st.index == "" || len(st.counts) < 2 || st.counts[1] <= 0 {
+			continue
+		}
+		for _, idx := range table.Indexes {
+			if idx.Name != st.index || len(idx.Columns) == 0 {
+				continue
+			}
+			distinct := (st.counts[0] + st.counts[1] - 1) / st.counts[1]
+			if col := target.column("", table.Name, idx.Columns[0]); col != nil && distinct > col.Distinct {
+				col.Distinct = distinct
+			}
+		}
+	}
+	return nil
+}
+
+// sqliteStat sqlite_stat1의 한 행 (counts는 행 수와 인덱스 컬럼 접두어 값 하나당 평균 행 수)
+type sqliteStat struct {
+	table  string
+	index  string
+	counts []int64
+}
+
+// getStat1 sqlite_stat1 내용 (ANALYZE를 실행하지 않아 테이블이 없으면 nil)
+func (s *SQLiteConnector) getStat1(ctx context.Context) ([]sqliteStat, error) {
+	var exists int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sqlite_stat1'`).Scan(&exists)
+	if err != nil || exists == 0 {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT tbl, idx, stat FROM sqlite_stat1`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []sqliteStat
+	for rows.Next() {
+		var st sqliteStat
+		var index sql.NullString
+		var stat string
+		if err := rows.Scan(&st.table, &index, &stat); err != nil {
+			return nil, err
+		}
+		st.index = index.String
+		// "행수 평균1 평균2 ... [unordered 등 옵션]"
+		for _, field := range strings.Fields(stat) {
+			n, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				break
+			}
+			st.counts = append(st.counts, n)
+		}
+		if len(st.counts) > 0 {
+			stats = append(stats, st)
+		}
+	}
+	return stats, rows.Err()
+}
+
+func (s *SQLiteConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
+	return s.Execute(ctx, query, ExecOptions{})
+}
+
+func (s *SQLiteConnector) Execute(ctx context.Context, query string, opts ExecOptions) (*QueryResult, error) {
+	return s.execute(ctx, query, opts, s.beginReadOnly)
+}
+
+// OpenCursor 결과 행을 하나씩 읽는 커서 열기
+func (s *SQLiteConnector) OpenCursor(ctx context.Context, query string, opts ExecOptions) (*Cursor, error) {
+	return s.openCursor(ctx, query, opts, s.beginReadOnly)
+}
+
+// OpenPage offset개 행 다음부터 읽는 페이지 커서 열기
+func (s *SQLiteConnector) OpenPage(ctx context.Context, query string, offset int, opts ExecOptions) (*Cursor, error) {
+	return s.openPage(ctx, query, offset, opts, s.beginReadOnly, false)
+}
+
+// DryRun 트랜잭션 안에서 DML을 실행해 영향을 받는 행을 확인한 뒤 롤백
+func (s *SQLiteConnector) DryRun(ctx context.Context, query string, params Params) (*DryRunResult, error) {
+	return s.dryRun(ctx, query, params, func(ctx context.Context, target *schema.DMLTarget) ([]string, error) {
+		return s.tablePrimaryKey(ctx, target.Table)
+	})
+}
+
+// beginReadOnly 전용 연결에 PRAGMA query_only를 켜고 트랜잭션 시작 (정리 시 다시 끔)
+func (s *SQLiteConnector) beginReadOnly(ctx context.Context) (*sql.Tx, func(), error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		conn.ExecContext(context.Background(), "PRAGMA query_only = OFF")
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return tx, func() {
+		tx.Rollback()
+		conn.ExecContext(context.Background(), "PRAGMA query_only = OFF")
+		conn.Close()
+	}, nil
+}
+
+func (s *SQLiteConnector) Explain(ctx context.Context, query string) (string, error) {
+	if err := s.singleStatement(query); err != nil {
+		return "", err
+	}
+
+	explainQuery := "EXPLAIN QUERY PLAN " + query
+	rows, err := s.db.QueryContext(ctx, explainQuery)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	// id, parent, notused, detail - parent 기준으로 들여쓰기
+	depth := make(map[int]int)
+	var result strings.Builder
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return "", err
+		}
+		depth[id] = depth[parent] + 1
+		result.WriteString(strings.Repeat("  ", depth[id]-1) + "-- " + detail + "\n")
+	}
+
+	return result.String(), nil
+}
@@ -0,0 +1,336 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sql-genius/internal/schema"
+	"sql-genius/pkg/models"
+	"testing"
+)
+
+const sqliteFixture = `
+CREATE TABLE users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	email TEXT NOT NULL UNIQUE,
+	name TEXT DEFAULT 'anonymous'
+);
+CREATE TABLE orders (
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	total REAL NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_orders_user ON orders (user_id);
+CREATE VIEW big_orders AS SELECT * FROM orders WHERE total > 100;
+INSERT INTO users (email, name) VALUES ('a@example.com', 'A'), ('b@example.com', 'B');
+INSERT INTO orders (user_id, total) VALUES (1, 50), (1, 150), (2, 300);
+`
+
+// newSQLite 픽스처를 채운 :memory: 연결 (연결이 하나뿐이라 같은 메모리 DB를 계속 씀)
+func newSQLite(t *testing.T, policy models.ExecPolicy) Connector {
+	t.Helper()
+	conn, err := NewConnector(models.DBConfig{Type: models.SQLite, Database: ":memory:", Policy: policy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := conn.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	// 정책과 무관하게 픽스처는 드라이버로 직접 만듦
+	stmts, err := schema.NewParser().SplitStatements(sqliteFixture, models.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range stmts {
+		if _, err := conn.GetDB().ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("fixture %q: %v", stmt, err)
+		}
+	}
+	return conn
+}
+
+// queryStrings 쿼리 결과를 문자열 행으로 (드라이버별 정수 타입 차이 무시)
+func queryStrings(t *testing.T, conn Connector, query string) [][]string {
+	t.Helper()
+	result, err := conn.Execute(context.Background(), query, ExecOptions{})
+	if err != nil {
+		t.Fatalf("Execute(%q) error = %v", query, err)
+	}
+	rows := [][]string{}
+	for _, row := range result.Rows {
+		values := make([]string, len(row))
+		for i, v := range row {
+			values[i] = fmt.Sprint(v)
+		}
+		rows = append(rows, values)
+	}
+	return rows
+}
+
+func TestSQLiteExtractSchema(t *testing.T) {
+	conn := newSQLite(t, "")
+	s, err := conn.ExtractSchema(context.Background(), ExtractOptions{})
+	if err != nil {
+		t.Fatalf("ExtractSchema() error = %v", err)
+	}
+
+	if s.DBType != models.SQLite || len(s.Tables) != 2 {
+		t.Fatalf("ExtractSchema() = %s with %d tables, want sqlite with 2", s.DBType, len(s.Tables))
+	}
+	tables := map[string]models.Table{}
+	for _, table := range s.Tables {
+		tables[table.Name] = table
+	}
+
+	users := tables["users"]
+	if !reflect.DeepEqual(users.PrimaryKey, []string{"id"}) {
+		t.Errorf("users.PrimaryKey = %v, want [id]", users.PrimaryKey)
+	}
+	columns := map[string]models.Column{}
+	for _, col := range users.Columns {
+		columns[col.Name] = col
+	}
+	if id := columns["id"]; !id.IsPK || !id.IsAutoIncr {
+		t.Errorf("users.id = %+v, want primary key with autoincrement", id)
+	}
+	if email := columns["email"]; email.Nullable || !email.IsUnique {
+		t.Errorf("users.email = %+v, want NOT NULL UNIQUE", email)
+	}
+	if name := columns["name"]; name.Default != "'anonymous'" || !name.Nullable {
+		t.Errorf("users.name = %+v, want nullable with default 'anonymous'", name)
+	}
+
+	orders := tables["orders"]
+	if len(orders.ForeignKeys) != 1 {
+		t.Fatalf("orders.ForeignKeys = %+v, want 1", orders.ForeignKeys)
+	}
+	fk := orders.ForeignKeys[0]
+	if fk.Column != "user_id" || fk.RefTable != "users" || fk.RefColumn != "id" || fk.OnDelete != "CASCADE" {
+		t.Errorf("orders FK = %+v, want user_id -> users(id) ON DELETE CASCADE", fk)
+	}
+	var index *models.Index
+	for i := range orders.Indexes {
+		if orders.Indexes[i].Name == "idx_orders_user" {
+			index = &orders.Indexes[i]
+		}
+	}
+	if index == nil || !reflect.DeepEqual(index.Columns, []string{"user_id"}) || index.IsUnique {
+		t.Errorf("orders.Indexes = %+v, want idx_orders_user (user_id)", orders.Indexes)
+	}
+
+	if len(s.Views) != 1 || s.Views[0].Name != "big_orders" || len(s.Views[0].Columns) != 3 {
+		t.Errorf("Views = %+v, want big_orders with 3 columns", s.Views)
+	}
+
+	filtered, err := conn.ExtractSchema(context.Background(), ExtractOptions{Tables: []string{"ord*"}})
+	if err != nil {
+		t.Fatalf("ExtractSchema(Tables) error = %v", err)
+	}
+	if len(filtered.Tables) != 1 || filtered.Tables[0].Name != "orders" {
+		t.Errorf("ExtractSchema(Tables: ord*) = %d tables, want orders only", len(filtered.Tables))
+	}
+}
+
+func TestSQLiteReadOnlyPolicy(t *testing.T) {
+	conn := newSQLite(t, "")
+	ctx := context.Background()
+
+	if conn.Policy() != models.PolicyReadOnly {
+		t.Fatalf("Policy() = %s, want read-only", conn.Policy())
+	}
+	got := queryStrings(t, conn, "SELECT id, email FROM users ORDER BY id")
+	want := [][]string{{"1", "a@example.com"}, {"2", "b@example.com"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SELECT = %v, want %v", got, want)
+	}
+
+	blocked := []struct {
+		name  string
+		query string
+		kind  schema.StatementKind
+	}{
+		{"INSERT", "INSERT INTO users (email) VALUES ('c@example.com')", schema.StatementDML},
+		{"UPDATE", "UPDATE users SET name = 'X'", schema.StatementDML},
+		{"DELETE", "DELETE FROM orders", schema.StatementDML},
+		{"데이터 변경 CTE", "WITH x AS (SELECT 1) DELETE FROM orders", schema.StatementDML},
+		{"DDL", "DROP TABLE orders", schema.StatementDDL},
+		{"조회 뒤에 붙인 DML", "SELECT 1; DELETE FROM orders", schema.StatementMulti},
+		{"PRAGMA 설정", "PRAGMA query_only = OFF", schema.StatementOther},
+	}
+	for _, tt := range blocked {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := conn.Execute(ctx, tt.query, ExecOptions{Confirmed: true})
+			var perr *PolicyError
+			if !errors.As(err, &perr) {
+				t.Fatalf("Execute() error = %v, want *PolicyError", err)
+			}
+			if perr.Kind != tt.kind || perr.NeedsConfirmation {
+				t.Errorf("PolicyError = %+v, want kind %s without confirmation", perr, tt.kind)
+			}
+		})
+	}
+
+	got = queryStrings(t, conn, "SELECT (SELECT COUNT(*) FROM users), (SELECT COUNT(*) FROM orders), (SELECT COUNT(*) FROM users WHERE name = 'X')")
+	if want := [][]string{{"2", "3", "0"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("counts after blocked queries = %v, want %v", got, want)
+	}
+
+	// 조회가 끝나면 query_only를 다시 꺼서 같은 연결로 쓰기가 가능해야 함
+	if _, err := conn.GetDB().ExecContext(ctx, "INSERT INTO users (email) VALUES ('c@example.com')"); err != nil {
+		t.Errorf("write after read-only query error = %v", err)
+	}
+}
+
+func TestSQLiteConfirmDML(t *testing.T) {
+	conn := newSQLite(t, models.PolicyConfirmDML)
+	ctx := context.Background()
+	query := "UPDATE orders SET total = total + 1 WHERE user_id = 1"
+
+	_, err := conn.Execute(ctx, query, ExecOptions{})
+	var perr *PolicyError
+	if !errors.As(err, &perr) || !perr.NeedsConfirmation {
+		t.Fatalf("Execute() without confirmation error = %v, want confirmation required", err)
+	}
+
+	result, err := conn.Execute(ctx, query, ExecOptions{Confirmed: true})
+	if err != nil {
+		t.Fatalf("Execute() confirmed error = %v", err)
+	}
+	if result.RowsAffected != 2 || result.LastInsertID != nil {
+		t.Errorf("RowsAffected = %d, LastInsertID = %v, want 2, nil", result.RowsAffected, result.LastInsertID)
+	}
+
+	if _, err := conn.Execute(ctx, "DROP TABLE orders", ExecOptions{Confirmed: true}); !errors.As(err, &perr) || perr.Kind != schema.StatementDDL {
+		t.Errorf("DDL error = %v, want DDL blocked", err)
+	}
+}
+
+func TestSQLiteLastInsertID(t *testing.T) {
+	conn := newSQLite(t, models.PolicyUnrestricted)
+	ctx := context.Background()
+
+	result, err := conn.Execute(ctx, "INSERT INTO users (email) VALUES (?)", ExecOptions{Params: Params{Args: []interface{}{"c@example.com"}}})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.RowsAffected != 1 || result.LastInsertID == nil || *result.LastInsertID != 3 {
+		t.Errorf("INSERT = rows %d, last insert id %v, want 1, 3", result.RowsAffected, result.LastInsertID)
+	}
+
+	result, err = conn.Execute(ctx, "DELETE FROM users WHERE id = 3", ExecOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.RowsAffected != 1 || result.LastInsertID != nil {
+		t.Errorf("DELETE = rows %d, last insert id %v, want 1, nil", result.RowsAffected, result.LastInsertID)
+	}
+
+	// 여러 문장은 문장별로 실행하고 영향 행 수를 합산
+	result, err = conn.Execute(ctx, "INSERT INTO users (email) VALUES ('d@example.com'); UPDATE users SET name = 'Z'", ExecOptions{})
+	if err != nil {
+		t.Fatalf("Execute() multi error = %v", err)
+	}
+	if result.RowsAffected != 4 {
+		t.Errorf("multi RowsAffected = %d, want 4", result.RowsAffected)
+	}
+}
+
+func TestSQLiteDryRun(t *testing.T) {
+	conn := newSQLite(t, models.PolicyConfirmDML)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		query  string
+		params Params
+		rows   int64
+		before int
+		after  [][]string
+	}{
+		{"UPDATE", "UPDATE users SET name = :name WHERE id = :id", Params{Named: map[string]interface{}{"name": "changed", "id": 1}},
+			1, 1, [][]string{{"1", "a@example.com", "changed"}}},
+		{"DELETE", "DELETE FROM orders WHERE total > 100", Params{}, 2, 2, nil},
+		{"CASCADE DELETE", "DELETE FROM users", Params{}, 2, 2, nil},
+		{"INSERT SELECT", "INSERT INTO users (email) SELECT 'copy-' || email FROM users", Params{}, 2, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := conn.DryRun(ctx, tt.query, tt.params)
+			if err != nil {
+				t.Fatalf("DryRun() error = %v", err)
+			}
+			if result.RowsAffected != tt.rows {
+				t.Errorf("RowsAffected = %d, want %d", result.RowsAffected, tt.rows)
+			}
+			if result.Before == nil || len(result.Before.Rows) != tt.before {
+				t.Errorf("Before = %+v, want %d rows (preview error %q)", result.Before, tt.before, result.PreviewError)
+			}
+			if tt.after != nil {
+				var after [][]string
+				if result.After != nil {
+					for _, row := range result.After.Rows {
+						after = append(after, []string{fmt.Sprint(row[0]), fmt.Sprint(row[1]), fmt.Sprint(row[2])})
+					}
+				}
+				if !reflect.DeepEqual(after, tt.after) {
+					t.Errorf("After = %v, want %v", after, tt.after)
+				}
+			}
+		})
+	}
+
+	// 모든 변경이 롤백됨
+	got := queryStrings(t, conn, "SELECT (SELECT COUNT(*) FROM users), (SELECT COUNT(*) FROM orders), (SELECT name FROM users WHERE id = 1)")
+	if want := [][]string{{"2", "3", "A"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after dry runs = %v, want %v", got, want)
+	}
+
+	if _, err := conn.DryRun(ctx, "SELECT * FROM users", Params{}); err == nil {
+		t.Error("DryRun(SELECT) error = nil, want DML only")
+	}
+
+	readOnly := newSQLite(t, models.PolicyReadOnly)
+	var perr *PolicyError
+	if _, err := readOnly.DryRun(ctx, "DELETE FROM orders", Params{}); !errors.As(err, &perr) {
+		t.Errorf("read-only DryRun() error = %v, want *PolicyError", err)
+	}
+}
+
+func TestSQLiteFilePath(t *testing.T) {
+	if got := sqliteURIPath(":memory:"); got != ":memory:" {
+		t.Errorf("sqliteURIPath(:memory:) = %q", got)
+	}
+
+	// ?, #, %가 들어간 경로도 잘리지 않고 그대로 파일 이름이 됨
+	path := filepath.Join(t.TempDir(), "dir?x=1", "data #1 100%.db")
+	if err := os.Mkdir(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := NewConnector(models.DBConfig{Type: models.SQLite, Database: path, Policy: models.PolicyUnrestricted})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := conn.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Execute(ctx, "CREATE TABLE t (a INTEGER)", ExecOptions{}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("database file not created at %q: %v", path, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(filepath.Dir(path)))
+	if len(entries) != 1 {
+		t.Errorf("temp dir entries = %d, want only the database directory", len(entries))
+	}
+}
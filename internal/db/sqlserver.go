@@ -3,12 +3,16 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"sql-genius/internal/schema"
 	"sql-genius/pkg/models"
+	"strconv"
 	"strings"
-	"time"
 
-	_ "github.com/denisenkom/go-mssqldb"
+	mssql "github.com/denisenkom/go-mssqldb"
 )
 
 // SQLServerConnector SQL Server 연결자
@@ -24,77 +28,305 @@ func NewSQLServerConnector(config models.DBConfig) (*SQLServerConnector, error)
 }
 
 func (s *SQLServerConnector) Connect(ctx context.Context) error {
-	dsn := fmt.Sprintf("server=%s;port=%d;user id=%s;password=%s;database=%s",
-		s.config.Host, s.config.Port, s.config.User, s.config.Password, s.config.Database)
+	// go-mssqldb는 클라이언트 인증서 인증을 지원하지 않음
+	if s.config.ClientCert != "" || s.config.ClientKey != "" {
+		return fmt.Errorf("SQL Server 연결은 클라이언트 인증서를 지원하지 않습니다")
+	}
 
-	db, err := sql.Open("sqlserver", dsn)
+	connector, err := mssql.NewConnector(s.dsn())
 	if err != nil {
 		return fmt.Errorf("SQL Server 연결 실패: %w", err)
 	}
-
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(time.Hour)
+	tunnel, err := s.openTunnel(ctx)
+	if err != nil {
+		return err
+	}
+	if tunnel != nil {
+		connector.Dialer = tunnel.to(net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port)))
+	}
+	db := sql.OpenDB(connector)
+	configurePool(db, s.config)
 
 	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		tunnel.Close()
 		return fmt.Errorf("SQL Server Ping 실패: %w", err)
 	}
 
-	s.db = db
+	s.db, s.tunnel = db, tunnel
 	return nil
 }
 
-func (s *SQLServerConnector) ExtractSchema(ctx context.Context) (*models.Schema, error) {
+// dsn sqlserver:// URL 형식 연결 문자열
+// TLS 모드: disable은 암호화 없음, prefer는 로그인만 암호화(서버가 요구하면 전체), require는 인증서 검증 없이 암호화,
+// verify-ca/verify-full은 인증서와 호스트 이름 검증 (드라이버가 CA만 검증하는 모드를 지원하지 않음)
+func (s *SQLServerConnector) dsn() string {
+	query := url.Values{}
+	query.Set("database", s.config.Database)
+	if s.config.ApplicationName != "" {
+		query.Set("app name", s.config.ApplicationName)
+	}
+	if s.config.ConnectTimeout > 0 {
+		query.Set("dial timeout", strconv.Itoa(s.config.ConnectTimeout))
+	}
+
+	switch s.config.TLSMode {
+	case models.TLSDisable:
+		query.Set("encrypt", "disable")
+	case models.TLSPrefer:
+		query.Set("encrypt", "false")
+	case models.TLSRequire:
+		query.Set("encrypt", "true")
+		query.Set("TrustServerCertificate", "true")
+	case models.TLSVerifyCA, models.TLSVerifyFull:
+		query.Set("encrypt", "true")
+		query.Set("TrustServerCertificate", "false")
+	}
+	if s.config.CACert != "" {
+		query.Set("certificate", s.config.CACert)
+	}
+
+	// 드라이버가 호스트 이름을 로컬에서 먼저 조회하므로, SSH 터널을 쓸 때는 IP 자리표시자를 두고
+	// 터널 dialer가 실제 주소로 연결 (인증서는 실제 호스트 이름으로 검증)
+	host := s.config.Host
+	if s.config.SSH != nil {
+		host = "127.0.0.1"
+		query.Set("hostnameincertificate", s.config.Host)
+	}
+
+	for k, v := range s.config.Params {
+		query.Set(k, v)
+	}
+
+	u := url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(s.config.User, s.config.Password),
+		Host:     net.JoinHostPort(host, strconv.Itoa(s.config.Port)),
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// ExtractSchema 스키마 추출 (스키마 패턴이 없으면 현재 데이터베이스의 모든 스키마)
+func (s *SQLServerConnector) ExtractSchema(ctx context.Context, opts ExtractOptions) (*models.Schema, error) {
 	schema := &models.Schema{
 		Database: s.config.Database,
 		DBType:   models.SQLServer,
 		Tables:   []models.Table{},
 	}
 
-	tables, err := s.getTables(ctx)
-	if err != nil {
+	if err := extractCatalog(ctx, s, schema, opts); err != nil {
 		return nil, err
 	}
+	return schema, nil
+}
 
-	for _, tableName := range tables {
-		table := models.Table{Name: tableName}
+// TableVersions 추출 범위의 테이블과 modify_date 버전
+func (s *SQLServerConnector) TableVersions(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	return s.getTables(ctx, opts)
+}
+
+// getTables 추출할 테이블 (버전은 ALTER와 인덱스 변경 때 바뀌는 sys.objects.modify_date)
+func (s *SQLServerConnector) getTables(ctx context.Context, opts ExtractOptions) ([]TableVersion, error) {
+	query := `
+		SELECT t.TABLE_SCHEMA, t.TABLE_NAME, CONVERT(varchar(33), o.modify_date, 126)
+		FROM INFORMATION_SCHEMA.TABLES t
+		JOIN sys.objects o ON o.object_id = OBJECT_ID(QUOTENAME(t.TABLE_SCHEMA) + '.' + QUOTENAME(t.TABLE_NAME))
+		WHERE t.TABLE_TYPE = 'BASE TABLE' AND t.TABLE_CATALOG = DB_NAME()
+		ORDER BY t.TABLE_SCHEMA, t.TABLE_NAME`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		columns, err := s.getColumns(ctx, tableName)
-		if err != nil {
+	var tables []TableVersion
+	for rows.Next() {
+		var t TableVersion
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Version); err != nil {
 			return nil, err
 		}
-		table.Columns = columns
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return opts.filterTables(tables), nil
+}
+
+// getViews 뷰 (암호화된 뷰는 정의 없이 컬럼만, 컬럼은 extractCatalog가 getColumns 결과로 채움)
+func (s *SQLServerConnector) getViews(ctx context.Context, opts ExtractOptions) ([]models.View, error) {
+	query := `
+		SELECT SCHEMA_NAME(v.schema_id), v.name, m.definition
+		FROM sys.views v
+		LEFT JOIN sys.sql_modules m ON m.object_id = v.object_id
+		WHERE v.is_ms_shipped = 0
+		ORDER BY 1, v.name`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		indexes, err := s.getIndexes(ctx, tableName)
-		if err != nil {
+	var views []models.View
+	for rows.Next() {
+		var v models.View
+		var definition sql.NullString
+		if err := rows.Scan(&v.Schema, &v.Name, &definition); err != nil {
 			return nil, err
 		}
-		table.Indexes = indexes
+		v.Definition = viewQuery(definition.String)
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
 
-		fks, err := s.getForeignKeys(ctx, tableName)
-		if err != nil {
+// getSequences 시퀀스 (SQL Server 2012 이상)
+func (s *SQLServerConnector) getSequences(ctx context.Context, opts ExtractOptions) ([]models.Sequence, error) {
+	query := `
+		SELECT SCHEMA_NAME(schema_id), name, TYPE_NAME(user_type_id),
+			CAST(start_value AS bigint), CAST(increment AS bigint), is_cycling
+		FROM sys.sequences
+		ORDER BY 1, name`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sequences []models.Sequence
+	for rows.Next() {
+		var seq models.Sequence
+		if err := rows.Scan(&seq.Schema, &seq.Name, &seq.DataType, &seq.Start, &seq.Increment, &seq.Cycle); err != nil {
 			return nil, err
 		}
-		table.ForeignKeys = fks
+		sequences = append(sequences, seq)
+	}
+	return sequences, rows.Err()
+}
 
-		pks, err := s.getPrimaryKeys(ctx, tableName)
-		if err != nil {
+// getRoutines 프로시저와 함수 (스칼라, 인라인·다중 문 테이블 반환 함수)
+func (s *SQLServerConnector) getRoutines(ctx context.Context, opts ExtractOptions) ([]models.Routine, error) {
+	query := `
+		SELECT o.object_id, SCHEMA_NAME(o.schema_id), o.name, o.type, m.definition
+		FROM sys.objects o
+		LEFT JOIN sys.sql_modules m ON m.object_id = o.object_id
+		WHERE o.type IN ('P', 'FN', 'IF', 'TF') AND o.is_ms_shipped = 0
+		ORDER BY 2, o.name`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routines []models.Routine
+	index := make(map[int64]int)
+	for rows.Next() {
+		var r models.Routine
+		var objectID int64
+		var objectType string
+		var definition sql.NullString
+		if err := rows.Scan(&objectID, &r.Schema, &r.Name, &objectType, &definition); err != nil {
 			return nil, err
 		}
-		table.PrimaryKey = pks
+		r.Kind = models.RoutineFunction
+		switch strings.TrimSpace(objectType) {
+		case "P":
+			r.Kind = models.RoutineProcedure
+		case "IF", "TF":
+			r.Returns = "TABLE"
+		}
+		r.Language = "T-SQL"
+		r.Definition = strings.TrimSpace(definition.String)
+		index[objectID] = len(routines)
+		routines = append(routines, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// 인자 (parameter_id 0은 스칼라 함수의 반환 값)
+	paramQuery := `
+		SELECT p.object_id, p.parameter_id, p.name, TYPE_NAME(p.user_type_id),
+			p.max_length, p.precision, p.scale, p.is_output
+		FROM sys.parameters p
+		JOIN sys.objects o ON o.object_id = p.object_id
+		WHERE o.type IN ('P', 'FN', 'IF', 'TF') AND o.is_ms_shipped = 0
+		ORDER BY p.object_id, p.parameter_id`
+
+	rows, err = s.db.QueryContext(ctx, paramQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		schema.Tables = append(schema.Tables, table)
+	args := make(map[int64][]string)
+	for rows.Next() {
+		var objectID int64
+		var paramID, maxLength, precision, scale int
+		var name, typeName string
+		var output bool
+		if err := rows.Scan(&objectID, &paramID, &name, &typeName, &maxLength, &precision, &scale, &output); err != nil {
+			return nil, err
+		}
+		i, ok := index[objectID]
+		if !ok {
+			continue
+		}
+		typ := mssqlTypeName(typeName, maxLength, precision, scale)
+		if paramID == 0 {
+			routines[i].Returns = typ
+			continue
+		}
+		arg := name + " " + typ
+		if output {
+			arg += " OUTPUT"
+		}
+		args[objectID] = append(args[objectID], arg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	for objectID, i := range index {
+		routines[i].Arguments = strings.Join(args[objectID], ", ")
+	}
+	return routines, nil
+}
 
-	return schema, nil
+// mssqlTypeName 길이와 정밀도를 붙인 타입 이름 (sys.parameters, sys.columns 값 기준)
+func mssqlTypeName(name string, maxLength, precision, scale int) string {
+	switch strings.ToLower(name) {
+	case "varchar", "char", "varbinary", "binary":
+		if maxLength < 0 {
+			return name + "(max)"
+		}
+		return fmt.Sprintf("%s(%d)", name, maxLength)
+	case "nvarchar", "nchar":
+		if maxLength < 0 {
+			return name + "(max)"
+		}
+		return fmt.Sprintf("%s(%d)", name, maxLength/2)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d,%d)", name, precision, scale)
+	}
+	return name
 }
 
-func (s *SQLServerConnector) getTables(ctx context.Context) ([]string, error) {
+// getTriggers 테이블·뷰 트리거 (데이터베이스 DDL 트리거 제외)
+func (s *SQLServerConnector) getTriggers(ctx context.Context, opts ExtractOptions) ([]models.Trigger, error) {
 	query := `
-		SELECT TABLE_NAME 
-		FROM INFORMATION_SCHEMA.TABLES 
-		WHERE TABLE_TYPE = 'BASE TABLE' AND TABLE_CATALOG = DB_NAME()
-		ORDER BY TABLE_NAME`
+		SELECT SCHEMA_NAME(o.schema_id), t.name, o.name, t.is_instead_of_trigger, te.type_desc, m.definition
+		FROM sys.triggers t
+		JOIN sys.objects o ON o.object_id = t.parent_id
+		JOIN sys.trigger_events te ON te.object_id = t.object_id
+		LEFT JOIN sys.sql_modules m ON m.object_id = t.object_id
+		WHERE t.parent_class = 1 AND t.is_ms_shipped = 0
+		ORDER BY 1, o.name, t.name, te.type`
 
 	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
@@ -102,49 +334,107 @@ func (s *SQLServerConnector) getTables(ctx context.Context) ([]string, error) {
 	}
 	defer rows.Close()
 
-	var tables []string
+	var triggers []models.Trigger
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var t models.Trigger
+		var insteadOf bool
+		var event string
+		var definition sql.NullString
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Table, &insteadOf, &event, &definition); err != nil {
 			return nil, err
 		}
-		tables = append(tables, name)
+		// 이벤트마다 한 행이므로 같은 트리거는 이벤트만 합침
+		if n := len(triggers); n > 0 && triggers[n-1].Schema == t.Schema && triggers[n-1].Name == t.Name {
+			triggers[n-1].Events = append(triggers[n-1].Events, event)
+			continue
+		}
+		t.Timing = "AFTER"
+		if insteadOf {
+			t.Timing = "INSTEAD OF"
+		}
+		t.Events = []string{event}
+		t.Definition = strings.TrimSpace(definition.String)
+		triggers = append(triggers, t)
 	}
-	return tables, nil
+	return triggers, rows.Err()
 }
 
-func (s *SQLServerConnector) getColumns(ctx context.Context, table string) ([]models.Column, error) {
+// getChecks CHECK 제약조건 (컬럼 수준 제약은 그 컬럼을 참조 컬럼으로)
+func (s *SQLServerConnector) getChecks(ctx context.Context, schema string) (map[string][]models.Check, error) {
+	query := `
+		SELECT OBJECT_NAME(cc.parent_object_id), cc.name, cc.definition,
+			COALESCE(COL_NAME(cc.parent_object_id, NULLIF(cc.parent_column_id, 0)), '')
+		FROM sys.check_constraints cc
+		WHERE cc.schema_id = SCHEMA_ID(@p1)
+		ORDER BY 1, cc.name`
+
+	rows, err := s.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string][]models.Check)
+	for rows.Next() {
+		var table, column string
+		var c models.Check
+		if err := rows.Scan(&table, &c.Name, &c.Expression, &column); err != nil {
+			return nil, err
+		}
+		if column != "" {
+			c.Columns = []string{column}
+		}
+		checks[table] = append(checks[table], c)
+	}
+	return checks, rows.Err()
+}
+
+// getColumns 스키마의 테이블과 뷰 컬럼
+func (s *SQLServerConnector) getColumns(ctx context.Context, schema string) (map[string][]models.Column, error) {
 	query := `
 		SELECT 
-			c.COLUMN_NAME, c.DATA_TYPE, c.IS_NULLABLE, c.COLUMN_DEFAULT,
+			c.TABLE_NAME, c.COLUMN_NAME, c.DATA_TYPE, c.IS_NULLABLE, c.COLUMN_DEFAULT,
 			CASE WHEN pk.COLUMN_NAME IS NOT NULL THEN 1 ELSE 0 END as is_pk,
-			COLUMNPROPERTY(OBJECT_ID(c.TABLE_SCHEMA + '.' + c.TABLE_NAME), c.COLUMN_NAME, 'IsIdentity') as is_identity
+			COLUMNPROPERTY(OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME)), c.COLUMN_NAME, 'IsIdentity') as is_identity,
+			CASE WHEN c.COLLATION_NAME <> CONVERT(sysname, DATABASEPROPERTYEX(DB_NAME(), 'Collation'))
+				THEN c.COLLATION_NAME END,
+			cc.definition, cc.is_persisted
 		FROM INFORMATION_SCHEMA.COLUMNS c
+		LEFT JOIN sys.computed_columns cc
+			ON cc.object_id = OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME)) AND cc.name = c.COLUMN_NAME
 		LEFT JOIN (
-			SELECT ku.COLUMN_NAME
+			SELECT tc.TABLE_NAME, ku.COLUMN_NAME
 			FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
-			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE ku ON tc.CONSTRAINT_NAME = ku.CONSTRAINT_NAME
-			WHERE tc.TABLE_NAME = @p1 AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
-		) pk ON c.COLUMN_NAME = pk.COLUMN_NAME
-		WHERE c.TABLE_NAME = @p1
-		ORDER BY c.ORDINAL_POSITION`
-
-	rows, err := s.db.QueryContext(ctx, query, table)
+			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE ku
+				ON tc.CONSTRAINT_SCHEMA = ku.CONSTRAINT_SCHEMA AND tc.CONSTRAINT_NAME = ku.CONSTRAINT_NAME
+			WHERE tc.TABLE_SCHEMA = @p1 AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+		) pk ON c.TABLE_NAME = pk.TABLE_NAME AND c.COLUMN_NAME = pk.COLUMN_NAME
+		WHERE c.TABLE_SCHEMA = @p1
+		ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`
+
+	rows, err := s.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var columns []models.Column
+	columns := make(map[string][]models.Column)
 	for rows.Next() {
 		var col models.Column
-		var nullable string
-		var defaultVal sql.NullString
+		var table, nullable string
+		var defaultVal, collation, computed sql.NullString
+		var persisted sql.NullBool
 		var isPK, isIdentity int
 
-		if err := rows.Scan(&col.Name, &col.Type, &nullable, &defaultVal, &isPK, &isIdentity); err != nil {
+		if err := rows.Scan(&table, &col.Name, &col.Type, &nullable, &defaultVal, &isPK, &isIdentity,
+			&collation, &computed, &persisted); err != nil {
 			return nil, err
 		}
+		col.Collation = collation.String
+		if computed.Valid {
+			col.Generated = generatedExpr(computed.String)
+			col.Stored = persisted.Bool
+		}
 
 		col.Nullable = nullable == "YES"
 		col.IsPK = isPK == 1
@@ -153,95 +443,127 @@ func (s *SQLServerConnector) getColumns(ctx context.Context, table string) ([]mo
 			col.Default = defaultVal.String
 		}
 
-		columns = append(columns, col)
+		columns[table] = append(columns[table], col)
 	}
-	return columns, nil
+	return columns, rows.Err()
 }
 
-func (s *SQLServerConnector) getIndexes(ctx context.Context, table string) ([]models.Index, error) {
+func (s *SQLServerConnector) getIndexes(ctx context.Context, schema string) (map[string][]models.Index, error) {
 	query := `
 		SELECT 
+			t.name as table_name,
 			i.name as index_name,
 			c.name as column_name,
 			i.is_unique,
 			i.type_desc as index_type
 		FROM sys.indexes i
+		JOIN sys.tables t ON t.object_id = i.object_id
 		JOIN sys.index_columns ic ON i.object_id = ic.object_id AND i.index_id = ic.index_id
 		JOIN sys.columns c ON ic.object_id = c.object_id AND ic.column_id = c.column_id
-		WHERE i.object_id = OBJECT_ID(@p1) AND i.name IS NOT NULL
-		ORDER BY i.name, ic.key_ordinal`
+		WHERE t.schema_id = SCHEMA_ID(@p1) AND i.name IS NOT NULL
+		ORDER BY t.name, i.name, ic.key_ordinal`
 
-	rows, err := s.db.QueryContext(ctx, query, table)
+	rows, err := s.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	indexMap := make(map[string]*models.Index)
+	indexes := make(map[string][]models.Index)
 	for rows.Next() {
-		var indexName, columnName, indexType string
+		var table, indexName, columnName, indexType string
 		var isUnique bool
 
-		if err := rows.Scan(&indexName, &columnName, &isUnique, &indexType); err != nil {
+		if err := rows.Scan(&table, &indexName, &columnName, &isUnique, &indexType); err != nil {
 			return nil, err
 		}
 
-		if idx, exists := indexMap[indexName]; exists {
-			idx.Columns = append(idx.Columns, columnName)
-		} else {
-			indexMap[indexName] = &models.Index{
-				Name:     indexName,
-				Columns:  []string{columnName},
-				IsUnique: isUnique,
-				Type:     indexType,
-			}
+		list := indexes[table]
+		if n := len(list); n > 0 && list[n-1].Name == indexName {
+			list[n-1].Columns = append(list[n-1].Columns, columnName)
+			continue
 		}
+		indexes[table] = append(list, models.Index{
+			Name:     indexName,
+			Columns:  []string{columnName},
+			IsUnique: isUnique,
+			Type:     indexType,
+		})
 	}
-
-	var indexes []models.Index
-	for _, idx := range indexMap {
-		indexes = append(indexes, *idx)
-	}
-	return indexes, nil
+	return indexes, rows.Err()
 }
 
-func (s *SQLServerConnector) getForeignKeys(ctx context.Context, table string) ([]models.FK, error) {
+func (s *SQLServerConnector) getForeignKeys(ctx context.Context, schema string) (map[string][]models.FK, error) {
 	query := `
 		SELECT 
+			OBJECT_NAME(fk.parent_object_id) as table_name,
 			fk.name as constraint_name,
 			COL_NAME(fkc.parent_object_id, fkc.parent_column_id) as column_name,
+			OBJECT_SCHEMA_NAME(fkc.referenced_object_id) as ref_schema,
 			OBJECT_NAME(fkc.referenced_object_id) as ref_table,
 			COL_NAME(fkc.referenced_object_id, fkc.referenced_column_id) as ref_column
 		FROM sys.foreign_keys fk
 		JOIN sys.foreign_key_columns fkc ON fk.object_id = fkc.constraint_object_id
-		WHERE fk.parent_object_id = OBJECT_ID(@p1)`
+		WHERE fk.schema_id = SCHEMA_ID(@p1)
+		ORDER BY 1, fk.name, fkc.constraint_column_id`
 
-	rows, err := s.db.QueryContext(ctx, query, table)
+	rows, err := s.db.QueryContext(ctx, query, schema)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var fks []models.FK
+	fks := make(map[string][]models.FK)
 	for rows.Next() {
+		var table string
 		var fk models.FK
-		if err := rows.Scan(&fk.Name, &fk.Column, &fk.RefTable, &fk.RefColumn); err != nil {
+		if err := rows.Scan(&table, &fk.Name, &fk.Column, &fk.RefSchema, &fk.RefTable, &fk.RefColumn); err != nil {
+			return nil, err
+		}
+		fks[table] = append(fks[table], fk)
+	}
+	return fks, rows.Err()
+}
+
+// getPrimaryKeys 스키마의 테이블별 기본 키 컬럼
+func (s *SQLServerConnector) getPrimaryKeys(ctx context.Context, schema string) (map[string][]string, error) {
+	query := `
+		SELECT tc.TABLE_NAME, ku.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+		JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE ku
+			ON tc.CONSTRAINT_SCHEMA = ku.CONSTRAINT_SCHEMA AND tc.CONSTRAINT_NAME = ku.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = @p1 AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+		ORDER BY tc.TABLE_NAME, ku.ORDINAL_POSITION`
+
+	rows, err := s.db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pks := make(map[string][]string)
+	for rows.Next() {
+		var table, pk string
+		if err := rows.Scan(&table, &pk); err != nil {
 			return nil, err
 		}
-		fks = append(fks, fk)
+		pks[table] = append(pks[table], pk)
 	}
-	return fks, nil
+	return pks, rows.Err()
 }
 
-func (s *SQLServerConnector) getPrimaryKeys(ctx context.Context, table string) ([]string, error) {
+// tablePrimaryKey 테이블 하나의 기본 키 컬럼 (schema가 비어 있으면 사용자의 기본 스키마)
+func (s *SQLServerConnector) tablePrimaryKey(ctx context.Context, schema, table string) ([]string, error) {
 	query := `
 		SELECT ku.COLUMN_NAME
 		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
-		JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE ku ON tc.CONSTRAINT_NAME = ku.CONSTRAINT_NAME
-		WHERE tc.TABLE_NAME = @p1 AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+		JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE ku
+			ON tc.CONSTRAINT_SCHEMA = ku.CONSTRAINT_SCHEMA AND tc.CONSTRAINT_NAME = ku.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = COALESCE(NULLIF(@p1, ''), SCHEMA_NAME())
+			AND tc.TABLE_NAME = @p2 AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
 		ORDER BY ku.ORDINAL_POSITION`
 
-	rows, err := s.db.QueryContext(ctx, query, table)
+	rows, err := s.db.QueryContext(ctx, query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -255,62 +577,155 @@ func (s *SQLServerConnector) getPrimaryKeys(ctx context.Context, table string) (
 		}
 		pks = append(pks, pk)
 	}
-	return pks, nil
+	return pks, rows.Err()
 }
 
-func (s *SQLServerConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
-	start := time.Now()
+// ExtractStats 파티션의 행 수와 할당된 페이지 크기, 컬럼 통계 객체의 히스토그램
+func (s *SQLServerConnector) ExtractStats(ctx context.Context, schema *models.Schema) error {
+	return extractStats(ctx, s, schema)
+}
 
-	rows, err := s.db.QueryContext(ctx, query)
+// getTableStats 힙 또는 클러스터형 인덱스의 행 수와 전체 할당 페이지 × 8KB
+func (s *SQLServerConnector) getTableStats(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT t.name,
+			(SELECT SUM(p.rows) FROM sys.partitions p
+				WHERE p.object_id = t.object_id AND p.index_id IN (0, 1)),
+			(SELECT SUM(a.total_pages) FROM sys.partitions p
+				JOIN sys.allocation_units a ON a.container_id = p.partition_id
+				WHERE p.object_id = t.object_id) * 8192
+		FROM sys.tables t
+		WHERE t.schema_id = SCHEMA_ID(@p1)`
+
+	rows, err := s.db.QueryContext(ctx, query, schema)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
+	for rows.Next() {
+		var name string
+		var count, size sql.NullInt64
+		if err := rows.Scan(&name, &count, &size); err != nil {
+			return err
+		}
+		stats := models.TableStats{Rows: -1, SizeBytes: size.Int64}
+		if count.Valid {
+			stats.Rows = count.Int64
+		}
+		target.setTable(schema, name, stats)
+	}
+	return rows.Err()
+}
+
+// getColumnStats 컬럼이 첫 번째인 통계 객체의 히스토그램 (dm_db_stats_properties, dm_db_stats_histogram)
+// 고유 값 수는 단계별 distinct_range_rows 합에 상한 값 개수를 더한 것이고, NULL 비율은 NULL 단계의 equal_rows로 계산합니다.
+// 한 컬럼에 통계 객체가 여럿이면 stats_id가 가장 작은 것만 사용합니다.
+// dm_db_stats_histogram이 없는 SQL Server 2016 SP1 CU2 이전 버전은 컬럼 통계를 건너뜁니다.
+func (s *SQLServerConnector) getColumnStats(ctx context.Context, target *statsTarget, schema string) error {
+	query := `
+		SELECT t.name, c.name, st.stats_id, sp.rows,
+			CONVERT(nvarchar(4000), h.range_high_key), h.equal_rows, h.distinct_range_rows
+		FROM sys.stats st
+		JOIN sys.tables t ON t.object_id = st.object_id
+		JOIN sys.stats_columns sc ON sc.object_id = st.object_id AND sc.stats_id = st.stats_id AND sc.stats_column_id = 1
+		JOIN sys.columns c ON c.object_id = sc.object_id AND c.column_id = sc.column_id
+		CROSS APPLY sys.dm_db_stats_properties(st.object_id, st.stats_id) sp
+		CROSS APPLY sys.dm_db_stats_histogram(st.object_id, st.stats_id) h
+		WHERE t.schema_id = SCHEMA_ID(@p1)
+		ORDER BY t.name, c.name, st.stats_id, h.step_number`
+
+	rows, err := s.db.QueryContext(ctx, query, schema)
 	if err != nil {
-		return nil, err
+		var msErr mssql.Error
+		if errors.As(err, &msErr) && msErr.Number == 208 { // Invalid object name
+			return nil
+		}
+		return err
 	}
+	defer rows.Close()
 
-	var resultRows [][]interface{}
+	// 컬럼마다 처음 나온 통계 객체
+	used := make(map[*models.ColumnStats]int)
 	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		valuePtrs := make([]interface{}, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
+		var table, column string
+		var statsID int
+		var total int64
+		var key sql.NullString
+		var equal, distinct float64
+		if err := rows.Scan(&table, &column, &statsID, &total, &key, &equal, &distinct); err != nil {
+			return err
 		}
-
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, err
+		stats := target.column(schema, table, column)
+		if stats == nil {
+			continue
 		}
+		if id, ok := used[stats]; ok && id != statsID {
+			continue
+		}
+		used[stats] = statsID
 
-		row := make([]interface{}, len(columns))
-		for i, v := range values {
-			if b, ok := v.([]byte); ok {
-				row[i] = string(b)
-			} else {
-				row[i] = v
-			}
+		if !key.Valid {
+			stats.NullFraction = nullFraction(int64(equal), total)
+			continue
 		}
-		resultRows = append(resultRows, row)
+		stats.Distinct += int64(distinct) + 1
+		stats.Histogram = append(stats.Histogram, key.String)
 	}
+	return rows.Err()
+}
 
-	return &QueryResult{
-		Columns:  columns,
-		Rows:     resultRows,
-		Duration: time.Since(start).Milliseconds(),
-	}, nil
+func (s *SQLServerConnector) ExecuteQuery(ctx context.Context, query string) (*QueryResult, error) {
+	return s.Execute(ctx, query, ExecOptions{})
+}
+
+func (s *SQLServerConnector) Execute(ctx context.Context, query string, opts ExecOptions) (*QueryResult, error) {
+	return s.execute(ctx, query, opts, s.beginReadOnly)
+}
+
+// OpenCursor 결과 행을 하나씩 읽는 커서 열기
+func (s *SQLServerConnector) OpenCursor(ctx context.Context, query string, opts ExecOptions) (*Cursor, error) {
+	return s.openCursor(ctx, query, opts, s.beginReadOnly)
+}
+
+// OpenPage offset개 행 다음부터 읽는 페이지 커서 열기
+func (s *SQLServerConnector) OpenPage(ctx context.Context, query string, offset int, opts ExecOptions) (*Cursor, error) {
+	return s.openPage(ctx, query, offset, opts, s.beginReadOnly, false)
+}
+
+// DryRun 트랜잭션 안에서 DML을 실행해 영향을 받는 행을 확인한 뒤 롤백
+func (s *SQLServerConnector) DryRun(ctx context.Context, query string, params Params) (*DryRunResult, error) {
+	return s.dryRun(ctx, query, params, func(ctx context.Context, target *schema.DMLTarget) ([]string, error) {
+		return s.tablePrimaryKey(ctx, target.Schema, target.Table)
+	})
+}
+
+// beginReadOnly SQL Server는 읽기 전용 트랜잭션이 없으므로 일반 트랜잭션에서 실행하고 항상 롤백
+// (분류기가 조회로 판단한 문장만 이 경로를 사용)
+func (s *SQLServerConnector) beginReadOnly(ctx context.Context) (*sql.Tx, func(), error) {
+	return s.beginTx(ctx, nil, "")
 }
 
 func (s *SQLServerConnector) Explain(ctx context.Context, query string) (string, error) {
+	if err := s.singleStatement(query); err != nil {
+		return "", err
+	}
+
 	// SQL Server: SET SHOWPLAN_TEXT ON
-	_, err := s.db.ExecContext(ctx, "SET SHOWPLAN_TEXT ON")
+	// 세션 설정이므로 풀의 다른 연결에서 쿼리가 실행되지 않도록 전용 연결 사용
+	conn, err := s.db.Conn(ctx)
 	if err != nil {
 		return "", err
 	}
-	defer s.db.ExecContext(ctx, "SET SHOWPLAN_TEXT OFF")
+	defer conn.Close()
 
-	rows, err := s.db.QueryContext(ctx, query)
+	_, err = conn.ExecContext(ctx, "SET SHOWPLAN_TEXT ON")
+	if err != nil {
+		return "", err
+	}
+	defer conn.ExecContext(context.Background(), "SET SHOWPLAN_TEXT OFF")
+
+	rows, err := conn.QueryContext(ctx, query)
 	if err != nil {
 		return "", err
 	}
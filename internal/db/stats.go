@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sql-genius/pkg/models"
+)
+
+// statsExtractor 테이블·컬럼 통계 조회 (연결자마다 구현, 스키마 하나씩 조회)
+// 조회 결과는 target에서 추출한 스키마에 있는 테이블과 컬럼에만 채워집니다.
+type statsExtractor interface {
+	getTableStats(ctx context.Context, target *statsTarget, schema string) error
+	getColumnStats(ctx context.Context, target *statsTarget, schema string) error
+}
+
+// extractStats 스키마의 테이블과 컬럼에 통계를 채움 (이전 통계는 지움)
+// 컬럼 통계가 테이블 행 수를 사용할 수 있도록 테이블 통계를 먼저 모두 조회합니다.
+func extractStats(ctx context.Context, x statsExtractor, schema *models.Schema) error {
+	target := newStatsTarget(schema)
+
+	for _, name := range target.schemas {
+		if err := x.getTableStats(ctx, target, name); err != nil {
+			return fmt.Errorf("테이블 통계 조회 실패: %w", err)
+		}
+	}
+	for _, name := range target.schemas {
+		if err := x.getColumnStats(ctx, target, name); err != nil {
+			return fmt.Errorf("컬럼 통계 조회 실패: %w", err)
+		}
+	}
+	target.trimHistograms()
+	return nil
+}
+
+// statsTarget 통계를 채울 테이블 (카탈로그가 돌려주는 이름 그대로 비교)
+type statsTarget struct {
+	tables  map[tableRef]*models.Table
+	schemas []string // 테이블이 있는 스키마 (SQLite는 "" 하나)
+}
+
+func newStatsTarget(schema *models.Schema) *statsTarget {
+	target := &statsTarget{tables: make(map[tableRef]*models.Table)}
+	seen := make(map[string]bool)
+
+	for i := range schema.Tables {
+		table := &schema.Tables[i]
+		table.Stats = nil
+		for j := range table.Columns {
+			table.Columns[j].Stats = nil
+		}
+		target.tables[tableRef{Schema: table.Schema, Name: table.Name}] = table
+
+		if !seen[table.Schema] {
+			seen[table.Schema] = true
+			target.schemas = append(target.schemas, table.Schema)
+		}
+	}
+	return target
+}
+
+// table 추출한 스키마의 테이블 (없으면 nil)
+func (t *statsTarget) table(schema, name string) *models.Table {
+	return t.tables[tableRef{Schema: schema, Name: name}]
+}
+
+// setTable 테이블 통계 설정 (추출한 스키마에 없는 테이블은 무시)
+func (t *statsTarget) setTable(schema, name string, stats models.TableStats) {
+	if table := t.table(schema, name); table != nil {
+		table.Stats = &stats
+	}
+}
+
+// rows 테이블의 추정 행 수 (모르면 -1)
+func (t *statsTarget) rows(schema, name string) int64 {
+	if table := t.table(schema, name); table != nil && table.Stats != nil {
+		return table.Stats.Rows
+	}
+	return -1
+}
+
+// column 컬럼 통계 (처음 찾을 때 만들며, 추출한 스키마에 없는 컬럼이면 nil)
+func (t *statsTarget) column(schema, table, column string) *models.ColumnStats {
+	tbl := t.table(schema, table)
+	if tbl == nil {
+		return nil
+	}
+	for i := range tbl.Columns {
+		if tbl.Columns[i].Name == column {
+			if tbl.Columns[i].Stats == nil {
+				tbl.Columns[i].Stats = &models.ColumnStats{}
+			}
+			return tbl.Columns[i].Stats
+		}
+	}
+	return nil
+}
+
+// maxHistogramBounds 컬럼마다 남기는 히스토그램 경계 값의 최대 개수
+const maxHistogramBounds = 11
+
+// trimHistograms 히스토그램 경계 값을 처음과 끝을 포함해 고르게 maxHistogramBounds개로 줄임
+func (t *statsTarget) trimHistograms() {
+	for _, table := range t.tables {
+		for i := range table.Columns {
+			stats := table.Columns[i].Stats
+			if stats == nil || len(stats.Histogram) <= maxHistogramBounds {
+				continue
+			}
+			bounds := make([]string, maxHistogramBounds)
+			last := len(stats.Histogram) - 1
+			for j := range bounds {
+				bounds[j] = stats.Histogram[j*last/(maxHistogramBounds-1)]
+			}
+			stats.Histogram = bounds
+		}
+	}
+}
+
+// nullFraction NULL 개수를 비율로 (행 수를 모르면 0)
+func nullFraction(nulls, rows int64) float64 {
+	if nulls <= 0 || rows <= 0 {
+		return 0
+	}
+	if nulls >= rows {
+		return 1
+	}
+	return float64(nulls) / float64(rows)
+}
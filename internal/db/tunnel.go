@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sql-genius/pkg/models"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// sshTunnel 배스천 호스트의 SSH 연결
+// 드라이버 dialer로 넘기면 DB 연결이 모두 SSH 채널(direct-tcpip)로 열리며, 연결자의 Close에서 닫힙니다.
+type sshTunnel struct {
+	client *ssh.Client
+	agent  net.Conn // ssh-agent 소켓 (사용할 때만)
+}
+
+// openTunnel SSH 설정이 있으면 배스천 호스트에 접속 (없으면 nil)
+func openTunnel(ctx context.Context, config *models.SSHConfig, timeout time.Duration) (*sshTunnel, error) {
+	if config == nil {
+		return nil, nil
+	}
+	if config.Host == "" || config.User == "" {
+		return nil, errors.New("SSH 터널에는 host와 user가 필요합니다")
+	}
+
+	tunnel := &sshTunnel{}
+	auth, err := tunnel.authMethods(config)
+	if err != nil {
+		tunnel.Close()
+		return nil, err
+	}
+	hostKey, err := hostKeyCallback(config)
+	if err != nil {
+		tunnel.Close()
+		return nil, err
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = 22
+	}
+	addr := net.JoinHostPort(config.Host, strconv.Itoa(port))
+	clientConfig := &ssh.ClientConfig{
+		User:            config.User,
+		Auth:            auth,
+		HostKeyCallback: hostKey,
+		Timeout:         timeout,
+	}
+
+	conn, err := (&net.Dialer{Timeout: timeout}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		tunnel.Close()
+		return nil, fmt.Errorf("SSH 접속 실패: %w", err)
+	}
+	// 핸드셰이크도 컨텍스트 시간 제한을 따르도록
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, clientConfig)
+	if err != nil {
+		conn.Close()
+		tunnel.Close()
+		return nil, fmt.Errorf("SSH 인증 실패: %w", err)
+	}
+	conn.SetDeadline(time.Time{})
+
+	tunnel.client = ssh.NewClient(c, chans, reqs)
+	return tunnel, nil
+}
+
+// authMethods 키 파일, 에이전트, 비밀번호 순으로 인증 방법 구성
+func (t *sshTunnel) authMethods(config *models.SSHConfig) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	if config.KeyFile != "" {
+		pem, err := os.ReadFile(expandHome(config.KeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("SSH 키 파일 읽기 실패: %w", err)
+		}
+		var signer ssh.Signer
+		if config.KeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(pem, []byte(config.KeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(pem)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("SSH 키 파싱 실패: %w", err)
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if config.Agent {
+		sock := os.Getenv("SSH_AUTH_SOCK")
+		if sock == "" {
+			return nil, errors.New("SSH_AUTH_SOCK이 설정되어 있지 않아 ssh-agent를 사용할 수 없습니다")
+		}
+		conn, err := net.Dial("unix", sock)
+		if err != nil {
+			return nil, fmt.Errorf("ssh-agent 연결 실패: %w", err)
+		}
+		t.agent = conn
+		methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+	}
+	if config.Password != "" {
+		methods = append(methods, ssh.Password(config.Password))
+	}
+
+	if len(methods) == 0 {
+		return nil, errors.New("SSH 인증 방법이 없습니다 (key_file, agent, password 중 하나 필요)")
+	}
+	return methods, nil
+}
+
+// hostKeyCallback known_hosts 파일로 호스트 키 검증
+func hostKeyCallback(config *models.SSHConfig) (ssh.HostKeyCallback, error) {
+	if config.InsecureIgnoreHostKey {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	path := config.KnownHosts
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("known_hosts 경로를 찾을 수 없습니다: %w", err)
+		}
+		path = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	callback, err := knownhosts.New(expandHome(path))
+	if err != nil {
+		return nil, fmt.Errorf("known_hosts 읽기 실패: %w", err)
+	}
+	return callback, nil
+}
+
+// expandHome 경로 앞의 ~/를 홈 디렉터리로
+func expandHome(path string) string {
+	if len(path) < 2 || path[:2] != "~/" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
+// Dial lib/pq Dialer
+func (t *sshTunnel) Dial(network, addr string) (net.Conn, error) {
+	return t.DialContext(context.Background(), network, addr)
+}
+
+// DialTimeout lib/pq Dialer
+func (t *sshTunnel) DialTimeout(network, addr string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return t.DialContext(ctx, network, addr)
+}
+
+// DialContext 배스천 호스트에서 addr로 연결 (ssh.Client.Dial은 컨텍스트를 받지 않으므로 취소되면 결과를 버림)
+func (t *sshTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		conn, err := t.client.Dial(network, addr)
+		done <- result{conn, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, fmt.Errorf("SSH 터널로 %s 연결 실패: %w", addr, r.err)
+		}
+		return pipeConn(r.conn), nil
+	case <-ctx.Done():
+		go func() {
+			if r := <-done; r.conn != nil {
+				r.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// pipeConn SSH 채널을 net.Pipe로 연결해 읽기/쓰기 deadline을 지원하는 연결로 만듦
+// (SSH 채널은 SetDeadline을 지원하지 않는데 드라이버는 타임아웃에 deadline을 사용)
+func pipeConn(channel net.Conn) net.Conn {
+	local, remote := net.Pipe()
+	go func() {
+		io.Copy(channel, remote)
+		channel.Close()
+	}()
+	go func() {
+		io.Copy(remote, channel)
+		remote.Close()
+	}()
+	return &tunnelConn{Conn: local, channel: channel}
+}
+
+// tunnelConn 주소는 SSH 채널의 것을 보여주는 파이프 연결
+type tunnelConn struct {
+	net.Conn
+	channel net.Conn
+}
+
+func (c *tunnelConn) LocalAddr() net.Addr  { return c.channel.LocalAddr() }
+func (c *tunnelConn) RemoteAddr() net.Addr { return c.channel.RemoteAddr() }
+
+// to 드라이버가 넘긴 주소 대신 항상 target으로 연결하는 dialer
+func (t *sshTunnel) to(target string) fixedDialer {
+	return fixedDialer{tunnel: t, target: target}
+}
+
+type fixedDialer struct {
+	tunnel *sshTunnel
+	target string
+}
+
+func (d fixedDialer) DialContext(ctx context.Context, network, _ string) (net.Conn, error) {
+	return d.tunnel.DialContext(ctx, network, d.target)
+}
+
+// Close SSH 연결 종료 (nil이면 아무것도 하지 않음)
+func (t *sshTunnel) Close() error {
+	if t == nil {
+		return nil
+	}
+	var err error
+	if t.client != nil {
+		err = t.client.Close()
+	}
+	if t.agent != nil {
+		t.agent.Close()
+	}
+	return err
+}
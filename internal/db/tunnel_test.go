@@ -0,0 +1,362 @@
+package db
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sql-genius/pkg/models"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const testSSHPassword = "secret"
+
+// testSSHServer direct-tcpip 채널만 처리하는 127.0.0.1의 SSH 서버
+type testSSHServer struct {
+	addr    string
+	hostKey ssh.Signer
+	closed  chan struct{} // 클라이언트 연결이 끝나면 닫힘
+}
+
+func newTestSigner(t *testing.T) (ssh.Signer, ed25519.PrivateKey) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, key
+}
+
+// startSSHServer 비밀번호 testSSHPassword 또는 clientKey로 인증하는 SSH 서버 (연결 하나만 받음)
+func startSSHServer(t *testing.T, clientKey ssh.PublicKey) *testSSHServer {
+	t.Helper()
+	hostKey, _ := newTestSigner(t)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if string(password) == testSSHPassword {
+				return nil, nil
+			}
+			return nil, errors.New("wrong password")
+		},
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if clientKey != nil && string(key.Marshal()) == string(clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unknown key")
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	server := &testSSHServer{addr: ln.Addr().String(), hostKey: hostKey, closed: make(chan struct{})}
+	go func() {
+		defer close(server.closed)
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			sconn, chans, reqs, err := ssh.NewServerConn(conn, config)
+			if err != nil {
+				conn.Close()
+				continue // 인증이나 호스트 키 검증에 실패하면 다음 연결을 기다림
+			}
+			go ssh.DiscardRequests(reqs)
+			go serveChannels(chans)
+			sconn.Wait()
+			return
+		}
+	}()
+	return server
+}
+
+// serveChannels direct-tcpip 채널을 요청한 주소로 전달
+func serveChannels(chans <-chan ssh.NewChannel) {
+	for ch := range chans {
+		if ch.ChannelType() != "direct-tcpip" {
+			ch.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(ch.ExtraData(), &target); err != nil {
+			ch.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		upstream, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			ch.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, reqs, err := ch.Accept()
+		if err != nil {
+			upstream.Close()
+			continue
+		}
+		go ssh.DiscardRequests(reqs)
+		go func() {
+			io.Copy(channel, upstream)
+			channel.Close()
+		}()
+		go func() {
+			io.Copy(upstream, channel)
+			upstream.Close()
+		}()
+	}
+}
+
+// knownHosts 서버 호스트 키를 기록한 known_hosts 파일
+func (s *testSSHServer) knownHosts(t *testing.T, key ssh.PublicKey) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(s.addr)}, key)
+	if err := os.WriteFile(path, []byte(line+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// sshConfig 서버 주소와 known_hosts를 채운 SSH 설정
+func (s *testSSHServer) sshConfig(t *testing.T) *models.SSHConfig {
+	t.Helper()
+	host, port, _ := net.SplitHostPort(s.addr)
+	portNum, _ := strconv.Atoi(port)
+	return &models.SSHConfig{
+		Host:       host,
+		Port:       portNum,
+		User:       "tester",
+		Password:   testSSHPassword,
+		KnownHosts: s.knownHosts(t, s.hostKey.PublicKey()),
+	}
+}
+
+// startEchoServer 받은 데이터를 그대로 돌려주는 TCP 서버
+func startEchoServer(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func echo(t *testing.T, conn net.Conn, msg string) {
+	t.Helper()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if _, err := conn.Write([]byte(msg)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if string(buf) != msg {
+		t.Errorf("echo = %q, want %q", buf, msg)
+	}
+}
+
+func TestTunnelForward(t *testing.T) {
+	server := startSSHServer(t, nil)
+	target := startEchoServer(t)
+
+	tunnel, err := openTunnel(testContext(t), server.sshConfig(t), 5*time.Second)
+	if err != nil {
+		t.Fatalf("openTunnel() error = %v", err)
+	}
+	defer tunnel.Close()
+
+	conn, err := tunnel.DialContext(testContext(t), "tcp", target)
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	defer conn.Close()
+	echo(t, conn, "hello")
+
+	// 드라이버가 넘긴 주소는 무시하고 항상 target으로 연결
+	fixed, err := tunnel.to(target).DialContext(testContext(t), "tcp", "db.invalid:5432")
+	if err != nil {
+		t.Fatalf("fixedDialer.DialContext() error = %v", err)
+	}
+	defer fixed.Close()
+	echo(t, fixed, "again")
+
+	// 파이프 연결이라 드라이버의 읽기 deadline이 동작
+	fixed.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	var netErr net.Error
+	if _, err := fixed.Read(make([]byte, 1)); !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Read() after deadline error = %v, want timeout", err)
+	}
+}
+
+func TestTunnelKeyFile(t *testing.T) {
+	signer, key := newTestSigner(t)
+	server := startSSHServer(t, signer.PublicKey())
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := server.sshConfig(t)
+	config.Password = ""
+	config.KeyFile = keyFile
+	tunnel, err := openTunnel(testContext(t), config, 5*time.Second)
+	if err != nil {
+		t.Fatalf("openTunnel() error = %v", err)
+	}
+	tunnel.Close()
+}
+
+func TestTunnelRejectsHostKey(t *testing.T) {
+	other, _ := newTestSigner(t)
+
+	tests := []struct {
+		name   string
+		config func(t *testing.T, s *testSSHServer) *models.SSHConfig
+		want   string
+	}{
+		{"다른 호스트 키", func(t *testing.T, s *testSSHServer) *models.SSHConfig {
+			config := s.sshConfig(t)
+			config.KnownHosts = s.knownHosts(t, other.PublicKey())
+			return config
+		}, "key mismatch"},
+		{"known_hosts에 없는 호스트", func(t *testing.T, s *testSSHServer) *models.SSHConfig {
+			config := s.sshConfig(t)
+			config.KnownHosts = filepath.Join(t.TempDir(), "empty")
+			os.WriteFile(config.KnownHosts, nil, 0o600)
+			return config
+		}, "key is unknown"},
+		{"잘못된 비밀번호", func(t *testing.T, s *testSSHServer) *models.SSHConfig {
+			config := s.sshConfig(t)
+			config.Password = "wrong"
+			return config
+		}, "unable to authenticate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startSSHServer(t, nil)
+			tunnel, err := openTunnel(testContext(t), tt.config(t, server), 5*time.Second)
+			if err == nil {
+				tunnel.Close()
+				t.Fatal("openTunnel() error = nil")
+			}
+			if !strings.Contains(err.Error(), "SSH 인증 실패") || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("openTunnel() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTunnelConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *models.SSHConfig
+		want   string
+	}{
+		{"호스트 없음", &models.SSHConfig{User: "u", Password: "p"}, "host와 user가 필요합니다"},
+		{"인증 방법 없음", &models.SSHConfig{Host: "127.0.0.1", User: "u"}, "SSH 인증 방법이 없습니다"},
+		{"없는 키 파일", &models.SSHConfig{Host: "127.0.0.1", User: "u", KeyFile: "/nonexistent/id_rsa"}, "SSH 키 파일 읽기 실패"},
+		{"없는 known_hosts", &models.SSHConfig{Host: "127.0.0.1", User: "u", Password: "p", KnownHosts: "/nonexistent/known_hosts"}, "known_hosts 읽기 실패"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := openTunnel(testContext(t), tt.config, time.Second)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("openTunnel() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if tunnel, err := openTunnel(testContext(t), nil, time.Second); tunnel != nil || err != nil {
+		t.Errorf("openTunnel(nil) = %v, %v, want nil, nil", tunnel, err)
+	}
+}
+
+func TestTunnelClose(t *testing.T) {
+	server := startSSHServer(t, nil)
+	target := startEchoServer(t)
+
+	tunnel, err := openTunnel(testContext(t), server.sshConfig(t), 5*time.Second)
+	if err != nil {
+		t.Fatalf("openTunnel() error = %v", err)
+	}
+	conn, err := tunnel.DialContext(testContext(t), "tcp", target)
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	echo(t, conn, "before close")
+
+	if err := tunnel.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// 열려 있던 채널도 끊기고, 서버의 SSH 연결도 끝남
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("Read() after Close error = %v, want EOF", err)
+	}
+	select {
+	case <-server.closed:
+	case <-time.After(5 * time.Second):
+		t.Error("SSH server connection still open after Close")
+	}
+
+	if _, err := tunnel.DialContext(testContext(t), "tcp", target); err == nil {
+		t.Error("DialContext() after Close error = nil")
+	}
+
+	var nilTunnel *sshTunnel
+	if err := nilTunnel.Close(); err != nil {
+		t.Errorf("nil Close() error = %v", err)
+	}
+}
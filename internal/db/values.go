@@ -0,0 +1,230 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sql-genius/pkg/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueKind 결과 값의 JSON 인코딩 종류
+type ValueKind string
+
+const (
+	KindInteger   ValueKind = "integer"   // 숫자
+	KindFloat     ValueKind = "float"     // 숫자 (NaN, ±무한대는 "NaN", "Infinity", "-Infinity")
+	KindDecimal   ValueKind = "decimal"   // 정밀도를 잃지 않도록 문자열 ("12.50")
+	KindBoolean   ValueKind = "boolean"   // true/false
+	KindString    ValueKind = "string"    // 문자열
+	KindTimestamp ValueKind = "timestamp" // RFC 3339 문자열 (시간대 포함)
+	KindDate      ValueKind = "date"      // "2006-01-02"
+	KindTime      ValueKind = "time"      // "15:04:05" (TIMETZ는 "15:04:05+09:00")
+	KindUUID      ValueKind = "uuid"      // 소문자 하이픈 형식
+	KindBinary    ValueKind = "binary"    // base64 문자열
+	KindJSON      ValueKind = "json"      // JSON 텍스트 문자열
+)
+
+// ColumnType 결과 컬럼 메타데이터 (드라이버가 알려주지 않는 값은 생략)
+type ColumnType struct {
+	Name      string    `json:"name"`
+	DBType    string    `json:"db_type"`        // 드라이버가 알려준 데이터베이스 타입 이름
+	Kind      ValueKind `json:"kind,omitempty"` // 행 값의 인코딩 종류 (타입을 모르면 생략)
+	Nullable  *bool     `json:"nullable,omitempty"`
+	Precision *int64    `json:"precision,omitempty"`
+	Scale     *int64    `json:"scale,omitempty"`
+	Length    *int64    `json:"length,omitempty"`
+}
+
+// columnTypes rows.ColumnTypes()를 ColumnType으로 변환
+func columnTypes(types []*sql.ColumnType, dbType models.DBType) []ColumnType {
+	result := make([]ColumnType, len(types))
+	for i, t := range types {
+		col := ColumnType{Name: t.Name(), DBType: t.DatabaseTypeName()}
+		if nullable, ok := t.Nullable(); ok {
+			col.Nullable = &nullable
+		}
+		if precision, scale, ok := t.DecimalSize(); ok {
+			col.Precision, col.Scale = &precision, &scale
+		}
+		if length, ok := t.Length(); ok && length != math.MaxInt64 { // MaxInt64: 길이 제한 없음
+			col.Length = &length
+		}
+		col.Kind = valueKind(dbType, col)
+		result[i] = col
+	}
+	return result
+}
+
+// valueKind 데이터베이스 타입 이름으로 값 인코딩 종류 결정
+// 타입 이름은 드라이버마다 다름 (SQLite는 선언된 타입, Oracle은 TIMESTAMPTZ·OCIBlobLocator 같은 TNS 타입 이름)
+func valueKind(dbType models.DBType, col ColumnType) ValueKind {
+	name := strings.ToUpper(strings.TrimSpace(col.DBType))
+	if i := strings.IndexAny(name, "( "); i > 0 && !strings.HasPrefix(name, "DOUBLE") {
+		name = name[:i]
+	}
+
+	switch name {
+	case "":
+		return ""
+	case "UUID", "UNIQUEIDENTIFIER":
+		return KindUUID
+	case "BYTEA", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY", "IMAGE",
+		"RAW", "LONGRAW", "VARRAW", "OCIBLOBLOCATOR", "OCIFILELOCATOR", "BFILE":
+		return KindBinary
+	case "BIT":
+		if dbType == models.SQLServer {
+			return KindBoolean
+		}
+		return KindBinary // MySQL BIT(n)
+	case "BOOL", "BOOLEAN":
+		return KindBoolean
+	case "DECIMAL", "NUMERIC", "MONEY", "SMALLMONEY", "DEC":
+		return KindDecimal
+	case "NUMBER":
+		// Oracle NUMBER(p,0)은 정수
+		if col.Scale != nil && col.Precision != nil && *col.Scale == 0 && *col.Precision > 0 && *col.Precision <= 18 {
+			return KindInteger
+		}
+		return KindDecimal
+	case "INT", "INTEGER", "BIGINT", "SMALLINT", "TINYINT", "MEDIUMINT", "INT2", "INT4", "INT8",
+		"SERIAL", "BIGSERIAL", "SMALLSERIAL", "UNSIGNED", "YEAR", "BINTEGER", "UINT", "OID":
+		return KindInteger
+	case "FLOAT", "FLOAT4", "FLOAT8", "REAL", "DOUBLE", "DOUBLE PRECISION",
+		"BFLOAT", "BDOUBLE", "IBFLOAT", "IBDOUBLE", "BINARY_FLOAT", "BINARY_DOUBLE":
+		return KindFloat
+	case "DATE":
+		if dbType == models.Oracle {
+			return KindTimestamp // Oracle DATE는 시각 포함
+		}
+		return KindDate
+	case "TIME", "TIMETZ":
+		return KindTime
+	case "JSON", "JSONB":
+		return KindJSON
+	}
+
+	if strings.HasPrefix(name, "TIMESTAMP") || strings.HasPrefix(name, "DATETIME") || name == "SMALLDATETIME" {
+		return KindTimestamp
+	}
+	return KindString
+}
+
+// encodeValue 드라이버가 반환한 값을 컬럼의 인코딩 종류에 맞게 정규화
+// 드라이버마다 같은 타입을 []byte, string, 숫자로 다르게 주므로 JSON 표현을 통일합니다.
+// JSON에 쓸 수 없는 NaN, ±무한대 실수는 종류와 관계없이 문자열로 바꿉니다.
+func encodeValue(v interface{}, col ColumnType, dbType models.DBType) interface{} {
+	switch n := v.(type) {
+	case nil:
+		return nil
+	case float64:
+		if s, ok := nonFinite(n); ok {
+			return s
+		}
+	case float32:
+		if s, ok := nonFinite(float64(n)); ok {
+			return s
+		}
+	}
+
+	switch col.Kind {
+	case KindBinary:
+		if s, ok := v.(string); ok {
+			return []byte(s)
+		}
+		return v // []byte는 JSON에서 base64
+	case KindUUID:
+		if b, ok := v.([]byte); ok && len(b) == 16 {
+			return formatUUID(b, dbType == models.SQLServer)
+		}
+	case KindDecimal:
+		switch n := v.(type) {
+		case float64:
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		case float32:
+			return strconv.FormatFloat(float64(n), 'f', -1, 32)
+		case int64:
+			return strconv.FormatInt(n, 10)
+		case []byte:
+			return string(n)
+		case string:
+			return n
+		}
+		return fmt.Sprint(v)
+	case KindInteger:
+		if b, ok := v.([]byte); ok {
+			if n, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+				return n
+			}
+		}
+	case KindFloat:
+		if b, ok := v.([]byte); ok {
+			if f, err := strconv.ParseFloat(string(b), 64); err == nil {
+				if s, ok := nonFinite(f); ok {
+					return s
+				}
+				return f
+			}
+		}
+	case KindBoolean:
+		switch b := v.(type) {
+		case int64:
+			return b != 0
+		case []byte:
+			if len(b) == 1 && (b[0] == 0 || b[0] == 1) {
+				return b[0] == 1
+			}
+		}
+	case KindDate:
+		if t, ok := v.(time.Time); ok {
+			return t.Format("2006-01-02")
+		}
+	case KindTime:
+		if t, ok := v.(time.Time); ok {
+			if hasTimeZone(col.DBType) {
+				return t.Format("15:04:05.999999999Z07:00")
+			}
+			return t.Format("15:04:05.999999999")
+		}
+	}
+
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
+
+// nonFinite NaN, ±무한대를 PostgreSQL 표기 문자열로 (유한한 값이면 false)
+func nonFinite(f float64) (string, bool) {
+	switch {
+	case math.IsNaN(f):
+		return "NaN", true
+	case math.IsInf(f, 1):
+		return "Infinity", true
+	case math.IsInf(f, -1):
+		return "-Infinity", true
+	}
+	return "", false
+}
+
+// hasTimeZone 시간대 오프셋을 가진 시각 타입인지 (PostgreSQL TIMETZ, TIME WITH TIME ZONE)
+func hasTimeZone(dbType string) bool {
+	name := strings.ToUpper(dbType)
+	return name == "TIMETZ" || strings.Contains(name, "WITH TIME ZONE")
+}
+
+// formatUUID 16바이트 UUID를 문자열로 (SQL Server는 앞 세 필드가 리틀 엔디언)
+func formatUUID(b []byte, mixedEndian bool) string {
+	u := make([]byte, 16)
+	copy(u, b)
+	if mixedEndian {
+		u[0], u[1], u[2], u[3] = u[3], u[2], u[1], u[0]
+		u[4], u[5] = u[5], u[4]
+		u[6], u[7] = u[7], u[6]
+	}
+	s := hex.EncodeToString(u)
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"sql-genius/pkg/models"
+	"testing"
+	"time"
+)
+
+func TestEncodeValue(t *testing.T) {
+	seoul := time.FixedZone("", 9*60*60)
+	tests := []struct {
+		name string
+		v    interface{}
+		col  ColumnType
+		want interface{}
+	}{
+		{"float NaN", math.NaN(), ColumnType{DBType: "FLOAT8", Kind: KindFloat}, "NaN"},
+		{"float +Inf", math.Inf(1), ColumnType{DBType: "FLOAT8", Kind: KindFloat}, "Infinity"},
+		{"float32 -Inf", float32(math.Inf(-1)), ColumnType{DBType: "FLOAT4", Kind: KindFloat}, "-Infinity"},
+		{"텍스트 float NaN", []byte("NaN"), ColumnType{DBType: "DOUBLE", Kind: KindFloat}, "NaN"},
+		{"텍스트 float", []byte("1.5"), ColumnType{DBType: "DOUBLE", Kind: KindFloat}, 1.5},
+		{"유한한 float", 2.25, ColumnType{DBType: "FLOAT8", Kind: KindFloat}, 2.25},
+		{"decimal의 float Inf", math.Inf(1), ColumnType{DBType: "NUMERIC", Kind: KindDecimal}, "Infinity"},
+		{"종류를 모르는 NaN", math.NaN(), ColumnType{}, "NaN"},
+		{"time", time.Date(0, 1, 1, 10, 20, 30, 0, time.UTC), ColumnType{DBType: "TIME", Kind: KindTime}, "10:20:30"},
+		{"timetz", time.Date(0, 1, 1, 10, 20, 30, 500000000, seoul), ColumnType{DBType: "TIMETZ", Kind: KindTime}, "10:20:30.5+09:00"},
+		{"timetz UTC", time.Date(0, 1, 1, 10, 20, 30, 0, time.UTC), ColumnType{DBType: "TIMETZ", Kind: KindTime}, "10:20:30Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := encodeValue(tt.v, tt.col, models.PostgreSQL)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteNonFiniteFloats(t *testing.T) {
+	conn := newSQLite(t, "")
+	result, err := conn.Execute(context.Background(), "SELECT 1e999 AS pos, -1e999 AS neg, 0.5 AS half", ExecOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := []interface{}{"Infinity", "-Infinity", 0.5}
+	if !reflect.DeepEqual(result.Rows[0], want) {
+		t.Errorf("row = %#v, want %#v", result.Rows[0], want)
+	}
+	if _, err := json.Marshal(result); err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+	}
+}
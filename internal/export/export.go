@@ -0,0 +1,326 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sql-genius/internal/db"
+	"sql-genius/pkg/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format 내보내기 형식
+type Format string
+
+const (
+	CSV      Format = "csv"
+	TSV      Format = "tsv"
+	NDJSON   Format = "ndjson"
+	Markdown Format = "markdown"
+	XLSX     Format = "xlsx"
+	SQL      Format = "sql"
+)
+
+// formatAliases 형식 이름과 확장자 별칭
+var formatAliases = map[string]Format{
+	"csv":      CSV,
+	"tsv":      TSV,
+	"ndjson":   NDJSON,
+	"jsonl":    NDJSON,
+	"markdown": Markdown,
+	"md":       Markdown,
+	"xlsx":     XLSX,
+	"sql":      SQL,
+}
+
+// ParseFormat 형식 이름 변환 (csv, tsv, ndjson/jsonl, markdown/md, xlsx, sql)
+func ParseFormat(s string) (Format, error) {
+	if f, ok := formatAliases[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return f, nil
+	}
+	return "", fmt.Errorf("지원하지 않는 내보내기 형식: %s (csv, tsv, ndjson, markdown, xlsx, sql)", s)
+}
+
+// FormatFromPath 파일 확장자로 형식 추정
+func FormatFromPath(path string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "", fmt.Errorf("파일 확장자로 형식을 알 수 없습니다: %s", path)
+	}
+	return ParseFormat(ext)
+}
+
+// ContentType HTTP 응답 Content-Type
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case TSV:
+		return "text/tab-separated-values; charset=utf-8"
+	case NDJSON:
+		return "application/x-ndjson"
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case SQL:
+		return "application/sql; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+// Extension 파일 확장자
+func (f Format) Extension() string {
+	if f == Markdown {
+		return "md"
+	}
+	return string(f)
+}
+
+// Options 내보내기 옵션
+type Options struct {
+	DBType models.DBType // SQL INSERT 문 방언
+	Table  string        // SQL INSERT 대상 테이블 (기본 result)
+}
+
+// Writer 결과 행을 하나씩 받아 형식에 맞게 씀
+// 행을 메모리에 모으지 않으므로 커서와 함께 쓰면 큰 결과도 내보낼 수 있습니다.
+type Writer interface {
+	// WriteHeader 컬럼 정보 (첫 행 전에 한 번)
+	WriteHeader(columns []db.ColumnType) error
+
+	// WriteRow 행 하나
+	WriteRow(row []interface{}) error
+
+	// Close 남은 내용을 쓰고 마무리 (XLSX는 여기서 파일이 완성됨)
+	Close() error
+}
+
+// NewWriter 형식별 Writer 생성
+func NewWriter(w io.Writer, format Format, opts Options) (Writer, error) {
+	switch format {
+	case CSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case TSV:
+		cw := csv.NewWriter(w)
+		cw.Comma = '\t'
+		return &csvWriter{w: cw}, nil
+	case NDJSON:
+		return &ndjsonWriter{w: w}, nil
+	case Markdown:
+		return &markdownWriter{w: w}, nil
+	case XLSX:
+		return newXLSXWriter(w)
+	case SQL:
+		return newSQLWriter(w, opts), nil
+	}
+	return nil, fmt.Errorf("지원하지 않는 내보내기 형식: %s", format)
+}
+
+// Write QueryResult의 첫 결과 집합을 내보냄
+func Write(w io.Writer, format Format, result *db.QueryResult, opts Options) error {
+	ew, err := NewWriter(w, format, opts)
+	if err != nil {
+		return err
+	}
+	if err := ew.WriteHeader(Columns(result)); err != nil {
+		return err
+	}
+	for _, row := range result.Rows {
+		if err := ew.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return ew.Close()
+}
+
+// Columns 결과의 컬럼 정보 (타입 정보가 없으면 이름만)
+func Columns(result *db.QueryResult) []db.ColumnType {
+	if len(result.ColumnTypes) == len(result.Columns) {
+		return result.ColumnTypes
+	}
+	columns := make([]db.ColumnType, len(result.Columns))
+	for i, name := range result.Columns {
+		columns[i] = db.ColumnType{Name: name}
+	}
+	return columns
+}
+
+// textValue 텍스트 형식(CSV, TSV, Markdown, XLSX)의 셀 값 (바이너리는 0x 16진수)
+func textValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// nonFiniteName NaN, ±Inf인 float 값과 db 패키지가 float을 바꿔 둔 문자열("NaN", "Infinity", "-Infinity")의 이름
+// 문자열은 float, DECIMAL 컬럼일 때만 봅니다 (텍스트 컬럼의 "NaN"은 그대로 문자열).
+func nonFiniteName(v interface{}, kind db.ValueKind) (string, bool) {
+	var f float64
+	switch v := v.(type) {
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	case string:
+		if kind != db.KindFloat && kind != db.KindDecimal {
+			return "", false
+		}
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return "", false
+		}
+		f = parsed
+	default:
+		return "", false
+	}
+
+	switch {
+	case math.IsNaN(f):
+		return "NaN", true
+	case math.IsInf(f, 1):
+		return "Infinity", true
+	case math.IsInf(f, -1):
+		return "-Infinity", true
+	}
+	return "", false
+}
+
+// isFiniteNumber DECIMAL 문자열을 숫자로 쓸 수 있는지 (ParseFloat는 NaN, Inf도 받아들이므로 유한한 값만)
+func isFiniteNumber(s string) bool {
+	f, err := strconv.ParseFloat(s, 64)
+	return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// csvWriter CSV, TSV (NULL은 빈 칸)
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) WriteHeader(columns []db.ColumnType) error {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	return c.w.Write(names)
+}
+
+func (c *csvWriter) WriteRow(row []interface{}) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		record[i] = textValue(v)
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonWriter 행마다 컬럼 순서를 유지한 JSON 객체 한 줄 (API와 같은 값 인코딩)
+type ndjsonWriter struct {
+	w    io.Writer
+	keys [][]byte
+}
+
+func (n *ndjsonWriter) WriteHeader(columns []db.ColumnType) error {
+	n.keys = make([][]byte, len(columns))
+	for i, col := range columns {
+		key, err := json.Marshal(col.Name)
+		if err != nil {
+			return err
+		}
+		n.keys[i] = key
+	}
+	return nil
+}
+
+func (n *ndjsonWriter) WriteRow(row []interface{}) error {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, v := range row {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		sb.Write(n.keys[i])
+		sb.WriteByte(':')
+		sb.Write(value)
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(n.w, sb.String())
+	return err
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+// markdownWriter Markdown 표 (NULL은 NULL로 표시)
+type markdownWriter struct {
+	w io.Writer
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func (m *markdownWriter) WriteHeader(columns []db.ColumnType) error {
+	var head, sep strings.Builder
+	head.WriteString("|")
+	sep.WriteString("|")
+	for _, col := range columns {
+		head.WriteString(" " + markdownEscaper.Replace(col.Name) + " |")
+		if isNumeric(col.Kind) {
+			sep.WriteString(" ---: |")
+		} else {
+			sep.WriteString(" --- |")
+		}
+	}
+	_, err := fmt.Fprintf(m.w, "%s\n%s\n", head.String(), sep.String())
+	return err
+}
+
+func (m *markdownWriter) WriteRow(row []interface{}) error {
+	var sb strings.Builder
+	sb.WriteString("|")
+	for _, v := range row {
+		cell := "NULL"
+		if v != nil {
+			cell = markdownEscaper.Replace(textValue(v))
+		}
+		sb.WriteString(" " + cell + " |")
+	}
+	sb.WriteString("\n")
+	_, err := io.WriteString(m.w, sb.String())
+	return err
+}
+
+func (m *markdownWriter) Close() error {
+	return nil
+}
+
+func isNumeric(kind db.ValueKind) bool {
+	return kind == db.KindInteger || kind == db.KindFloat || kind == db.KindDecimal
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bufio"
+	"math"
+	"sql-genius/internal/db"
+	"sql-genius/pkg/models"
+	"strings"
+	"testing"
+)
+
+func TestSQLLiteral(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		v      interface{}
+		kind   db.ValueKind
+		want   string
+	}{
+		{"decimal", models.PostgreSQL, "12.50", db.KindDecimal, "12.50"},
+		{"decimal NaN 문자열", models.MySQL, "NaN", db.KindDecimal, "NULL"},
+		{"decimal Inf 문자열", models.MySQL, "Inf", db.KindDecimal, "NULL"},
+		{"decimal 숫자가 아닌 문자열", models.MySQL, "12,5", db.KindDecimal, "'12,5'"},
+		{"float NaN", models.PostgreSQL, math.NaN(), db.KindFloat, "'NaN'"},
+		{"float +Inf", models.SQLServer, math.Inf(1), db.KindFloat, "NULL"},
+		{"float Infinity 문자열", models.PostgreSQL, "Infinity", db.KindFloat, "'Infinity'"},
+		{"float -Infinity 문자열 Oracle", models.Oracle, "-Infinity", db.KindFloat, "-BINARY_DOUBLE_INFINITY"},
+		{"float NaN 문자열 Oracle", models.Oracle, "NaN", db.KindFloat, "BINARY_DOUBLE_NAN"},
+		{"float NaN 문자열 SQLite", models.SQLite, "NaN", db.KindFloat, "NULL"},
+		{"텍스트 NaN", models.MySQL, "NaN", db.KindString, "'NaN'"},
+		{"유한한 float", models.MySQL, 1.5, db.KindFloat, "1.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSQLWriter(nil, Options{DBType: tt.dbType})
+			if got := s.literal(tt.v, tt.kind); got != tt.want {
+				t.Errorf("literal(%v) = %s, want %s", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXLSXCell(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		kind db.ValueKind
+		want string
+	}{
+		{"decimal", "12.50", db.KindDecimal, `<c><v>12.50</v></c>`},
+		{"decimal NaN 문자열", "NaN", db.KindDecimal, `<c t="inlineStr"><is><t xml:space="preserve">NaN</t></is></c>`},
+		{"decimal Infinity 문자열", "Infinity", db.KindDecimal, `<c t="inlineStr"><is><t xml:space="preserve">Infinity</t></is></c>`},
+		{"float -Inf", math.Inf(-1), db.KindFloat, `<c t="inlineStr"><is><t xml:space="preserve">-Infinity</t></is></c>`},
+		{"float NaN", math.NaN(), db.KindFloat, `<c t="inlineStr"><is><t xml:space="preserve">NaN</t></is></c>`},
+		{"유한한 float", 2.25, db.KindFloat, `<c><v>2.25</v></c>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			x := &xlsxWriter{sheet: bufio.NewWriter(&buf)}
+			x.writeCell(tt.v, tt.kind)
+			x.sheet.Flush()
+			if buf.String() != tt.want {
+				t.Errorf("writeCell(%v) = %s, want %s", tt.v, buf.String(), tt.want)
+			}
+		})
+	}
+}
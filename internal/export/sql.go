@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sql-genius/internal/db"
+	"sql-genius/pkg/models"
+	"strings"
+	"time"
+)
+
+// sqlWriter 행마다 INSERT INTO ... VALUES 문 한 줄 (연결의 방언으로 리터럴 작성)
+// Oracle은 여러 행 VALUES를 지원하지 않으므로 모든 방언에서 행마다 한 문장을 씁니다.
+type sqlWriter struct {
+	w      *bufio.Writer
+	dbType models.DBType
+	table  string
+	prefix string
+	kinds  []db.ValueKind
+}
+
+func newSQLWriter(w io.Writer, opts Options) *sqlWriter {
+	table := opts.Table
+	if table == "" {
+		table = "result"
+	}
+	dbType := opts.DBType
+	if dbType == "" {
+		dbType = models.PostgreSQL // 표준 SQL에 가장 가까운 문법
+	}
+	return &sqlWriter{w: bufio.NewWriter(w), dbType: dbType, table: table}
+}
+
+func (s *sqlWriter) WriteHeader(columns []db.ColumnType) error {
+	names := make([]string, len(columns))
+	s.kinds = make([]db.ValueKind, len(columns))
+	for i, col := range columns {
+		names[i] = db.QuoteIdent(s.dbType, col.Name)
+		s.kinds[i] = col.Kind
+	}
+	s.prefix = fmt.Sprintf("INSERT INTO %s (%s) VALUES (", s.tableRef(), strings.Join(names, ", "))
+	return nil
+}
+
+// tableRef schema.table 형식이면 부분마다 인용
+func (s *sqlWriter) tableRef() string {
+	parts := strings.Split(s.table, ".")
+	for i, part := range parts {
+		parts[i] = db.QuoteIdent(s.dbType, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func (s *sqlWriter) WriteRow(row []interface{}) error {
+	s.w.WriteString(s.prefix)
+	for i, v := range row {
+		if i > 0 {
+			s.w.WriteString(", ")
+		}
+		var kind db.ValueKind
+		if i < len(s.kinds) {
+			kind = s.kinds[i]
+		}
+		s.w.WriteString(s.literal(v, kind))
+	}
+	_, err := s.w.WriteString(");\n")
+	return err
+}
+
+func (s *sqlWriter) Close() error {
+	return s.w.Flush()
+}
+
+// literal 값을 방언별 SQL 리터럴로
+func (s *sqlWriter) literal(v interface{}, kind db.ValueKind) string {
+	if name, ok := nonFiniteName(v, kind); ok {
+		return s.nonFiniteLiteral(name)
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		switch s.dbType {
+		case models.SQLServer, models.Oracle:
+			if v {
+				return "1"
+			}
+			return "0"
+		}
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int, int32, int64, uint32, uint64, float32, float64:
+		return textValue(v)
+	case []byte:
+		return s.binaryLiteral(v)
+	case time.Time:
+		return s.timeLiteral(v)
+	case string:
+		// DECIMAL 문자열은 따옴표 없이 (유한한 숫자로 해석되는 값만)
+		if kind == db.KindDecimal && isFiniteNumber(v) {
+			return v
+		}
+		return s.stringLiteral(v)
+	}
+	return s.stringLiteral(fmt.Sprint(v))
+}
+
+// nonFiniteLiteral NaN, Infinity, -Infinity
+// PostgreSQL은 float, numeric 모두 문자열로 받고 Oracle은 BINARY_DOUBLE 상수가 있지만
+// MySQL, SQL Server, SQLite에는 해당 값이 없어 NULL로 씁니다.
+func (s *sqlWriter) nonFiniteLiteral(name string) string {
+	switch s.dbType {
+	case models.PostgreSQL:
+		return "'" + name + "'"
+	case models.Oracle:
+		switch name {
+		case "NaN":
+			return "BINARY_DOUBLE_NAN"
+		case "Infinity":
+			return "BINARY_DOUBLE_INFINITY"
+		}
+		return "-BINARY_DOUBLE_INFINITY"
+	}
+	return "NULL"
+}
+
+func (s *sqlWriter) stringLiteral(v string) string {
+	quoted := "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	switch s.dbType {
+	case models.SQLServer:
+		return "N" + quoted
+	case models.MySQL:
+		// MySQL은 기본 설정에서 백슬래시를 이스케이프 문자로 해석
+		return strings.ReplaceAll(quoted, `\`, `\\`)
+	}
+	return quoted
+}
+
+func (s *sqlWriter) binaryLiteral(v []byte) string {
+	h := hex.EncodeToString(v)
+	switch s.dbType {
+	case models.PostgreSQL:
+		return "'\\x" + h + "'::bytea"
+	case models.SQLServer:
+		return "0x" + h
+	case models.Oracle:
+		return "HEXTORAW('" + h + "')"
+	}
+	return "X'" + h + "'"
+}
+
+func (s *sqlWriter) timeLiteral(t time.Time) string {
+	switch s.dbType {
+	case models.PostgreSQL:
+		return "'" + t.Format("2006-01-02 15:04:05.999999Z07:00") + "'"
+	case models.Oracle:
+		return "TIMESTAMP '" + t.Format("2006-01-02 15:04:05.999999999 -07:00") + "'"
+	case models.SQLServer:
+		return "'" + t.Format("2006-01-02T15:04:05.9999999") + "'"
+	}
+	return "'" + t.Format("2006-01-02 15:04:05.999999") + "'"
+}
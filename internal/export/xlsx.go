@@ -0,0 +1,141 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sql-genius/internal/db"
+	"strings"
+)
+
+const (
+	// xlsxMaxRows Excel 시트 최대 행 수 (머리글 포함)
+	xlsxMaxRows = 1048576
+	// xlsxMaxCellText Excel 셀 최대 문자 수
+	xlsxMaxCellText = 32767
+)
+
+// xlsxParts 시트 외의 고정 파일 (최소 구성의 SpreadsheetML 패키지)
+var xlsxParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Result" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// xlsxWriter 시트 하나짜리 XLSX를 zip 스트림으로 바로 씀
+// 숫자는 숫자 셀, 나머지는 인라인 문자열 셀 (공유 문자열 표를 만들지 않아 메모리를 쓰지 않음)
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	kinds []db.ValueKind
+	rows  int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	// 머리글 행 고정
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><sheetData>`)
+	return &xlsxWriter{zw: zw, sheet: sheet}, nil
+}
+
+func (x *xlsxWriter) WriteHeader(columns []db.ColumnType) error {
+	x.kinds = make([]db.ValueKind, len(columns))
+	row := make([]interface{}, len(columns))
+	for i, col := range columns {
+		x.kinds[i] = col.Kind
+		row[i] = col.Name
+	}
+	return x.writeRow(row, true)
+}
+
+func (x *xlsxWriter) WriteRow(row []interface{}) error {
+	return x.writeRow(row, false)
+}
+
+func (x *xlsxWriter) writeRow(row []interface{}, header bool) error {
+	if x.rows >= xlsxMaxRows {
+		return fmt.Errorf("XLSX 시트 최대 행 수(%d)를 넘었습니다", xlsxMaxRows)
+	}
+	x.rows++
+
+	x.sheet.WriteString("<row>")
+	for i, v := range row {
+		var kind db.ValueKind
+		if !header && i < len(x.kinds) {
+			kind = x.kinds[i]
+		}
+		x.writeCell(v, kind)
+	}
+	_, err := x.sheet.WriteString("</row>")
+	return err
+}
+
+func (x *xlsxWriter) writeCell(v interface{}, kind db.ValueKind) {
+	// Excel 숫자 셀에는 NaN, Infinity가 없으므로 이름을 문자열 셀로
+	if name, ok := nonFiniteName(v, kind); ok {
+		v = name
+	}
+
+	switch v := v.(type) {
+	case nil:
+		x.sheet.WriteString("<c/>")
+		return
+	case bool:
+		b := "0"
+		if v {
+			b = "1"
+		}
+		x.sheet.WriteString(`<c t="b"><v>` + b + `</v></c>`)
+		return
+	case int, int32, int64, uint32, uint64, float32, float64:
+		x.sheet.WriteString(`<c><v>` + textValue(v) + `</v></c>`)
+		return
+	case string:
+		// DECIMAL은 문자열로 오지만 Excel에서는 숫자로 다룰 수 있도록
+		if kind == db.KindDecimal && isFiniteNumber(v) {
+			x.sheet.WriteString(`<c><v>` + v + `</v></c>`)
+			return
+		}
+	}
+
+	text := textValue(v)
+	if runes := []rune(text); len(runes) > xlsxMaxCellText {
+		text = string(runes[:xlsxMaxCellText])
+	}
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+	x.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	x.sheet.WriteString(escaped.String())
+	x.sheet.WriteString(`</t></is></c>`)
+}
+
+func (x *xlsxWriter) Close() error {
+	x.sheet.WriteString("</sheetData></worksheet>")
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zw.Close()
+}
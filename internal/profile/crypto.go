@@ -0,0 +1,258 @@
+package profile
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// MasterKeyEnv 비밀번호 암호화 키를 만들 마스터 키 환경변수
+	MasterKeyEnv = "SQL_GENIUS_MASTER_KEY"
+
+	keySourceMaster  = "master-key"
+	keySourceKeyFile = "keyfile"
+
+	// encPrefix 암호화된 값 앞에 붙는 표시 (없으면 평문으로 보고 다음 저장 때 암호화)
+	encPrefix = "enc:"
+	// checkText Check 필드에 암호화해 두는 값 (키가 맞는지 확인)
+	checkText = "sql-genius"
+
+	// scrypt 매개변수 (약 32MB, 수십 ms)
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// cipherKey 프로필 값 암호화
+type cipherKey struct {
+	aead cipher.AEAD
+}
+
+// newKey 새 파일의 키 (마스터 키 환경변수가 있으면 마스터 키, 없으면 키 파일 생성)
+func (s *Store) newKey(f *file) (*cipherKey, error) {
+	if os.Getenv(MasterKeyEnv) != "" {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		f.KeySource = keySourceMaster
+		f.Salt = base64.StdEncoding.EncodeToString(salt)
+	} else {
+		f.KeySource = keySourceKeyFile
+	}
+
+	c, err := s.deriveKey(f, true)
+	if err != nil {
+		return nil, err
+	}
+	if f.Check, err = c.encrypt(checkText); err != nil {
+		return nil, err
+	}
+	s.cached = newCachedKey(f, c, nil)
+	return c, nil
+}
+
+// cachedKey 마지막으로 확인한 키와 그 키를 만든 조건
+// scrypt는 호출마다 약 32MB를 쓰므로 프로필을 읽을 때마다 다시 계산하지 않습니다.
+type cachedKey struct {
+	source string
+	salt   string
+	check  string
+	master string // 키를 만들 때의 마스터 키 환경변수 값
+	key    *cipherKey
+	err    error // 마스터 키가 맞지 않는 등 scrypt 후 실패한 결과 (다시 계산하지 않음)
+}
+
+func newCachedKey(f *file, c *cipherKey, err error) *cachedKey {
+	return &cachedKey{source: f.KeySource, salt: f.Salt, check: f.Check, master: os.Getenv(MasterKeyEnv), key: c, err: err}
+}
+
+// matches 같은 파일 헤더와 마스터 키로 만든 키인지
+func (k *cachedKey) matches(f *file) bool {
+	return k != nil && k.source == f.KeySource && k.salt == f.Salt && k.check == f.Check &&
+		(k.source != keySourceMaster || k.master == os.Getenv(MasterKeyEnv))
+}
+
+// key 파일에 기록된 방식으로 키를 만들고 Check로 확인 (같은 파일 헤더면 캐시한 키 사용)
+// 키 파일은 만들지 않으므로 읽기 경로에서 써도 됩니다.
+func (s *Store) key(f *file) (*cipherKey, error) {
+	if s.cached.matches(f) {
+		return s.cached.key, s.cached.err
+	}
+	c, err := s.deriveKey(f, false)
+	if err == nil || f.KeySource == keySourceMaster {
+		s.cached = newCachedKey(f, c, err)
+	}
+	return c, err
+}
+
+// deriveKey 키를 만들고 Check가 있으면 확인 (create면 키 파일이 없을 때 생성)
+func (s *Store) deriveKey(f *file, create bool) (*cipherKey, error) {
+	var (
+		raw []byte
+		err error
+	)
+	switch f.KeySource {
+	case keySourceMaster:
+		raw, err = masterKey(f.Salt)
+	case keySourceKeyFile:
+		raw, err = s.fileKey(create)
+	default:
+		return nil, fmt.Errorf("알 수 없는 키 방식: %s", f.KeySource)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	c := &cipherKey{aead: aead}
+
+	if f.Check != "" {
+		if text, err := c.decrypt(f.Check); err != nil || text != checkText {
+			if f.KeySource == keySourceMaster {
+				return nil, fmt.Errorf("%s 값이 프로필 파일을 암호화한 마스터 키와 다릅니다", MasterKeyEnv)
+			}
+			return nil, fmt.Errorf("키 파일이 프로필 파일과 맞지 않습니다: %s", s.keyPath)
+		}
+	}
+	return c, nil
+}
+
+// masterKey 마스터 키 환경변수에서 scrypt로 256비트 키 생성
+func masterKey(salt string) ([]byte, error) {
+	master := os.Getenv(MasterKeyEnv)
+	if master == "" {
+		return nil, fmt.Errorf("프로필 파일이 마스터 키로 암호화되어 있습니다. %s 환경변수를 설정하세요", MasterKeyEnv)
+	}
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 솔트: %w", err)
+	}
+	return scrypt.Key([]byte(master), saltBytes, scryptN, scryptR, scryptP, 32)
+}
+
+// fileKey 키 파일의 256비트 키 (create면 없을 때 생성)
+func (s *Store) fileKey(create bool) ([]byte, error) {
+	data, err := os.ReadFile(s.keyPath)
+	if errors.Is(err, os.ErrNotExist) && create {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(s.keyPath), 0o700); err != nil {
+			return nil, fmt.Errorf("키 파일 생성 실패: %w", err)
+		}
+		if err := os.WriteFile(s.keyPath, []byte(hex.EncodeToString(key)+"\n"), 0o600); err != nil {
+			return nil, fmt.Errorf("키 파일 생성 실패: %w", err)
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("키 파일 읽기 실패: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("잘못된 키 파일: %s", s.keyPath)
+	}
+	return key, nil
+}
+
+// encrypt "enc:" + base64(nonce || 암호문)
+func (c *cipherKey) encrypt(plain string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return encPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt encrypt의 역 ("enc:"가 없는 값은 평문 그대로)
+func (c *cipherKey) decrypt(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encPrefix)
+	if !ok {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	size := c.aead.NonceSize()
+	if len(sealed) < size {
+		return "", errors.New("암호문이 너무 짧습니다")
+	}
+	plain, err := c.aead.Open(nil, sealed[:size], sealed[size:], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// encryptProfile 비밀번호, SSH 비밀번호·키 암호와 API 키 암호화 (빈 값은 그대로)
+func (c *cipherKey) encryptProfile(p *Profile) error {
+	var err error
+	if p.DB.Password != "" {
+		if p.DB.Password, err = c.encrypt(p.DB.Password); err != nil {
+			return err
+		}
+	}
+	if p.DB.SSH != nil {
+		ssh := *p.DB.SSH
+		for _, secret := range []*string{&ssh.Password, &ssh.KeyPassphrase} {
+			if *secret == "" {
+				continue
+			}
+			if *secret, err = c.encrypt(*secret); err != nil {
+				return err
+			}
+		}
+		p.DB.SSH = &ssh
+	}
+	if p.AI != nil && p.AI.APIKey != "" {
+		ai := *p.AI
+		if ai.APIKey, err = c.encrypt(ai.APIKey); err != nil {
+			return err
+		}
+		p.AI = &ai
+	}
+	return nil
+}
+
+// decryptProfile encryptProfile의 역
+func (c *cipherKey) decryptProfile(p *Profile) error {
+	var err error
+	if p.DB.Password, err = c.decrypt(p.DB.Password); err != nil {
+		return fmt.Errorf("프로필 %s 비밀번호 복호화 실패: %w", p.Name, err)
+	}
+	if p.DB.SSH != nil {
+		for _, secret := range []*string{&p.DB.SSH.Password, &p.DB.SSH.KeyPassphrase} {
+			if *secret, err = c.decrypt(*secret); err != nil {
+				return fmt.Errorf("프로필 %s SSH 비밀번호 복호화 실패: %w", p.Name, err)
+			}
+		}
+	}
+	if p.AI != nil {
+		if p.AI.APIKey, err = c.decrypt(p.AI.APIKey); err != nil {
+			return fmt.Errorf("프로필 %s API 키 복호화 실패: %w", p.Name, err)
+		}
+	}
+	return nil
+}
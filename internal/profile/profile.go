@@ -0,0 +1,219 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sql-genius/pkg/models"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound 이름에 해당하는 프로필이 없음
+var ErrNotFound = errors.New("프로필을 찾을 수 없습니다")
+
+// fileVersion 프로필 파일 형식 버전
+const fileVersion = 1
+
+// Profile 이름 붙인 연결 설정 (DB 연결과 선택적인 AI 설정)
+// 파일에는 DB.Password, DB.SSH의 비밀번호·키 암호와 AI.APIKey가 암호화되어 저장되며, Store가 읽을 때 복호화합니다.
+type Profile struct {
+	Name string           `json:"name"`
+	DB   models.DBConfig  `json:"db"`
+	AI   *models.AIConfig `json:"ai,omitempty"`
+}
+
+// file 프로필 파일 내용
+type file struct {
+	Version   int       `json:"version"`
+	KeySource string    `json:"key_source"`     // master-key 또는 keyfile
+	Salt      string    `json:"salt,omitempty"` // master-key의 scrypt 솔트 (base64)
+	Check     string    `json:"check"`          // 키 확인용 암호문
+	Profiles  []Profile `json:"profiles"`
+}
+
+// Store 프로필 파일 (JSON) 읽기·쓰기
+// 비밀번호는 환경변수 SQL_GENIUS_MASTER_KEY가 있으면 그 값에서 만든 키로, 없으면 프로필 파일 옆의
+// 키 파일(profiles.key, 처음 저장할 때 생성)로 AES-GCM 암호화합니다.
+type Store struct {
+	path    string
+	keyPath string
+	mu      sync.Mutex
+	cached  *cachedKey // mu로 보호
+}
+
+// DefaultPath 사용자 설정 디렉터리의 프로필 파일 (예: ~/.config/sql-genius/profiles.json)
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("설정 디렉터리를 찾을 수 없습니다: %w", err)
+	}
+	return filepath.Join(dir, "sql-genius", "profiles.json"), nil
+}
+
+// Open path의 프로필 파일을 쓰는 Store (파일은 처음 저장할 때 생성)
+func Open(path string) *Store {
+	return &Store{
+		path:    path,
+		keyPath: strings.TrimSuffix(path, filepath.Ext(path)) + ".key",
+	}
+}
+
+// Path 프로필 파일 경로
+func (s *Store) Path() string {
+	return s.path
+}
+
+// List 이름 순 프로필 목록 (비밀번호 복호화)
+func (s *Store) List() ([]Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, c, err := s.load(false)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]Profile, 0, len(f.Profiles))
+	for _, p := range f.Profiles {
+		if err := c.decryptProfile(&p); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// Get 이름으로 프로필 조회
+func (s *Store) Get(name string) (*Profile, error) {
+	profiles, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// Save 프로필 추가 또는 같은 이름의 프로필 교체
+func (s *Store) Save(p Profile) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("프로필 이름이 필요합니다")
+	}
+	if p.DB.Type == "" {
+		return errors.New("프로필의 DB 타입이 필요합니다")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, c, err := s.load(true)
+	if err != nil {
+		return err
+	}
+	if err := c.encryptProfile(&p); err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range f.Profiles {
+		if f.Profiles[i].Name == p.Name {
+			f.Profiles[i] = p
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		f.Profiles = append(f.Profiles, p)
+	}
+	return s.write(f)
+}
+
+// Delete 프로필 삭제
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, _, err := s.load(false)
+	if err != nil {
+		return err
+	}
+	for i := range f.Profiles {
+		if f.Profiles[i].Name == name {
+			f.Profiles = append(f.Profiles[:i], f.Profiles[i+1:]...)
+			return s.write(f)
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// load 파일과 암호화 키를 읽음
+// 파일이 없으면 빈 파일 내용과, create면 새 키(키 파일 생성 포함)를, 아니면 nil 키를 반환합니다.
+func (s *Store) load(create bool) (*file, *cipherKey, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		f := &file{Version: fileVersion}
+		if !create {
+			return f, nil, nil
+		}
+		c, err := s.newKey(f)
+		return f, c, err
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("프로필 파일 읽기 실패: %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, nil, fmt.Errorf("프로필 파일 파싱 실패: %w", err)
+	}
+	if f.Version > fileVersion {
+		return nil, nil, fmt.Errorf("지원하지 않는 프로필 파일 버전: %d", f.Version)
+	}
+	c, err := s.key(&f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &f, c, nil
+}
+
+// write 임시 파일에 쓴 뒤 교체 (권한 0600)
+func (s *Store) write(f *file) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("프로필 디렉터리 생성 실패: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".profiles-*.tmp")
+	if err != nil {
+		return fmt.Errorf("프로필 파일 쓰기 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("프로필 파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("프로필 파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("프로필 파일 쓰기 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("프로필 파일 쓰기 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sql-genius/pkg/models"
+	"testing"
+)
+
+func TestStoreReadDoesNotCreateKey(t *testing.T) {
+	t.Setenv(MasterKeyEnv, "")
+	s := Open(filepath.Join(t.TempDir(), "profiles.json"))
+
+	profiles, err := s.List()
+	if err != nil || len(profiles) != 0 {
+		t.Fatalf("List() = %v, %v, want empty", profiles, err)
+	}
+	if _, err := s.Get("prod"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete("prod"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() error = %v, want ErrNotFound", err)
+	}
+	for _, path := range []string{s.path, s.keyPath} {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s created by read (stat error = %v)", path, err)
+		}
+	}
+}
+
+func TestStoreKeyFile(t *testing.T) {
+	t.Setenv(MasterKeyEnv, "")
+	s := Open(filepath.Join(t.TempDir(), "profiles.json"))
+
+	if err := s.Save(Profile{Name: "prod", DB: models.DBConfig{Type: models.PostgreSQL, Password: "pw"}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(s.keyPath); err != nil {
+		t.Fatalf("key file not created: %v", err)
+	}
+	p, err := s.Get("prod")
+	if err != nil || p.DB.Password != "pw" {
+		t.Fatalf("Get() = %+v, %v, want password pw", p, err)
+	}
+
+	// 같은 파일을 새로 연 Store도 키 파일로 복호화
+	p, err = Open(s.path).Get("prod")
+	if err != nil || p.DB.Password != "pw" {
+		t.Errorf("Get() from new store = %+v, %v, want password pw", p, err)
+	}
+}
+
+func TestStoreMasterKeyCached(t *testing.T) {
+	t.Setenv(MasterKeyEnv, "master")
+	s := Open(filepath.Join(t.TempDir(), "profiles.json"))
+
+	if err := s.Save(Profile{Name: "prod", DB: models.DBConfig{Type: models.MySQL, Password: "pw"}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	key := s.cached.key
+	for i := 0; i < 3; i++ {
+		if p, err := s.Get("prod"); err != nil || p.DB.Password != "pw" {
+			t.Fatalf("Get() = %+v, %v, want password pw", p, err)
+		}
+	}
+	if s.cached.key != key {
+		t.Error("Get() derived the key again, want cached key")
+	}
+
+	// 마스터 키가 바뀌면 캐시를 쓰지 않고 다시 확인
+	t.Setenv(MasterKeyEnv, "other")
+	if _, err := s.List(); err == nil {
+		t.Error("List() with another master key error = nil")
+	}
+	t.Setenv(MasterKeyEnv, "master")
+	if _, err := s.List(); err != nil {
+		t.Errorf("List() error = %v", err)
+	}
+}
@@ -2,34 +2,183 @@ package query
 
 import (
 	"context"
+	"errors"
 	"sql-genius/internal/ai"
+	"sql-genius/internal/db"
+	"sql-genius/internal/schema"
 	"sql-genius/pkg/models"
+	"strings"
+	"time"
 )
 
+// DefaultMaxRetries 검증 실패 시 기본 재생성 횟수
+const DefaultMaxRetries = 2
+
+// explainTimeout 검증용 EXPLAIN 제한 시간
+const explainTimeout = 10 * time.Second
+
+// explainable EXPLAIN으로 실행 없이 검증할 수 있는 문장
+var explainable = map[string]bool{
+	"SELECT": true, "WITH": true, "INSERT": true, "UPDATE": true, "DELETE": true,
+	"MERGE": true, "REPLACE": true,
+}
+
 // Generator 쿼리 생성기
 type Generator struct {
 	aiProvider ai.Provider
 	schema     *models.Schema
+
+	// 생성된 쿼리 검증
+	parser     *schema.Parser
+	connector  db.Connector // nil이면 EXPLAIN 검증 생략
+	maxRetries int
+
+	options models.ModelOptions // 요청별 모델 설정
 }
 
 // NewGenerator 쿼리 생성기 생성
-func NewGenerator(provider ai.Provider, schema *models.Schema) *Generator {
+func NewGenerator(provider ai.Provider, s *models.Schema) *Generator {
 	return &Generator{
 		aiProvider: provider,
-		schema:     schema,
+		schema:     s,
+		parser:     schema.NewParser(),
+		maxRetries: DefaultMaxRetries,
 	}
 }
 
+// WithOptions 모델 설정만 다른 생성기 (원래 생성기는 그대로라 동시에 쓰는 요청마다 만들어 사용)
+func (g *Generator) WithOptions(opts models.ModelOptions) *Generator {
+	copied := *g
+	copied.options = opts
+	return &copied
+}
+
 // Generate 자연어로 쿼리 생성
 func (g *Generator) Generate(ctx context.Context, prompt string, queryType string) (*models.QueryResponse, error) {
 	req := &models.QueryRequest{
-		Prompt:    prompt,
-		Schema:    *g.schema,
-		QueryType: queryType,
-		Optimize:  true,
+		Prompt:       prompt,
+		Schema:       *g.schema,
+		QueryType:    queryType,
+		Optimize:     true,
+		ModelOptions: g.options,
+	}
+
+	return g.generateVerified(ctx, req, g.aiProvider.GenerateQuery)
+}
+
+// GenerateStream 자연어로 쿼리 생성 (생성 중인 텍스트를 onToken으로 실시간 전달)
+func (g *Generator) GenerateStream(ctx context.Context, prompt string, queryType string, onToken ai.TokenHandler) (*models.QueryResponse, error) {
+	req := &models.QueryRequest{
+		Prompt:       prompt,
+		Schema:       *g.schema,
+		QueryType:    queryType,
+		Optimize:     true,
+		ModelOptions: g.options,
+	}
+
+	// 첫 시도만 스트리밍하고, 검증 실패 후 재생성은 일반 요청으로 처리
+	first := true
+	return g.generateVerified(ctx, req, func(ctx context.Context, req *models.QueryRequest) (*models.QueryResponse, error) {
+		if first {
+			first = false
+			return g.aiProvider.GenerateQueryStream(ctx, req, onToken)
+		}
+		return g.aiProvider.GenerateQuery(ctx, req)
+	})
+}
+
+// generateVerified 쿼리를 생성하고 검증에 실패하면 오류를 전달해 최대 maxRetries번 다시 생성
+// 응답에서 쿼리를 찾지 못한 경우(ai.ErrNoQuery)도 같은 횟수 안에서 다시 생성합니다.
+// 모든 시도가 실패하면 마지막 결과를 Verified=false로 반환합니다.
+func (g *Generator) generateVerified(ctx context.Context, req *models.QueryRequest, generate func(context.Context, *models.QueryRequest) (*models.QueryResponse, error)) (*models.QueryResponse, error) {
+	var (
+		attempts  []models.QueryAttempt
+		last      *models.QueryResponse
+		totalTime int64
+	)
+
+	for round := 0; ; round++ {
+		resp, err := generate(ctx, req)
+		if err != nil {
+			// 응답 형식이 잘못돼 쿼리를 찾지 못했으면 남은 횟수 안에서 다시 생성
+			if errors.Is(err, ai.ErrNoQuery) && round < g.maxRetries && ctx.Err() == nil {
+				continue
+			}
+			if last != nil {
+				return last, nil // 재생성 중 오류면 직전 결과 반환
+			}
+			return nil, err
+		}
+		totalTime += resp.ExecuteTime
+		g.fillParams(resp)
+
+		attempt := g.Verify(ctx, resp.Query)
+		attempts = append(attempts, attempt)
+
+		resp.ExecuteTime = totalTime
+		resp.Verified = attempt.Valid
+		resp.Attempts = append([]models.QueryAttempt(nil), attempts...)
+		if attempt.Valid || round >= g.maxRetries || ctx.Err() != nil {
+			return resp, nil
+		}
+
+		last = resp
+		retry := *req
+		retry.Attempts = attempts
+		req = &retry
+	}
+}
+
+// Verify 생성된 쿼리를 스키마와 대조하고, DB 연결이 있으면 EXPLAIN으로 확인 (쿼리는 실행하지 않음)
+func (g *Generator) Verify(ctx context.Context, query string) models.QueryAttempt {
+	attempt := models.QueryAttempt{Query: query}
+
+	check := g.parser.CheckQuery(query, g.schema)
+	attempt.Issues = check.Issues
+	if check.HasErrors() {
+		return attempt
+	}
+
+	// 여러 문장이면 EXPLAIN 뒤의 문장이 실행될 수 있으므로 단일 DML/SELECT만 확인
+	if g.connector != nil && check.Statements == 1 && explainable[check.Statement] {
+		explainCtx, cancel := context.WithTimeout(ctx, explainTimeout)
+		defer cancel()
+
+		// 바인드 파라미터는 값 없이 EXPLAIN할 수 없으므로 NULL로 대체
+		stmt := strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+		if inlined, _, err := g.parser.RewritePlaceholders(stmt, g.connector.Type(), func(schema.Placeholder) string { return "NULL" }); err == nil {
+			stmt = inlined
+		}
+		if _, err := g.connector.Explain(explainCtx, stmt); err != nil {
+			attempt.DBError = err.Error()
+			return attempt
+		}
+	}
+
+	attempt.Valid = true
+	return attempt
+}
+
+// fillParams 쿼리의 이름 있는 파라미터(:name)와 params 목록을 맞춤
+// AI가 빠뜨린 파라미터는 추가하고 쿼리에 없는 항목은 제거합니다.
+func (g *Generator) fillParams(resp *models.QueryResponse) {
+	placeholders, err := g.parser.Placeholders(resp.Query, g.schema.DBType)
+	if err != nil {
+		return
 	}
 
-	return g.aiProvider.GenerateQuery(ctx, req)
+	described := make(map[string]models.QueryParam)
+	for _, param := range resp.Params {
+		described[strings.ToLower(param.Name)] = param
+	}
+
+	var params []models.QueryParam
+	for _, name := range schema.ParamNames(placeholders) {
+		param := described[strings.ToLower(name)]
+		param.Name = name
+		params = append(params, param)
+	}
+	resp.Params = params
 }
 
 // GenerateSelect SELECT 쿼리 생성
@@ -64,7 +213,12 @@ func (g *Generator) GenerateCreate(ctx context.Context, prompt string) (*models.
 
 // Optimize 기존 쿼리 최적화
 func (g *Generator) Optimize(ctx context.Context, query string) (*models.QueryResponse, error) {
-	return g.aiProvider.OptimizeQuery(ctx, query, g.schema)
+	resp, err := g.aiProvider.OptimizeQuery(ctx, query, g.schema)
+	if err != nil {
+		return nil, err
+	}
+	g.fillParams(resp)
+	return resp, nil
 }
 
 // Explain 쿼리 설명
@@ -73,8 +227,21 @@ func (g *Generator) Explain(ctx context.Context, query string) (string, error) {
 }
 
 // SetSchema 스키마 설정
-func (g *Generator) SetSchema(schema *models.Schema) {
-	g.schema = schema
+func (g *Generator) SetSchema(s *models.Schema) {
+	g.schema = s
+}
+
+// SetConnector 생성된 쿼리를 EXPLAIN으로 검증할 DB 연결 설정 (nil이면 스키마 대조만 수행)
+func (g *Generator) SetConnector(conn db.Connector) {
+	g.connector = conn
+}
+
+// SetMaxRetries 검증 실패 시 재생성 횟수 설정 (0이면 재생성하지 않음)
+func (g *Generator) SetMaxRetries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	g.maxRetries = n
 }
 
 // GetSchema 현재 스키마 조회
@@ -0,0 +1,645 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sql-genius/pkg/models"
+	"strings"
+	"unicode"
+)
+
+// ApplyDDL DDL 문을 순서대로 기존 스키마에 적용 (마이그레이션 재생)
+// CREATE/ALTER/DROP TABLE, CREATE/DROP INDEX, PostgreSQL enum 타입과 도메인을 반영하며 나머지 문은 무시합니다.
+func (p *Parser) ApplyDDL(schema *models.Schema, ddl string) error {
+	stmts, err := p.ParseStatements(ddl, schema.DBType)
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range stmts {
+		if err := p.applyStatement(schema, stmt); err != nil {
+			return err
+		}
+	}
+
+	resolveReferences(schema)
+	ResolveTypes(schema)
+	return nil
+}
+
+// ParseDDLDir 디렉터리의 마이그레이션 파일(*.sql)을 파일 이름 순서대로 적용
+// 롤백용 *.down.sql 파일은 건너뜁니다.
+func (p *Parser) ParseDDLDir(dir string, dbType models.DBType) (*models.Schema, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("마이그레이션 디렉터리 읽기 실패: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		lower := strings.ToLower(name)
+		if entry.IsDir() || !strings.HasSuffix(lower, ".sql") || strings.HasSuffix(lower, ".down.sql") {
+			continue
+		}
+		files = append(files, name)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return naturalLess(files[i], files[j])
+	})
+
+	schema := &models.Schema{
+		Database: filepath.Base(dir),
+		DBType:   dbType,
+		Tables:   []models.Table{},
+	}
+
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if err := p.ApplyDDL(schema, string(data)); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return schema, nil
+}
+
+func (p *Parser) applyStatement(schema *models.Schema, stmt Statement) error {
+	switch s := stmt.(type) {
+	case *CreateTableStmt:
+		if lookupTable(schema, s.Name.Schema, s.Name.Name) != nil {
+			if s.IfNotExists {
+				return nil
+			}
+			return applyError(s, "이미 존재하는 테이블입니다: %s", s.Name.Name)
+		}
+		schema.Tables = append(schema.Tables, p.buildTable(s, schema.DBType))
+
+	case *CreateIndexStmt:
+		// 뷰 등 스키마에 없는 객체의 인덱스는 무시
+		if table := lookupTable(schema, s.Table.Schema, s.Table.Name); table != nil {
+			if s.IfNotExists && s.Name != "" && hasIndex(table, s.Name) {
+				return nil
+			}
+			table.Indexes = append(table.Indexes, models.Index{
+				Name:     s.Name,
+				Columns:  s.Columns,
+				IsUnique: s.Unique,
+				Type:     s.Type,
+			})
+		}
+
+	case *AlterTableStmt:
+		return p.applyAlterTable(schema, s)
+
+	case *DropTableStmt:
+		for _, name := range s.Names {
+			if !removeTable(schema, name) && !s.IfExists {
+				return applyError(s, "테이블을 찾을 수 없습니다: %s", name.Name)
+			}
+		}
+
+	case *DropIndexStmt:
+		// schema.index 표기면 인덱스는 그 스키마의 테이블에 속함
+		schemaName := s.Table.Schema
+		if s.Table.Name == "" {
+			schemaName = s.Schema
+		}
+		if !dropIndex(schema, schemaName, s.Table.Name, s.Name) && !s.IfExists {
+			return applyError(s, "인덱스를 찾을 수 없습니다: %s", s.Name)
+		}
+
+	case *CreateTypeStmt:
+		if lookupType(schema, models.QualifiedName(s.Name.Schema, s.Name.Name)) != nil {
+			return applyError(s, "이미 존재하는 타입입니다: %s", s.Name.Name)
+		}
+		schema.Types = append(schema.Types, models.UserType{
+			Schema: s.Name.Schema,
+			Name:   s.Name.Name,
+			Kind:   models.TypeEnum,
+			Values: s.Values,
+		})
+
+	case *CreateDomainStmt:
+		if lookupType(schema, models.QualifiedName(s.Name.Schema, s.Name.Name)) != nil {
+			return applyError(s, "이미 존재하는 타입입니다: %s", s.Name.Name)
+		}
+		var checks []string
+		for _, c := range s.Def.Checks {
+			checks = append(checks, c.Expr)
+		}
+		schema.Types = append(schema.Types, models.UserType{
+			Schema:   s.Name.Schema,
+			Name:     s.Name.Name,
+			Kind:     models.TypeDomain,
+			BaseType: s.Def.Type,
+			NotNull:  s.Def.NotNull,
+			Default:  s.Def.Default,
+			Check:    JoinChecks(checks),
+		})
+
+	case *AlterTypeStmt:
+		t := lookupType(schema, models.QualifiedName(s.Name.Schema, s.Name.Name))
+		if t == nil {
+			return applyError(s, "타입을 찾을 수 없습니다: %s", s.Name.Name)
+		}
+		if s.NewValue != "" {
+			replaceValue(t.Values, s.Value, s.NewValue)
+		} else {
+			t.Values = insertValue(t.Values, s.Value, s.Before, s.After)
+		}
+
+	case *DropTypeStmt:
+		for _, name := range s.Names {
+			if !removeType(schema, name) && !s.IfExists {
+				return applyError(s, "타입을 찾을 수 없습니다: %s", name.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Parser) applyAlterTable(schema *models.Schema, stmt *AlterTableStmt) error {
+	table := lookupTable(schema, stmt.Table.Schema, stmt.Table.Name)
+	if table == nil {
+		if stmt.IfExists {
+			return nil
+		}
+		// OWNER TO 등 모델과 무관한 동작만 있으면 무시 (pg_dump 시퀀스/뷰)
+		for _, action := range stmt.Actions {
+			if action.Kind != AlterOther {
+				return applyError(stmt, "테이블을 찾을 수 없습니다: %s", stmt.Table.Name)
+			}
+		}
+		return nil
+	}
+
+	for _, action := range stmt.Actions {
+		if err := p.applyAlterAction(schema, table, action, schema.DBType); err != nil {
+			return err
+		}
+		// RENAME TO 이후에도 같은 테이블을 가리키도록 다시 조회
+		if action.Kind == AlterRenameTable {
+			table = lookupTable(schema, table.Schema, action.NewName)
+		}
+	}
+	return nil
+}
+
+func (p *Parser) applyAlterAction(schema *models.Schema, table *models.Table, action AlterAction, dbType models.DBType) error {
+	switch action.Kind {
+	case AlterAddColumn:
+		def := action.Column
+		if findColumn(table, def.Name) != nil {
+			if action.IfNotExists {
+				return nil
+			}
+			return &ParseError{Pos: action.Pos, Msg: fmt.Sprintf("이미 존재하는 컬럼입니다: %s.%s", table.Name, def.Name)}
+		}
+		table.Columns = append(table.Columns, columnFromDef(*def))
+		if def.PrimaryKey {
+			table.PrimaryKey = append(table.PrimaryKey, def.Name)
+		}
+		if def.Unique {
+			addConstraint(table, TableConstraint{Kind: ConstraintUnique, Columns: []string{def.Name}}, dbType)
+		}
+		if def.References != nil {
+			addConstraint(table, *def.References, dbType)
+		}
+		for _, c := range def.Checks {
+			addConstraint(table, c, dbType)
+		}
+
+	case AlterDropColumn:
+		if !dropColumn(table, action.Name, dbType) && !action.IfExists {
+			return &ParseError{Pos: action.Pos, Msg: fmt.Sprintf("컬럼을 찾을 수 없습니다: %s.%s", table.Name, action.Name)}
+		}
+
+	case AlterModifyColumn:
+		return modifyColumn(schema, table, action, dbType)
+
+	case AlterRenameColumn:
+		if findColumn(table, action.Name) == nil {
+			return &ParseError{Pos: action.Pos, Msg: fmt.Sprintf("컬럼을 찾을 수 없습니다: %s.%s", table.Name, action.Name)}
+		}
+		renameColumn(schema, table, action.Name, action.NewName, dbType)
+
+	case AlterRenameTable:
+		renameTable(schema, table, action.NewName)
+
+	case AlterAddConstraint:
+		addConstraint(table, *action.Constraint, dbType)
+
+	case AlterDropConstraint:
+		dropConstraint(table, action.Name, dbType)
+
+	case AlterDropPrimaryKey:
+		clearPrimaryKey(table)
+
+	case AlterDropIndex:
+		dropIndex(schema, table.Schema, table.Name, action.Name)
+	}
+	return nil
+}
+
+// modifyColumn MODIFY/CHANGE/ALTER COLUMN 반영
+// MySQL과 SQL Server는 컬럼 정의 전체를 교체하고, 나머지 DB는 지정된 속성만 변경
+func modifyColumn(schema *models.Schema, table *models.Table, action AlterAction, dbType models.DBType) error {
+	def := action.Column
+	oldName := action.Name
+	if oldName == "" {
+		oldName = def.Name
+	}
+
+	col := findColumn(table, oldName)
+	if col == nil {
+		return &ParseError{Pos: action.Pos, Msg: fmt.Sprintf("컬럼을 찾을 수 없습니다: %s.%s", table.Name, oldName)}
+	}
+
+	switch {
+	case action.SetNotNull:
+		col.Nullable = false
+	case action.DropNotNull:
+		col.Nullable = true
+	case action.DropDefault:
+		col.Default = ""
+	case def.Type == "" || dbType == models.PostgreSQL || dbType == models.Oracle || dbType == models.SQLite:
+		if def.Type != "" {
+			setColumnType(table, col, def.Type, dbType)
+		}
+		if def.NotNull {
+			col.Nullable = false
+		} else if def.Null {
+			col.Nullable = true
+		}
+		if def.HasDefault {
+			col.Default = def.Default
+		}
+	default:
+		setColumnType(table, col, def.Type, dbType)
+		col.Nullable = !def.NotNull && !col.IsPK
+		if dbType == models.MySQL {
+			col.Default = def.Default
+			col.Comment = def.Comment
+			col.IsAutoIncr = def.AutoIncr
+			col.Collation = def.Collation
+			col.Generated = TrimParens(def.Generated)
+			col.Stored = def.Stored
+		}
+	}
+
+	if !strings.EqualFold(oldName, def.Name) {
+		renameColumn(schema, table, oldName, def.Name, dbType)
+	}
+	return nil
+}
+
+// setColumnType 컬럼 타입 변경 (값 목록은 새 타입과 CHECK에서 다시 구하고, enum 타입은 ResolveTypes가 채움)
+func setColumnType(table *models.Table, col *models.Column, typ string, dbType models.DBType) {
+	col.Type = typ
+	col.Domain = ""
+	col.EnumValues = columnEnumValues(table, col, dbType)
+}
+
+func dropColumn(table *models.Table, name string, dbType models.DBType) bool {
+	idx := -1
+	for i := range table.Columns {
+		if strings.EqualFold(table.Columns[i].Name, name) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return false
+	}
+	table.Columns = append(table.Columns[:idx], table.Columns[idx+1:]...)
+
+	// 기본키도 인덱스와 같이 MySQL은 컬럼만 빠지고, 다른 DB는 복합 기본키 전체가 삭제됨
+	if containsName(table.PrimaryKey, name) {
+		if dbType == models.MySQL {
+			table.PrimaryKey = removeName(table.PrimaryKey, name)
+		} else {
+			clearPrimaryKey(table)
+		}
+	}
+
+	// 컬럼이 속한 외래키 제약조건은 복합 외래키의 다른 컬럼 쌍까지 함께 삭제
+	dropped := make(map[string]bool)
+	for _, fk := range GroupForeignKeys(table.ForeignKeys) {
+		if fk.Name != "" && containsName(fk.Columns, name) {
+			dropped[strings.ToLower(fk.Name)] = true
+		}
+	}
+	var fks []models.FK
+	for _, fk := range table.ForeignKeys {
+		if !strings.EqualFold(fk.Column, name) && !(fk.Name != "" && dropped[strings.ToLower(fk.Name)]) {
+			fks = append(fks, fk)
+		}
+	}
+	table.ForeignKeys = fks
+	refreshFKFlags(table)
+
+	var checks []models.Check
+	for _, c := range table.Checks {
+		if !containsName(c.Columns, name) {
+			checks = append(checks, c)
+		}
+	}
+	table.Checks = checks
+
+	// MySQL은 인덱스에서 컬럼만 제거하고, 다른 DB는 해당 컬럼을 포함한 인덱스를 삭제
+	var indexes []models.Index
+	for _, index := range table.Indexes {
+		if !containsName(index.Columns, name) {
+			indexes = append(indexes, index)
+			continue
+		}
+		if dbType == models.MySQL {
+			index.Columns = removeName(index.Columns, name)
+			if len(index.Columns) > 0 {
+				indexes = append(indexes, index)
+			}
+		}
+	}
+	table.Indexes = indexes
+	return true
+}
+
+// renameColumn 컬럼 이름과 이를 참조하는 기본키/인덱스/외래키/CHECK를 함께 변경
+func renameColumn(schema *models.Schema, table *models.Table, oldName, newName string, dbType models.DBType) {
+	if col := findColumn(table, oldName); col != nil {
+		col.Name = newName
+	}
+	replaceName(table.PrimaryKey, oldName, newName)
+	for i := range table.Indexes {
+		replaceName(table.Indexes[i].Columns, oldName, newName)
+	}
+	for i := range table.ForeignKeys {
+		if strings.EqualFold(table.ForeignKeys[i].Column, oldName) {
+			table.ForeignKeys[i].Column = newName
+		}
+	}
+	for i := range table.Checks {
+		c := &table.Checks[i]
+		if containsName(c.Columns, oldName) {
+			replaceName(c.Columns, oldName, newName)
+			c.Expression = renameIdent(c.Expression, oldName, newName, dbType)
+		}
+	}
+
+	for i := range schema.Tables {
+		for j := range schema.Tables[i].ForeignKeys {
+			fk := &schema.Tables[i].ForeignKeys[j]
+			if strings.EqualFold(fk.RefTable, table.Name) && strings.EqualFold(fk.RefColumn, oldName) {
+				fk.RefColumn = newName
+			}
+		}
+	}
+}
+
+func renameTable(schema *models.Schema, table *models.Table, newName string) {
+	oldName := table.Name
+	table.Name = newName
+	for i := range schema.Tables {
+		for j := range schema.Tables[i].ForeignKeys {
+			fk := &schema.Tables[i].ForeignKeys[j]
+			if strings.EqualFold(fk.RefTable, oldName) {
+				fk.RefTable = newName
+			}
+		}
+	}
+}
+
+// dropConstraint 이름으로 외래키/유니크/CHECK 제약 삭제
+func dropConstraint(table *models.Table, name string, dbType models.DBType) {
+	found := false
+
+	if dropCheck(table, name, dbType) {
+		found = true
+	}
+
+	var fks []models.FK
+	for _, fk := range table.ForeignKeys {
+		if strings.EqualFold(fk.Name, name) {
+			found = true
+			continue
+		}
+		fks = append(fks, fk)
+	}
+	table.ForeignKeys = fks
+	refreshFKFlags(table)
+
+	if dropTableIndex(table, name) {
+		found = true
+	}
+
+	// 기본키 제약 이름은 모델에 저장되지 않으므로 관례적인 이름(users_pkey, PK_users)으로 판단
+	lower := strings.ToLower(name)
+	if !found && (strings.HasSuffix(lower, "_pkey") || strings.HasPrefix(lower, "pk")) {
+		clearPrimaryKey(table)
+	}
+}
+
+// dropCheck 이름으로 CHECK 삭제 (그 CHECK에서 얻은 컬럼 값 목록도 다시 구함)
+func dropCheck(table *models.Table, name string, dbType models.DBType) bool {
+	for i, c := range table.Checks {
+		if c.Name == "" || !strings.EqualFold(c.Name, name) {
+			continue
+		}
+		table.Checks = append(table.Checks[:i], table.Checks[i+1:]...)
+		if len(c.Columns) == 1 {
+			if col := findColumn(table, c.Columns[0]); col != nil && col.Domain == "" {
+				col.EnumValues = columnEnumValues(table, col, dbType)
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func clearPrimaryKey(table *models.Table) {
+	for _, name := range table.PrimaryKey {
+		if col := findColumn(table, name); col != nil {
+			col.IsPK = false
+		}
+	}
+	table.PrimaryKey = nil
+}
+
+func refreshFKFlags(table *models.Table) {
+	for i := range table.Columns {
+		table.Columns[i].IsFK = false
+		for _, fk := range table.ForeignKeys {
+			if strings.EqualFold(fk.Column, table.Columns[i].Name) {
+				table.Columns[i].IsFK = true
+			}
+		}
+	}
+}
+
+// hasIndex 테이블에 같은 이름의 인덱스가 있는지 (대소문자 무시)
+func hasIndex(table *models.Table, name string) bool {
+	for _, idx := range table.Indexes {
+		if strings.EqualFold(idx.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropIndex 테이블을 지정하지 않으면 모든 테이블에서 이름으로 검색
+// 스키마를 지정하면 그 스키마의 테이블만 검색합니다 (스키마 비교는 lookupTable과 같음).
+func dropIndex(schema *models.Schema, schemaName, tableName, indexName string) bool {
+	for i := range schema.Tables {
+		t := &schema.Tables[i]
+		if tableName != "" && !strings.EqualFold(t.Name, tableName) {
+			continue
+		}
+		if schemaName != "" && t.Schema != "" && !strings.EqualFold(t.Schema, schemaName) {
+			continue
+		}
+		if dropTableIndex(t, indexName) {
+			return true
+		}
+	}
+	return false
+}
+
+func dropTableIndex(table *models.Table, name string) bool {
+	for i, index := range table.Indexes {
+		if strings.EqualFold(index.Name, name) {
+			table.Indexes = append(table.Indexes[:i], table.Indexes[i+1:]...)
+			if index.IsUnique && len(index.Columns) == 1 {
+				if col := findColumn(table, index.Columns[0]); col != nil {
+					col.IsUnique = false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func removeTable(schema *models.Schema, name ObjectName) bool {
+	t := lookupTable(schema, name.Schema, name.Name)
+	if t == nil {
+		return false
+	}
+	for i := range schema.Tables {
+		if &schema.Tables[i] == t {
+			schema.Tables = append(schema.Tables[:i], schema.Tables[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func removeType(schema *models.Schema, name ObjectName) bool {
+	t := lookupType(schema, models.QualifiedName(name.Schema, name.Name))
+	if t == nil {
+		return false
+	}
+	for i := range schema.Types {
+		if &schema.Types[i] == t {
+			schema.Types = append(schema.Types[:i], schema.Types[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// insertValue enum 값 추가 (BEFORE/AFTER가 없거나 기준 값을 찾지 못하면 끝에, 이미 있으면 그대로)
+func insertValue(values []string, value, before, after string) []string {
+	pos := len(values)
+	for i, v := range values {
+		switch v {
+		case value:
+			return values
+		case before:
+			pos = i
+		case after:
+			pos = i + 1
+		}
+	}
+	values = append(values, "")
+	copy(values[pos+1:], values[pos:])
+	values[pos] = value
+	return values
+}
+
+func replaceValue(values []string, oldValue, newValue string) {
+	for i := range values {
+		if values[i] == oldValue {
+			values[i] = newValue
+		}
+	}
+}
+
+func applyError(stmt Statement, format string, args ...interface{}) error {
+	return &ParseError{Pos: stmt.Position(), Msg: fmt.Sprintf(format, args...)}
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeName(names []string, name string) []string {
+	var result []string
+	for _, n := range names {
+		if !strings.EqualFold(n, name) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func replaceName(names []string, oldName, newName string) {
+	for i := range names {
+		if strings.EqualFold(names[i], oldName) {
+			names[i] = newName
+		}
+	}
+}
+
+// naturalLess 숫자 부분을 수 크기로 비교 (V2__ < V10__)
+func naturalLess(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	i, j := 0, 0
+	for i < len(ra) && j < len(rb) {
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+			si := i
+			for i < len(ra) && unicode.IsDigit(ra[i]) {
+				i++
+			}
+			sj := j
+			for j < len(rb) && unicode.IsDigit(rb[j]) {
+				j++
+			}
+			na := strings.TrimLeft(string(ra[si:i]), "0")
+			nb := strings.TrimLeft(string(rb[sj:j]), "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			continue
+		}
+		if ra[i] != rb[j] {
+			return ra[i] < rb[j]
+		}
+		i++
+		j++
+	}
+	return len(ra)-i < len(rb)-j
+}
@@ -0,0 +1,299 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"sql-genius/pkg/models"
+	"testing"
+)
+
+// parseDDL 스크립트를 적용한 스키마 (실패하면 테스트 중단)
+func parseDDL(t *testing.T, ddl string, dbType models.DBType) *models.Schema {
+	t.Helper()
+	schema, err := NewParser().ParseDDL(ddl, dbType)
+	if err != nil {
+		t.Fatalf("ParseDDL() error = %v", err)
+	}
+	return schema
+}
+
+// qualifiedTableNames 스키마로 한정한 테이블 이름 목록
+func qualifiedTableNames(schema *models.Schema) []string {
+	var names []string
+	for _, t := range schema.Tables {
+		names = append(names, t.QualifiedName())
+	}
+	return names
+}
+
+func TestApplySchemaQualifiedTables(t *testing.T) {
+	schema := parseDDL(t, `CREATE TABLE sales.users (id INT);
+CREATE TABLE hr.users (id INT);
+CREATE INDEX ix_users ON sales.users (id);
+CREATE INDEX ix_users ON hr.users (id);
+ALTER TABLE hr.users ADD COLUMN name TEXT;
+DROP INDEX hr.ix_users;
+DROP TABLE hr.users;`, models.PostgreSQL)
+
+	if got := qualifiedTableNames(schema); !reflect.DeepEqual(got, []string{"sales.users"}) {
+		t.Fatalf("tables = %v, want [sales.users]", got)
+	}
+	sales := schema.Tables[0]
+	if len(sales.Columns) != 1 {
+		t.Errorf("sales.users columns = %+v, want id only (ALTER hr.users는 다른 테이블)", sales.Columns)
+	}
+	if len(sales.Indexes) != 1 || sales.Indexes[0].Name != "ix_users" {
+		t.Errorf("sales.users indexes = %+v, want ix_users (DROP INDEX hr.ix_users는 다른 테이블)", sales.Indexes)
+	}
+}
+
+func TestApplyUnqualifiedNames(t *testing.T) {
+	schema := parseDDL(t, `CREATE TABLE users (id INT);
+ALTER TABLE public.users ADD COLUMN name TEXT;
+DROP TABLE IF EXISTS other.missing;`, models.PostgreSQL)
+
+	// 스키마 없이 만든 테이블은 스키마로 한정한 이름으로도 찾음
+	if len(schema.Tables) != 1 || len(schema.Tables[0].Columns) != 2 {
+		t.Errorf("tables = %+v", schema.Tables)
+	}
+}
+
+func TestApplyForeignKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		ddl    string
+	}{
+		{"PostgreSQL", models.PostgreSQL, `CREATE TABLE p (a INT, b INT, PRIMARY KEY (a, b));
+CREATE TABLE c (id INT PRIMARY KEY, x INT, y INT, CONSTRAINT fk_c FOREIGN KEY (x, y) REFERENCES p (a, b));`},
+		{"MySQL", models.MySQL, "CREATE TABLE `p` (`a` INT, `b` INT, PRIMARY KEY (`a`, `b`));\n" +
+			"CREATE TABLE `c` (`id` INT PRIMARY KEY, `x` INT, `y` INT, CONSTRAINT `fk_c` FOREIGN KEY (`x`, `y`) REFERENCES `p` (`a`, `b`));"},
+		{"SQL Server", models.SQLServer, "CREATE TABLE [p] ([a] INT, [b] INT, PRIMARY KEY ([a], [b]))\nGO\n" +
+			"CREATE TABLE [c] ([id] INT PRIMARY KEY, [x] INT, [y] INT, CONSTRAINT [fk_c] FOREIGN KEY ([x], [y]) REFERENCES [p] ([a], [b]))\nGO"},
+		{"Oracle", models.Oracle, "CREATE TABLE p (a NUMBER, b NUMBER, PRIMARY KEY (a, b))\n/\n" +
+			"CREATE TABLE c (id NUMBER PRIMARY KEY, x NUMBER, y NUMBER, CONSTRAINT fk_c FOREIGN KEY (x, y) REFERENCES p (a, b))\n/"},
+	}
+
+	want := []ForeignKey{{Name: "fk_c", Columns: []string{"x", "y"}, RefTable: "p", RefColumns: []string{"a", "b"}}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := parseDDL(t, tt.ddl, tt.dbType)
+			c := findTable(schema, "c")
+			if got := GroupForeignKeys(c.ForeignKeys); !reflect.DeepEqual(got, want) {
+				t.Errorf("GroupForeignKeys() = %+v, want %+v", got, want)
+			}
+			for _, name := range []string{"x", "y"} {
+				if !findColumn(c, name).IsFK {
+					t.Errorf("column %s IsFK = false", name)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyDropColumn(t *testing.T) {
+	const create = `CREATE TABLE p (a INT, b INT, PRIMARY KEY (a, b));
+CREATE TABLE c (x INT, y INT, z INT, PRIMARY KEY (x, y), CONSTRAINT fk_c FOREIGN KEY (x, y) REFERENCES p (a, b), CHECK (z > y));
+CREATE INDEX ix_yz ON c (y, z);
+`
+	tests := []struct {
+		name       string
+		dbType     models.DBType
+		ddl        string
+		primaryKey []string
+		indexes    int
+	}{
+		// 복합 기본키와 외래키의 컬럼을 지우면 제약조건 전체가 없어짐
+		{"PostgreSQL", models.PostgreSQL, create + "ALTER TABLE c DROP COLUMN y;", nil, 0},
+		// MySQL은 기본키와 인덱스에서 컬럼만 빠짐
+		{"MySQL", models.MySQL, create + "ALTER TABLE c DROP COLUMN y;", []string{"x"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := findTable(parseDDL(t, tt.ddl, tt.dbType), "c")
+			if len(c.PrimaryKey) != len(tt.primaryKey) || (len(tt.primaryKey) > 0 && !reflect.DeepEqual(c.PrimaryKey, tt.primaryKey)) {
+				t.Errorf("PrimaryKey = %v, want %v", c.PrimaryKey, tt.primaryKey)
+			}
+			if len(c.ForeignKeys) != 0 {
+				t.Errorf("ForeignKeys = %+v, want none (fk_c의 x 쌍도 삭제)", c.ForeignKeys)
+			}
+			if len(c.Checks) != 0 {
+				t.Errorf("Checks = %+v, want none", c.Checks)
+			}
+			if len(c.Indexes) != tt.indexes {
+				t.Errorf("Indexes = %+v, want %d", c.Indexes, tt.indexes)
+			}
+			x := findColumn(c, "x")
+			if x.IsFK || x.IsPK != (len(tt.primaryKey) > 0) {
+				t.Errorf("x = %+v", x)
+			}
+		})
+	}
+}
+
+func TestApplyAlterTable(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		ddl    string
+		check  func(t *testing.T, schema *models.Schema)
+	}{
+		{"PostgreSQL 컬럼 이름 변경", models.PostgreSQL, `CREATE TABLE p (a INT PRIMARY KEY);
+CREATE TABLE c (id INT PRIMARY KEY, x INT REFERENCES p (a));
+CREATE INDEX ix ON c (x, id);
+ALTER TABLE c RENAME COLUMN x TO x2;
+ALTER TABLE c RENAME COLUMN id TO id2;`, func(t *testing.T, schema *models.Schema) {
+			c := findTable(schema, "c")
+			if !reflect.DeepEqual(c.PrimaryKey, []string{"id2"}) || c.ForeignKeys[0].Column != "x2" ||
+				!reflect.DeepEqual(c.Indexes[0].Columns, []string{"x2", "id2"}) {
+				t.Errorf("c = %+v", c)
+			}
+		}},
+		{"PostgreSQL 부분 변경", models.PostgreSQL, `CREATE TABLE t (a INT DEFAULT 1);
+ALTER TABLE t ALTER COLUMN a SET NOT NULL, ALTER COLUMN a TYPE bigint, ALTER COLUMN a DROP DEFAULT;`, func(t *testing.T, schema *models.Schema) {
+			a := schema.Tables[0].Columns[0]
+			if a.Type != "bigint" || a.Nullable || a.Default != "" {
+				t.Errorf("a = %+v", a)
+			}
+		}},
+		{"MySQL MODIFY와 CHANGE", models.MySQL, "CREATE TABLE t (id INT, x INT, KEY ix (x, id));\n" +
+			"ALTER TABLE t MODIFY COLUMN x VARCHAR(10) NOT NULL DEFAULT 'a', CHANGE id id2 BIGINT;", func(t *testing.T, schema *models.Schema) {
+			tbl := schema.Tables[0]
+			x := findColumn(&tbl, "x")
+			if x.Type != "VARCHAR(10)" || x.Nullable || x.Default != "'a'" || findColumn(&tbl, "id2") == nil ||
+				!reflect.DeepEqual(tbl.Indexes[0].Columns, []string{"x", "id2"}) {
+				t.Errorf("t = %+v", tbl)
+			}
+		}},
+		{"SQL Server ALTER COLUMN", models.SQLServer, "CREATE TABLE t (x INT)\nGO\nALTER TABLE t ALTER COLUMN x NVARCHAR(10) NOT NULL\nGO", func(t *testing.T, schema *models.Schema) {
+			x := schema.Tables[0].Columns[0]
+			if x.Type != "NVARCHAR(10)" || x.Nullable {
+				t.Errorf("x = %+v", x)
+			}
+		}},
+		{"Oracle MODIFY", models.Oracle, "CREATE TABLE t (x NUMBER)\n/\nALTER TABLE t MODIFY (x VARCHAR2(10) NOT NULL)\n/", func(t *testing.T, schema *models.Schema) {
+			x := schema.Tables[0].Columns[0]
+			if x.Type != "VARCHAR2(10)" || x.Nullable {
+				t.Errorf("x = %+v", x)
+			}
+		}},
+		{"복합 외래키 제약조건 삭제", models.PostgreSQL, `CREATE TABLE p (a INT, b INT);
+CREATE TABLE c (x INT, y INT, CONSTRAINT fk FOREIGN KEY (x, y) REFERENCES p (a, b));
+ALTER TABLE c DROP CONSTRAINT fk;`, func(t *testing.T, schema *models.Schema) {
+			c := findTable(schema, "c")
+			if len(c.ForeignKeys) != 0 || findColumn(c, "x").IsFK || findColumn(c, "y").IsFK {
+				t.Errorf("c = %+v", c)
+			}
+		}},
+		{"테이블 이름 변경", models.PostgreSQL, `CREATE TABLE p (a INT PRIMARY KEY);
+CREATE TABLE c (x INT REFERENCES p (a));
+ALTER TABLE p RENAME TO parent;
+ALTER TABLE parent ADD COLUMN b INT;`, func(t *testing.T, schema *models.Schema) {
+			parent := findTable(schema, "parent")
+			if parent == nil || len(parent.Columns) != 2 || findTable(schema, "c").ForeignKeys[0].RefTable != "parent" {
+				t.Errorf("tables = %+v", schema.Tables)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, parseDDL(t, tt.ddl, tt.dbType))
+		})
+	}
+}
+
+func TestApplyDDLIncremental(t *testing.T) {
+	p := NewParser()
+	schema := parseDDL(t, "CREATE TABLE t (id INT);", models.PostgreSQL)
+	if err := p.ApplyDDL(schema, "CREATE TABLE IF NOT EXISTS t (other INT); ALTER TABLE t ADD COLUMN name TEXT;"); err != nil {
+		t.Fatal(err)
+	}
+	if len(schema.Tables) != 1 || len(schema.Tables[0].Columns) != 2 {
+		t.Errorf("tables = %+v", schema.Tables)
+	}
+}
+
+func TestApplyIfExists(t *testing.T) {
+	tests := []struct {
+		name    string
+		dbType  models.DBType
+		ddl     string
+		columns []string
+		indexes []string
+	}{
+		{"있는 컬럼 ADD COLUMN IF NOT EXISTS", models.PostgreSQL,
+			"CREATE TABLE a (id INT);\nALTER TABLE a ADD COLUMN IF NOT EXISTS id BIGINT, ADD COLUMN IF NOT EXISTS name TEXT;",
+			[]string{"id", "name"}, nil},
+		{"없는 컬럼 DROP COLUMN IF EXISTS", models.PostgreSQL,
+			"CREATE TABLE a (id INT);\nALTER TABLE a DROP COLUMN IF EXISTS missing;",
+			[]string{"id"}, nil},
+		{"MariaDB 형식의 ADD IF NOT EXISTS", models.MySQL,
+			"CREATE TABLE a (id INT);\nALTER TABLE a ADD IF NOT EXISTS id INT;",
+			[]string{"id"}, nil},
+		{"CREATE INDEX IF NOT EXISTS 두 번", models.MySQL,
+			"CREATE TABLE a (id INT);\nCREATE INDEX IF NOT EXISTS ix_a ON a (id);\nCREATE INDEX IF NOT EXISTS IX_A ON a (id);",
+			[]string{"id"}, []string{"ix_a"}},
+		{"없는 테이블 ALTER TABLE IF EXISTS", models.PostgreSQL,
+			"CREATE TABLE a (id INT);\nALTER TABLE IF EXISTS b ADD COLUMN x INT;",
+			[]string{"id"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := findTable(parseDDL(t, tt.ddl, tt.dbType), "a")
+			var columns, indexes []string
+			for _, c := range a.Columns {
+				columns = append(columns, c.Name)
+			}
+			for _, idx := range a.Indexes {
+				indexes = append(indexes, idx.Name)
+			}
+			if !reflect.DeepEqual(columns, tt.columns) {
+				t.Errorf("columns = %v, want %v", columns, tt.columns)
+			}
+			if !reflect.DeepEqual(indexes, tt.indexes) {
+				t.Errorf("indexes = %v, want %v", indexes, tt.indexes)
+			}
+		})
+	}
+
+	// ADD COLUMN IF NOT EXISTS는 기존 컬럼 정의를 바꾸지 않음
+	a := findTable(parseDDL(t, "CREATE TABLE a (id INT);\nALTER TABLE a ADD COLUMN IF NOT EXISTS id TEXT NOT NULL;", models.PostgreSQL), "a")
+	if a.Columns[0].Type != "INT" || !a.Columns[0].Nullable {
+		t.Errorf("id = %+v, want unchanged INT NULL", a.Columns[0])
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		ddl    string
+		pos    string
+		msg    string
+	}{
+		{"중복 테이블", models.PostgreSQL, "CREATE TABLE t (a INT);\nCREATE TABLE t (b INT);", "2:1", "이미 존재하는 테이블입니다: t"},
+		{"같은 스키마의 중복 테이블", models.PostgreSQL, "CREATE TABLE hr.t (a INT);\nCREATE TABLE hr.t (b INT);", "2:1", "이미 존재하는 테이블입니다: t"},
+		{"없는 테이블 삭제", models.MySQL, "CREATE TABLE t (a INT);\n  DROP TABLE u;", "2:3", "테이블을 찾을 수 없습니다: u"},
+		{"다른 스키마의 테이블 삭제", models.PostgreSQL, "CREATE TABLE sales.t (a INT);\nDROP TABLE hr.t;", "2:1", "테이블을 찾을 수 없습니다: t"},
+		{"없는 테이블 변경", models.SQLServer, "ALTER TABLE t ADD b INT", "1:1", "테이블을 찾을 수 없습니다: t"},
+		{"없는 인덱스 삭제", models.PostgreSQL, "CREATE TABLE t (a INT);\nDROP INDEX ix;", "2:1", "인덱스를 찾을 수 없습니다: ix"},
+		{"중복 컬럼", models.PostgreSQL, "CREATE TABLE t (a INT);\nALTER TABLE t ADD COLUMN a INT;", "2:15", "이미 존재하는 컬럼입니다: t.a"},
+		{"없는 컬럼 삭제", models.Oracle, "CREATE TABLE t (a NUMBER)\n/\nALTER TABLE t DROP COLUMN b\n/", "3:15", "컬럼을 찾을 수 없습니다: t.b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser().ParseDDL(tt.ddl, tt.dbType)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseDDL() error = %v, want *ParseError", err)
+			}
+			if perr.Pos.String() != tt.pos || perr.Msg != tt.msg {
+				t.Errorf("error = %s %q, want %s %q", perr.Pos, perr.Msg, tt.pos, tt.msg)
+			}
+		})
+	}
+}
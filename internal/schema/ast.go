@@ -0,0 +1,177 @@
+package schema
+
+// Statement 파싱된 DDL 문
+type Statement interface {
+	Position() Pos
+}
+
+// CreateTableStmt CREATE TABLE 문
+type CreateTableStmt struct {
+	Pos         Pos
+	Name        ObjectName
+	IfNotExists bool
+	Columns     []ColumnDef
+	Constraints []TableConstraint
+}
+
+// CreateIndexStmt CREATE INDEX 문
+type CreateIndexStmt struct {
+	Pos         Pos
+	Name        string
+	Table       ObjectName
+	IfNotExists bool
+	Unique      bool
+	Type        string // BTREE, HASH, FULLTEXT 등
+	Columns     []string
+}
+
+// AlterTableStmt ALTER TABLE 문
+type AlterTableStmt struct {
+	Pos      Pos
+	Table    ObjectName
+	IfExists bool
+	Actions  []AlterAction
+}
+
+// DropTableStmt DROP TABLE 문
+type DropTableStmt struct {
+	Pos      Pos
+	Names    []ObjectName
+	IfExists bool
+}
+
+// DropIndexStmt DROP INDEX 문
+type DropIndexStmt struct {
+	Pos      Pos
+	Schema   string // PostgreSQL/Oracle의 schema.index 표기 (없으면 비어 있음)
+	Name     string
+	Table    ObjectName // MySQL/SQL Server의 ON 절 또는 table.index 표기 (없으면 비어 있음)
+	IfExists bool
+}
+
+// CreateTypeStmt CREATE TYPE ... AS ENUM 문 (PostgreSQL)
+type CreateTypeStmt struct {
+	Pos    Pos
+	Name   ObjectName
+	Values []string
+}
+
+// CreateDomainStmt CREATE DOMAIN 문 (PostgreSQL)
+type CreateDomainStmt struct {
+	Pos  Pos
+	Name ObjectName
+	Def  ColumnDef // 기본 타입과 DEFAULT, NOT NULL, CHECK (컬럼 이름은 VALUE)
+}
+
+// AlterTypeStmt ALTER TYPE ... ADD VALUE / RENAME VALUE 문 (PostgreSQL enum)
+type AlterTypeStmt struct {
+	Pos      Pos
+	Name     ObjectName
+	Value    string // 추가하거나 이름을 바꿀 값
+	NewValue string // RENAME VALUE 결과 값
+	Before   string // ADD VALUE ... BEFORE
+	After    string // ADD VALUE ... AFTER
+}
+
+// DropTypeStmt DROP TYPE / DROP DOMAIN 문
+type DropTypeStmt struct {
+	Pos      Pos
+	Names    []ObjectName
+	IfExists bool
+}
+
+func (s *CreateTableStmt) Position() Pos  { return s.Pos }
+func (s *CreateIndexStmt) Position() Pos  { return s.Pos }
+func (s *AlterTableStmt) Position() Pos   { return s.Pos }
+func (s *DropTableStmt) Position() Pos    { return s.Pos }
+func (s *DropIndexStmt) Position() Pos    { return s.Pos }
+func (s *CreateTypeStmt) Position() Pos   { return s.Pos }
+func (s *CreateDomainStmt) Position() Pos { return s.Pos }
+func (s *AlterTypeStmt) Position() Pos    { return s.Pos }
+func (s *DropTypeStmt) Position() Pos     { return s.Pos }
+
+// ObjectName 스키마로 한정될 수 있는 객체 이름 (schema.table)
+type ObjectName struct {
+	Schema string
+	Name   string
+}
+
+// ColumnDef 컬럼 정의
+type ColumnDef struct {
+	Pos        Pos
+	Name       string
+	Type       string // 원본 타입 표기 (예: DECIMAL(10,2))
+	NotNull    bool
+	Null       bool // 명시적 NULL
+	Default    string
+	HasDefault bool
+	PrimaryKey bool
+	Unique     bool
+	AutoIncr   bool
+	Comment    string
+	References *TableConstraint  // 컬럼 수준 REFERENCES
+	Checks     []TableConstraint // 컬럼 수준 CHECK
+	Collation  string
+	Generated  string // 계산 컬럼 표현식 원문 (괄호 포함)
+	Stored     bool   // STORED / PERSISTED
+}
+
+// ConstraintKind 테이블 제약조건 종류
+type ConstraintKind int
+
+const (
+	ConstraintPrimaryKey ConstraintKind = iota
+	ConstraintUnique
+	ConstraintForeignKey
+	ConstraintCheck
+	ConstraintIndex   // MySQL의 INDEX/KEY 정의
+	ConstraintDefault // SQL Server의 DEFAULT ... FOR col
+)
+
+// TableConstraint 테이블 수준 제약조건
+type TableConstraint struct {
+	Pos        Pos
+	Kind       ConstraintKind
+	Name       string
+	Columns    []string
+	RefTable   ObjectName
+	RefColumns []string
+	OnDelete   string
+	OnUpdate   string
+	IndexType  string // ConstraintIndex: BTREE, FULLTEXT, SPATIAL
+	Expr       string // ConstraintCheck/ConstraintDefault: 표현식 원문
+}
+
+// AlterKind ALTER TABLE 동작 종류
+type AlterKind int
+
+const (
+	AlterAddColumn AlterKind = iota
+	AlterDropColumn
+	AlterModifyColumn // MODIFY / ALTER COLUMN / CHANGE
+	AlterRenameColumn
+	AlterRenameTable
+	AlterAddConstraint
+	AlterDropConstraint
+	AlterDropPrimaryKey
+	AlterDropIndex
+	AlterOther // 스키마 모델에 영향이 없는 동작
+)
+
+// AlterAction ALTER TABLE 내 개별 동작
+type AlterAction struct {
+	Pos        Pos
+	Kind       AlterKind
+	Column     *ColumnDef       // ADD/MODIFY COLUMN
+	Name       string           // DROP/RENAME 대상
+	NewName    string           // RENAME 결과 이름
+	Constraint *TableConstraint // ADD CONSTRAINT
+
+	IfNotExists bool // ADD COLUMN IF NOT EXISTS
+	IfExists    bool // DROP COLUMN/CONSTRAINT/INDEX IF EXISTS
+
+	// ALTER COLUMN 부분 변경 (PostgreSQL 스타일)
+	SetNotNull  bool
+	DropNotNull bool
+	DropDefault bool
+}
@@ -0,0 +1,226 @@
+package schema
+
+import (
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// AddCheck CHECK 제약조건을 테이블 모델에 추가
+// 참조 컬럼이 비어 있으면 표현식에서 찾고, 컬럼 하나를 상수 목록과 비교하는 형태
+// (col IN ('a', 'b'), col = 'a' OR col = 'b', PostgreSQL의 col = ANY (ARRAY[...]))이면
+// 값 목록을 그 컬럼의 EnumValues로 채웁니다 (이미 값이 있는 ENUM 컬럼은 그대로).
+func AddCheck(table *models.Table, check models.Check, dbType models.DBType) {
+	check.Expression = CheckExpression(check.Expression)
+
+	if tokens, err := tokenize(check.Expression, dbType); err == nil {
+		if len(check.Columns) == 0 {
+			check.Columns = checkColumns(table, tokens)
+		}
+		if len(check.Columns) == 1 {
+			if col := findColumn(table, check.Columns[0]); col != nil && len(col.EnumValues) == 0 {
+				col.EnumValues = checkValues(tokens, col.Name)
+			}
+		}
+	}
+	table.Checks = append(table.Checks, check)
+}
+
+// CheckValues 표현식이 name을 상수 목록과 비교하는 형태면 그 값 목록 (도메인은 name에 VALUE)
+func CheckValues(expr, name string, dbType models.DBType) []string {
+	tokens, err := tokenize(CheckExpression(expr), dbType)
+	if err != nil {
+		return nil
+	}
+	return checkValues(tokens, name)
+}
+
+// CheckExpression 앞의 CHECK 키워드와 표현식 전체를 감싼 괄호를 벗겨냄
+// (카탈로그마다 CHECK ((a > 0)), ((a > 0)), a > 0 등 표기가 달라 같은 형태로 맞춤)
+func CheckExpression(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if len(expr) > 5 && strings.EqualFold(expr[:5], "CHECK") && (expr[5] == ' ' || expr[5] == '(') {
+		expr = strings.TrimSpace(expr[5:])
+	}
+	return TrimParens(expr)
+}
+
+// JoinChecks 여러 CHECK 표현식을 AND로 묶음 (각각 CheckExpression으로 정리)
+func JoinChecks(exprs []string) string {
+	if len(exprs) == 1 {
+		return CheckExpression(exprs[0])
+	}
+	parts := make([]string, len(exprs))
+	for i, expr := range exprs {
+		parts[i] = "(" + CheckExpression(expr) + ")"
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// TrimParens 표현식 전체를 감싼 괄호를 벗겨냄 ((a + b) → a + b, (a) + (b)는 그대로)
+func TrimParens(expr string) string {
+	expr = strings.TrimSpace(expr)
+	for strings.HasPrefix(expr, "(") && closingParen(expr) == len(expr)-1 {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	return expr
+}
+
+// EnumTypeValues MySQL ENUM('a', 'b') / SET(...) 타입의 값 목록 (다른 타입은 nil)
+func EnumTypeValues(typ string) []string {
+	tokens, err := tokenize(typ, models.MySQL)
+	if err != nil || len(tokens) < 2 {
+		return nil
+	}
+	if kw := tokens[0].upper(); (kw != "ENUM" && kw != "SET") || tokens[1].value != "(" {
+		return nil
+	}
+
+	var values []string
+	for _, tok := range tokens[2:] {
+		if tok.kind == tokString {
+			values = append(values, tok.value)
+		}
+	}
+	return values
+}
+
+// columnEnumValues 컬럼 타입이나 그 컬럼만 참조하는 CHECK에서 얻은 값 목록
+func columnEnumValues(table *models.Table, col *models.Column, dbType models.DBType) []string {
+	if values := EnumTypeValues(col.Type); values != nil {
+		return values
+	}
+	for _, c := range table.Checks {
+		if len(c.Columns) == 1 && strings.EqualFold(c.Columns[0], col.Name) {
+			if values := CheckValues(c.Expression, col.Name, dbType); values != nil {
+				return values
+			}
+		}
+	}
+	return nil
+}
+
+// renameIdent 표현식의 식별자 oldName을 newName으로 바꿈 (따옴표로 감싼 식별자는 따옴표 유지)
+func renameIdent(expr, oldName, newName string, dbType models.DBType) string {
+	tokens, err := tokenize(expr, dbType)
+	if err != nil {
+		return expr
+	}
+
+	src := []rune(expr)
+	var sb strings.Builder
+	last := 0
+	for _, tok := range tokens {
+		if (tok.kind != tokIdent && tok.kind != tokQuotedIdent) || !strings.EqualFold(tok.value, oldName) {
+			continue
+		}
+		from, to := tok.pos.Offset, tok.end
+		if tok.kind == tokQuotedIdent {
+			from++
+			to--
+		}
+		sb.WriteString(string(src[last:from]))
+		sb.WriteString(newName)
+		last = to
+	}
+	sb.WriteString(string(src[last:]))
+	return sb.String()
+}
+
+// closingParen expr[0]의 여는 괄호와 짝이 맞는 닫는 괄호 위치 (따옴표 안은 무시, 없으면 -1)
+func closingParen(expr string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// checkColumns 표현식에서 테이블 컬럼을 가리키는 식별자 (함수 이름과 타입 변환 제외, 처음 나온 순서)
+func checkColumns(table *models.Table, tokens []token) []string {
+	var columns []string
+	for i, tok := range tokens {
+		if tok.kind != tokIdent && tok.kind != tokQuotedIdent {
+			continue
+		}
+		if i+1 < len(tokens) && tokens[i+1].kind == tokPunct && tokens[i+1].value == "(" {
+			continue
+		}
+		if i > 0 && tokens[i-1].kind == tokPunct && tokens[i-1].value == "::" {
+			continue
+		}
+		col := findColumn(table, tok.value)
+		if col != nil && !containsName(columns, col.Name) {
+			columns = append(columns, col.Name)
+		}
+	}
+	return columns
+}
+
+// checkValues name과 상수 목록만으로 이루어진 비교식이면 상수 값 목록 (아니면 nil)
+// 허용하는 형태: IN (...), = ... OR = ..., = ANY (ARRAY[...]), 형 변환(::type), MySQL 문자 집합 접두어(_utf8mb4'a')
+func checkValues(tokens []token, name string) []string {
+	var values []string
+	referenced := false
+	inCast := false
+
+	for _, tok := range tokens {
+		if inCast {
+			// ::character varying, ::text[] 의 타입 이름
+			if tok.kind == tokIdent || (tok.kind == tokPunct && (tok.value == "[" || tok.value == "]")) {
+				continue
+			}
+			inCast = false
+		}
+
+		switch tok.kind {
+		case tokEOF:
+			continue
+		case tokString, tokNumber:
+			values = append(values, tok.value)
+		case tokQuotedIdent:
+			if !strings.EqualFold(tok.value, name) {
+				return nil
+			}
+			referenced = true
+		case tokIdent:
+			switch upper := tok.upper(); {
+			case strings.EqualFold(tok.value, name):
+				referenced = true
+			case upper == "IN" || upper == "OR" || upper == "ANY" || upper == "ARRAY":
+			case strings.HasPrefix(tok.value, "_"):
+				// MySQL 문자 집합 접두어
+			default:
+				return nil
+			}
+		case tokPunct:
+			switch tok.value {
+			case "::":
+				inCast = true
+			case "(", ")", ",", "=", "[", "]":
+			default:
+				return nil
+			}
+		}
+	}
+
+	if !referenced || len(values) == 0 {
+		return nil
+	}
+	return values
+}
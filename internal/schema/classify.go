@@ -0,0 +1,244 @@
+package schema
+
+import (
+	"fmt"
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// StatementKind 실행 정책 판단을 위한 SQL 문 분류
+type StatementKind string
+
+const (
+	StatementRead  StatementKind = "read"  // SELECT, SHOW, DESCRIBE, EXPLAIN 등 조회
+	StatementDML   StatementKind = "dml"   // INSERT, UPDATE, DELETE, MERGE, SELECT ... FOR UPDATE
+	StatementDDL   StatementKind = "ddl"   // CREATE, ALTER, DROP, TRUNCATE, GRANT, SELECT INTO 등
+	StatementOther StatementKind = "other" // CALL, EXEC, SET, BEGIN 등 영향을 알 수 없는 문장
+	StatementMulti StatementKind = "multi" // 여러 문장
+)
+
+// Classification SQL 분류 결과
+type Classification struct {
+	Kind        StatementKind   `json:"kind"`         // 전체 분류 (문장이 여러 개면 multi)
+	Statements  []StatementKind `json:"statements"`   // 문장별 분류
+	Command     string          `json:"command"`      // 첫 문장의 첫 키워드 (INSERT, SELECT 등)
+	ReturnsRows bool            `json:"returns_rows"` // 결과 집합을 반환하는지 (조회, RETURNING/OUTPUT, CALL/EXEC, 여러 문장)
+	Block       bool            `json:"block"`        // Oracle PL/SQL 블록·프로시저 정의 (내부 ';'까지 한 문장)
+}
+
+var dmlStarts = toSet(`INSERT UPDATE DELETE MERGE REPLACE UPSERT`)
+
+var readStarts = toSet(`SELECT WITH VALUES TABLE SHOW DESCRIBE DESC`)
+
+// Classify 쿼리를 문장 단위로 나눠 조회/DML/DDL/기타로 분류
+// 문자열과 주석은 렉서가 처리하므로 그 안의 키워드에 속지 않습니다.
+func (p *Parser) Classify(query string, dbType models.DBType) (*Classification, error) {
+	tokens, err := tokenize(query, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("SQL 분석 실패: %w", err)
+	}
+
+	stmts := splitTokenStatements(tokens)
+	if len(stmts) == 0 {
+		return nil, fmt.Errorf("SQL 쿼리가 비어 있습니다")
+	}
+
+	c := &Classification{}
+	for _, stmt := range stmts {
+		c.Statements = append(c.Statements, classifyStatement(stmt))
+	}
+	c.Kind = c.Statements[0]
+	c.Command = stmts[0][0].upper()
+	c.Block = dbType == models.Oracle && isPLSQLBlock(stmts[0])
+	c.ReturnsRows = returnsRows(stmts[0], c.Kind, dbType)
+	if len(stmts) > 1 {
+		c.Kind = StatementMulti
+		c.ReturnsRows = !c.Block // PL/SQL 블록은 결과 집합 없이 실행
+	}
+	return c, nil
+}
+
+// returnsRows 문장이 결과 집합을 반환하는지 (QueryContext/ExecContext 선택용)
+func returnsRows(stmt []token, kind StatementKind, dbType models.DBType) bool {
+	first := stmt[0].upper()
+	switch {
+	case first == "EXPLAIN", readStarts[first], stmt[0].kind == tokPunct && stmt[0].value == "(":
+		return true // SELECT ... FOR UPDATE, 데이터 변경 CTE 포함
+	case kind == StatementRead:
+		return true // PRAGMA 조회
+	case kind == StatementDML:
+		depth := 0
+		for _, tok := range stmt {
+			if tok.kind == tokPunct {
+				switch tok.value {
+				case "(":
+					depth++
+				case ")":
+					depth--
+				}
+				continue
+			}
+			if depth != 0 {
+				continue
+			}
+			switch tok.upper() {
+			case "RETURNING":
+				return dbType != models.Oracle // Oracle RETURNING INTO는 바인드 변수로 반환
+			case "OUTPUT":
+				return dbType == models.SQLServer
+			}
+		}
+	case kind == StatementOther:
+		switch first {
+		case "CALL":
+			return dbType == models.MySQL
+		case "EXEC", "EXECUTE":
+			return dbType == models.SQLServer
+		}
+	}
+	return false
+}
+
+// isPLSQLBlock BEGIN/DECLARE 블록 또는 CREATE [OR REPLACE] PROCEDURE/FUNCTION/TRIGGER/PACKAGE/TYPE
+func isPLSQLBlock(stmt []token) bool {
+	switch stmt[0].upper() {
+	case "BEGIN", "DECLARE":
+		return true
+	case "CREATE":
+		for _, tok := range stmt[1:] {
+			switch tok.upper() {
+			case "OR", "REPLACE", "EDITIONABLE", "NONEDITIONABLE":
+				continue
+			case "PROCEDURE", "FUNCTION", "TRIGGER", "PACKAGE", "TYPE":
+				return true
+			}
+			return false
+		}
+	}
+	return false
+}
+
+// SplitStatements 쿼리를 문장별 원문으로 나눔 (문장 끝 ';'과 앞뒤 주석 제외)
+func (p *Parser) SplitStatements(query string, dbType models.DBType) ([]string, error) {
+	tokens, err := tokenize(query, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("SQL 분석 실패: %w", err)
+	}
+	src := []rune(query)
+
+	var result []string
+	for _, stmt := range splitTokenStatements(tokens) {
+		result = append(result, string(src[stmt[0].pos.Offset:stmt[len(stmt)-1].end]))
+	}
+	return result, nil
+}
+
+// SplitBatches SQL Server 스크립트를 GO 구분자 기준 배치로 나눔 (다른 DB는 쿼리 전체 하나)
+func (p *Parser) SplitBatches(query string, dbType models.DBType) ([]string, error) {
+	if dbType != models.SQLServer {
+		return []string{query}, nil
+	}
+	tokens, err := tokenize(query, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("SQL 분석 실패: %w", err)
+	}
+	src := []rune(query)
+
+	var batches []string
+	start := 0
+	for _, tok := range tokens {
+		// 렉서는 줄 시작의 GO를 ';'로 바꿈
+		isGo := tok.kind == tokPunct && tok.value == ";" && strings.EqualFold(string(src[tok.pos.Offset:tok.end]), "GO")
+		if !isGo && tok.kind != tokEOF {
+			continue
+		}
+		end := tok.pos.Offset
+		if tok.kind == tokEOF {
+			end = len(src)
+		}
+		if batch := strings.TrimSpace(string(src[start:end])); batch != "" {
+			batches = append(batches, batch)
+		}
+		start = tok.end
+	}
+	return batches, nil
+}
+
+func classifyStatement(stmt []token) StatementKind {
+	first := stmt[0].upper()
+	switch {
+	case ddlStarts[first]:
+		return StatementDDL
+	case dmlStarts[first]:
+		return StatementDML
+	case first == "EXPLAIN":
+		// EXPLAIN ANALYZE는 문장을 실제로 실행
+		for _, tok := range stmt[1:] {
+			if tok.upper() == "ANALYZE" {
+				if inner := explainTarget(stmt); inner != nil {
+					return classifyStatement(inner)
+				}
+				return StatementOther
+			}
+		}
+		return StatementRead
+	case first == "PRAGMA":
+		for _, tok := range stmt {
+			if tok.kind == tokPunct && tok.value == "=" {
+				return StatementOther
+			}
+		}
+		return StatementRead
+	case readStarts[first] || (stmt[0].kind == tokPunct && stmt[0].value == "("):
+		return classifyRead(stmt)
+	}
+	return StatementOther
+}
+
+// classifyRead 조회로 시작하지만 데이터를 바꿀 수 있는 문장 확인
+// (데이터 변경 CTE, SELECT ... INTO, FOR UPDATE 잠금)
+func classifyRead(stmt []token) StatementKind {
+	depth := 0
+	kind := StatementRead
+	for i, tok := range stmt {
+		if tok.kind == tokPunct {
+			switch tok.value {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+			continue
+		}
+
+		prev := ""
+		if i > 0 {
+			prev = stmt[i-1].upper()
+		}
+		switch tok.upper() {
+		case "INSERT", "UPDATE", "DELETE", "MERGE":
+			// WITH d AS (DELETE ... RETURNING *) 또는 SELECT ... FOR UPDATE
+			kind = StatementDML
+		case "SHARE":
+			if prev == "FOR" {
+				kind = StatementDML // SELECT ... FOR SHARE 행 잠금
+			}
+		case "INTO":
+			if depth == 0 {
+				return StatementDDL // SELECT ... INTO new_table, INTO OUTFILE 등
+			}
+		}
+	}
+	return kind
+}
+
+// explainTarget EXPLAIN [ANALYZE] [옵션] 뒤의 실제 문장
+func explainTarget(stmt []token) []token {
+	for i := 1; i < len(stmt); i++ {
+		up := stmt[i].upper()
+		if readStarts[up] || dmlStarts[up] || ddlStarts[up] {
+			return stmt[i:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package schema
+
+import (
+	"reflect"
+	"sql-genius/pkg/models"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name        string
+		dbType      models.DBType
+		query       string
+		kind        StatementKind
+		command     string
+		returnsRows bool
+	}{
+		{"조회", models.PostgreSQL, "SELECT * FROM t", StatementRead, "SELECT", true},
+		{"괄호로 시작하는 조회", models.PostgreSQL, "(SELECT 1) UNION (SELECT 2)", StatementRead, "", true},
+		{"CTE 조회", models.PostgreSQL, "WITH x AS (SELECT 1) SELECT * FROM x", StatementRead, "WITH", true},
+		{"끝의 세미콜론과 주석", models.PostgreSQL, "SELECT 1; -- 끝\n", StatementRead, "SELECT", true},
+		{"문자열 안의 키워드", models.PostgreSQL, "SELECT 'DELETE FROM t; DROP TABLE t'", StatementRead, "SELECT", true},
+		{"주석 안의 키워드", models.PostgreSQL, "SELECT 1 /* ; DROP TABLE t */", StatementRead, "SELECT", true},
+		{"SHOW", models.MySQL, "SHOW TABLES", StatementRead, "SHOW", true},
+		{"EXPLAIN", models.PostgreSQL, "EXPLAIN SELECT 1", StatementRead, "EXPLAIN", true},
+		{"EXPLAIN ANALYZE DELETE", models.PostgreSQL, "EXPLAIN ANALYZE DELETE FROM t", StatementDML, "EXPLAIN", true},
+		{"PRAGMA 조회", models.SQLite, "PRAGMA table_info(t)", StatementRead, "PRAGMA", true},
+		{"PRAGMA 설정", models.SQLite, "PRAGMA foreign_keys = ON", StatementOther, "PRAGMA", false},
+		{"INSERT", models.PostgreSQL, "INSERT INTO t VALUES (1)", StatementDML, "INSERT", false},
+		{"INSERT RETURNING", models.PostgreSQL, "INSERT INTO t VALUES (1) RETURNING id", StatementDML, "INSERT", true},
+		{"서브쿼리 안의 RETURNING은 무시", models.PostgreSQL, "UPDATE t SET a = (SELECT returning FROM u)", StatementDML, "UPDATE", false},
+		{"Oracle RETURNING INTO", models.Oracle, "INSERT INTO t VALUES (1) RETURNING id INTO :id", StatementDML, "INSERT", false},
+		{"SQL Server OUTPUT", models.SQLServer, "DELETE FROM t OUTPUT deleted.id", StatementDML, "DELETE", true},
+		{"데이터 변경 CTE", models.PostgreSQL, "WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d", StatementDML, "WITH", true},
+		{"FOR UPDATE", models.PostgreSQL, "SELECT * FROM t FOR UPDATE", StatementDML, "SELECT", true},
+		{"FOR SHARE", models.PostgreSQL, "SELECT * FROM t FOR SHARE", StatementDML, "SELECT", true},
+		{"SELECT INTO", models.SQLServer, "SELECT * INTO t2 FROM t", StatementDDL, "SELECT", true},
+		{"서브쿼리 안의 INTO는 무시", models.PostgreSQL, "SELECT (SELECT 1) AS into_x FROM t", StatementRead, "SELECT", true},
+		{"CREATE", models.PostgreSQL, "CREATE TABLE t (a INT)", StatementDDL, "CREATE", false},
+		{"TRUNCATE", models.MySQL, "TRUNCATE TABLE t", StatementDDL, "TRUNCATE", false},
+		{"GRANT", models.PostgreSQL, "GRANT SELECT ON t TO u", StatementDDL, "GRANT", false},
+		{"MySQL CALL", models.MySQL, "CALL p()", StatementOther, "CALL", true},
+		{"SQL Server EXEC", models.SQLServer, "EXEC sp_who", StatementOther, "EXEC", true},
+		{"SET", models.PostgreSQL, "SET search_path = x", StatementOther, "SET", false},
+		{"여러 문장", models.PostgreSQL, "SELECT 1; SELECT 2", StatementMulti, "SELECT", true},
+		{"PostgreSQL --1은 주석", models.PostgreSQL, "SELECT 1 --1; DROP TABLE t", StatementRead, "SELECT", true},
+		{"MySQL -- 주석", models.MySQL, "SELECT 1 -- ; DROP TABLE t", StatementRead, "SELECT", true},
+		{"MySQL # 주석", models.MySQL, "SELECT 1 # ; DROP TABLE t", StatementRead, "SELECT", true},
+		{"MySQL --1 우회", models.MySQL, "SELECT 1 --1; DROP TABLE t", StatementMulti, "SELECT", true},
+		{"MySQL 실행 주석 우회", models.MySQL, "SELECT 1 /*!; DROP TABLE t */", StatementMulti, "SELECT", true},
+		{"MySQL 버전 실행 주석 우회", models.MySQL, "SELECT 1 /*!50000 ; DELETE FROM t */", StatementMulti, "SELECT", true},
+		{"MySQL 실행 주석 INTO OUTFILE", models.MySQL, "SELECT * FROM t /*! INTO OUTFILE '/tmp/x' */", StatementDDL, "SELECT", true},
+		{"MySQL 옵티마이저 힌트", models.MySQL, "SELECT /*+ MAX_EXECUTION_TIME(10) */ * FROM t", StatementRead, "SELECT", true},
+		{"MySQL 일반 주석", models.MySQL, "SELECT 1 /* ; DROP TABLE t */", StatementRead, "SELECT", true},
+		{"SQL Server GO", models.SQLServer, "SELECT 1\nGO\nSELECT 2", StatementMulti, "SELECT", true},
+		{"Oracle PL/SQL 블록", models.Oracle, "BEGIN UPDATE t SET a = 1; END;", StatementMulti, "BEGIN", false},
+	}
+
+	p := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := p.Classify(tt.query, tt.dbType)
+			if err != nil {
+				t.Fatalf("Classify() error = %v", err)
+			}
+			if c.Kind != tt.kind || c.Command != tt.command || c.ReturnsRows != tt.returnsRows {
+				t.Errorf("Classify() = {%s %q rows=%v}, want {%s %q rows=%v}",
+					c.Kind, c.Command, c.ReturnsRows, tt.kind, tt.command, tt.returnsRows)
+			}
+		})
+	}
+}
+
+func TestClassifyStatements(t *testing.T) {
+	c, err := NewParser().Classify("SELECT 1; UPDATE t SET a = 1; DROP TABLE t", models.PostgreSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []StatementKind{StatementRead, StatementDML, StatementDDL}
+	if !reflect.DeepEqual(c.Statements, want) {
+		t.Errorf("Statements = %v, want %v", c.Statements, want)
+	}
+}
+
+func TestClassifyBlock(t *testing.T) {
+	tests := []struct {
+		query string
+		block bool
+	}{
+		{"BEGIN NULL; END;", true},
+		{"DECLARE x NUMBER; BEGIN NULL; END;", true},
+		{"CREATE OR REPLACE PROCEDURE p AS BEGIN NULL; END;", true},
+		{"CREATE TABLE t (a NUMBER)", false},
+		{"SELECT 1 FROM dual", false},
+	}
+
+	p := NewParser()
+	for _, tt := range tests {
+		c, err := p.Classify(tt.query, models.Oracle)
+		if err != nil {
+			t.Fatalf("Classify(%q) error = %v", tt.query, err)
+		}
+		if c.Block != tt.block {
+			t.Errorf("Classify(%q).Block = %v, want %v", tt.query, c.Block, tt.block)
+		}
+	}
+}
+
+func TestClassifyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		query  string
+	}{
+		{"빈 쿼리", models.PostgreSQL, "  -- 주석만\n;"},
+		{"닫히지 않은 문자열", models.PostgreSQL, "SELECT 'a"},
+		{"닫히지 않은 실행 주석", models.MySQL, "SELECT 1 /*! ; DROP TABLE t"},
+	}
+
+	p := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := p.Classify(tt.query, tt.dbType); err == nil {
+				t.Errorf("Classify() = %+v, want error", c)
+			}
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		query  string
+		want   []string
+	}{
+		{"세미콜론", models.PostgreSQL, "SELECT 1; SELECT 'a;b';", []string{"SELECT 1", "SELECT 'a;b'"}},
+		{"앞뒤 주석 제외", models.PostgreSQL, "-- 머리\nSELECT 1 /* 꼬리 */;\n/* x */ SELECT 2", []string{"SELECT 1", "SELECT 2"}},
+		{"달러 인용 안의 세미콜론", models.PostgreSQL, "DO $$ BEGIN PERFORM 1; END $$; SELECT 1",
+			[]string{"DO $$ BEGIN PERFORM 1; END $$", "SELECT 1"}},
+		{"MySQL 실행 주석 본문", models.MySQL, "SELECT 1 /*!; SELECT 2 */", []string{"SELECT 1", "SELECT 2"}},
+	}
+
+	p := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.SplitStatements(tt.query, tt.dbType)
+			if err != nil {
+				t.Fatalf("SplitStatements() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitBatches(t *testing.T) {
+	got, err := NewParser().SplitBatches("SELECT 1\nGO\nSELECT 'GO'\ngo\n", models.SQLServer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"SELECT 1", "SELECT 'GO'"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitBatches() = %q, want %q", got, want)
+	}
+}
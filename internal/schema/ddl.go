@@ -0,0 +1,1518 @@
+package schema
+
+import (
+	"fmt"
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// ddlParser 토큰 스트림을 DDL 구문 트리로 변환하는 재귀 하향 파서
+type ddlParser struct {
+	src    []rune
+	tokens []token
+	pos    int
+	dbType models.DBType
+}
+
+// columnStopWords 컬럼 타입/기본값 표현식이 끝나는 키워드
+var columnStopWords = map[string]bool{
+	"CONSTRAINT": true, "NOT": true, "NULL": true, "DEFAULT": true,
+	"PRIMARY": true, "UNIQUE": true, "KEY": true, "REFERENCES": true,
+	"CHECK": true, "AUTO_INCREMENT": true, "AUTOINCREMENT": true,
+	"IDENTITY": true, "GENERATED": true, "AS": true, "COMMENT": true,
+	"COLLATE": true, "CHARSET": true, "ON": true, "ENABLE": true,
+	"DISABLE": true, "SPARSE": true, "ROWGUIDCOL": true, "FILESTREAM": true,
+	"VISIBLE": true, "INVISIBLE": true, "STORAGE": true, "COLUMN_FORMAT": true,
+	"PERSISTED": true, "STORED": true, "VIRTUAL": true, "FIRST": true,
+	"AFTER": true, "MASKED": true, "ENCRYPTED": true, "USING": true,
+}
+
+// tableConstraintStarts 테이블 수준 제약조건 시작 키워드
+var tableConstraintStarts = map[string]bool{
+	"CONSTRAINT": true, "PRIMARY": true, "FOREIGN": true, "UNIQUE": true,
+	"CHECK": true, "INDEX": true, "KEY": true, "FULLTEXT": true, "SPATIAL": true,
+}
+
+// ParseStatements DDL 스크립트를 구문 트리로 파싱
+// CREATE TABLE/INDEX, ALTER TABLE, DROP TABLE/INDEX와 PostgreSQL enum 타입/도메인 문 이외의 문은 건너뜁니다.
+func (p *Parser) ParseStatements(ddl string, dbType models.DBType) ([]Statement, error) {
+	tokens, err := tokenize(ddl, dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	dp := &ddlParser{
+		src:    []rune(ddl),
+		tokens: tokens,
+		dbType: dbType,
+	}
+	return dp.parseScript()
+}
+
+func (p *ddlParser) parseScript() ([]Statement, error) {
+	var stmts []Statement
+	for p.cur().kind != tokEOF {
+		if p.acceptPunct(";") {
+			continue
+		}
+
+		stmt, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		if stmt != nil {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts, nil
+}
+
+func (p *ddlParser) parseStatement() (Statement, error) {
+	switch p.cur().upper() {
+	case "CREATE":
+		switch p.createTarget() {
+		case "TABLE":
+			return p.parseCreateTable()
+		case "INDEX":
+			return p.parseCreateIndex()
+		case "TYPE":
+			return p.parseCreateType()
+		case "DOMAIN":
+			return p.parseCreateDomain()
+		}
+	case "ALTER":
+		switch p.peekToken(1).upper() {
+		case "TABLE":
+			return p.parseAlterTable()
+		case "TYPE":
+			return p.parseAlterType()
+		}
+	case "DROP":
+		switch p.peekToken(1).upper() {
+		case "TABLE":
+			return p.parseDropTable()
+		case "INDEX":
+			return p.parseDropIndex()
+		case "TYPE", "DOMAIN":
+			return p.parseDropType()
+		}
+	}
+
+	// 스키마 모델과 무관한 문 (INSERT, CREATE VIEW 등)
+	p.next()
+	p.skipStatement()
+	return nil, nil
+}
+
+// createTarget CREATE 뒤 수식어를 건너뛰고 생성 대상(TABLE, INDEX 등) 반환
+func (p *ddlParser) createTarget() string {
+	for i := 1; ; i++ {
+		switch kw := p.peekToken(i).upper(); kw {
+		case "OR", "REPLACE", "GLOBAL", "LOCAL", "TEMPORARY", "TEMP", "UNLOGGED",
+			"UNIQUE", "CLUSTERED", "NONCLUSTERED", "FULLTEXT", "SPATIAL", "BITMAP":
+			continue
+		default:
+			return kw
+		}
+	}
+}
+
+// ─── CREATE TABLE ───────────────────────────────────────────
+
+func (p *ddlParser) parseCreateTable() (Statement, error) {
+	stmt := &CreateTableStmt{Pos: p.cur().pos}
+	for !p.acceptKeyword("TABLE") {
+		p.next()
+	}
+
+	if p.acceptKeyword("IF") {
+		if err := p.expectKeywords("NOT", "EXISTS"); err != nil {
+			return nil, err
+		}
+		stmt.IfNotExists = true
+	}
+
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = name
+
+	// CREATE TABLE ... AS SELECT, LIKE, PARTITION OF 등은 컬럼 정의가 없음
+	if !p.isPunct("(") {
+		p.skipStatement()
+		return nil, nil
+	}
+	p.next()
+
+	for {
+		switch {
+		case p.isKeyword("LIKE", "EXCLUDE", "PERIOD"):
+			p.skipToClauseEnd()
+		case p.isTableConstraintStart():
+			c, err := p.parseTableConstraint()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Constraints = append(stmt.Constraints, *c)
+		default:
+			col, err := p.parseColumnDef()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Columns = append(stmt.Columns, *col)
+		}
+
+		if p.acceptPunct(",") {
+			continue
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	// 테이블 옵션 (ENGINE=InnoDB, TABLESPACE, ON [PRIMARY] 등)
+	p.skipStatement()
+	return stmt, nil
+}
+
+func (p *ddlParser) isTableConstraintStart() bool {
+	return tableConstraintStarts[p.cur().upper()]
+}
+
+func (p *ddlParser) parseColumnDef() (*ColumnDef, error) {
+	col := &ColumnDef{Pos: p.cur().pos}
+
+	name, err := p.parseIdent("컬럼 이름")
+	if err != nil {
+		return nil, err
+	}
+	col.Name = name
+
+	if !p.atClauseEnd() && !p.isColumnStopWord() {
+		col.Type = p.parseType()
+	}
+
+	if err := p.parseColumnConstraints(col); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// parseType 컬럼 타입을 원문 그대로 읽음 (DECIMAL(10,2), TIMESTAMP WITH TIME ZONE, INT[] 등)
+func (p *ddlParser) parseType() string {
+	start := p.pos
+	p.next()
+
+	for !p.atClauseEnd() {
+		tok := p.cur()
+		switch {
+		case p.isPunct("("):
+			p.skipBalanced()
+		case p.isPunct("["):
+			// PostgreSQL 배열 타입
+			for !p.atEOF() && !p.isPunct("]") {
+				p.next()
+			}
+			p.next()
+		case p.isPunct("."):
+			p.next()
+			p.next()
+		case tok.kind == tokIdent && !p.isColumnStopWord():
+			p.next()
+		default:
+			return p.typeText(start, p.pos)
+		}
+	}
+	return p.typeText(start, p.pos)
+}
+
+// typeText 타입 원문. SQL Server의 [decimal](18,2) 처럼 따옴표로 감싼 타입 이름은 벗겨냄
+func (p *ddlParser) typeText(start, end int) string {
+	first := p.tokens[start]
+	if first.kind != tokQuotedIdent {
+		return p.rawText(start, end)
+	}
+	if end-start == 1 {
+		return first.value
+	}
+	rest := p.rawText(start+1, end)
+	if p.tokens[start+1].pos.Offset > first.end {
+		return first.value + " " + rest
+	}
+	return first.value + rest
+}
+
+func (p *ddlParser) isColumnStopWord() bool {
+	kw := p.cur().upper()
+	if kw == "CHARACTER" || kw == "CHAR" {
+		return p.peekToken(1).upper() == "SET"
+	}
+	return columnStopWords[kw]
+}
+
+func (p *ddlParser) parseColumnConstraints(col *ColumnDef) error {
+	var constraintName string
+
+	for !p.atClauseEnd() {
+		tok := p.cur()
+		switch tok.upper() {
+		case "CONSTRAINT":
+			p.next()
+			name, err := p.parseIdent("제약조건 이름")
+			if err != nil {
+				return err
+			}
+			constraintName = name
+			continue
+		case "NOT":
+			p.next()
+			if p.acceptKeyword("NULL") {
+				col.NotNull = true
+			} else {
+				// NOT DEFERRABLE, NOT FOR REPLICATION 등
+				p.next()
+			}
+		case "NULL":
+			p.next()
+			col.Null = true
+		case "DEFAULT":
+			p.next()
+			col.Default = p.parseDefault()
+			col.HasDefault = true
+		case "PRIMARY":
+			p.next()
+			if err := p.expectKeywords("KEY"); err != nil {
+				return err
+			}
+			col.PrimaryKey = true
+		case "KEY":
+			p.next()
+			col.PrimaryKey = true
+		case "UNIQUE":
+			p.next()
+			p.acceptKeyword("KEY")
+			col.Unique = true
+		case "REFERENCES":
+			ref := &TableConstraint{
+				Pos:     tok.pos,
+				Kind:    ConstraintForeignKey,
+				Name:    constraintName,
+				Columns: []string{col.Name},
+			}
+			if err := p.parseReferences(ref); err != nil {
+				return err
+			}
+			col.References = ref
+		case "CHECK":
+			p.next()
+			if err := p.expectPunctAhead("("); err != nil {
+				return err
+			}
+			start := p.pos
+			p.skipBalanced()
+			col.Checks = append(col.Checks, TableConstraint{
+				Pos:     tok.pos,
+				Kind:    ConstraintCheck,
+				Name:    constraintName,
+				Columns: []string{col.Name},
+				Expr:    p.rawText(start, p.pos),
+			})
+		case "AUTO_INCREMENT", "AUTOINCREMENT":
+			p.next()
+			col.AutoIncr = true
+		case "IDENTITY":
+			p.next()
+			col.AutoIncr = true
+			if p.isPunct("(") {
+				p.skipBalanced()
+			}
+		case "GENERATED":
+			p.next()
+			if p.acceptKeyword("BY") {
+				p.acceptKeyword("DEFAULT")
+			} else {
+				p.acceptKeyword("ALWAYS")
+			}
+			if err := p.expectKeywords("AS"); err != nil {
+				return err
+			}
+			if p.acceptKeyword("IDENTITY") {
+				col.AutoIncr = true
+				if p.isPunct("(") {
+					p.skipBalanced()
+				}
+			} else if p.isPunct("(") {
+				col.Generated = p.parseGenerated()
+			}
+		case "AS":
+			// 계산 컬럼 (SQL Server, MySQL 축약형)
+			p.next()
+			if p.isPunct("(") {
+				col.Generated = p.parseGenerated()
+			}
+		case "STORED", "PERSISTED":
+			p.next()
+			col.Stored = true
+		case "COMMENT":
+			p.next()
+			if c := p.cur(); c.kind == tokString || c.kind == tokQuotedIdent {
+				col.Comment = c.value
+				p.next()
+			}
+		case "COLLATE":
+			p.next()
+			if c := p.cur(); c.kind == tokString {
+				col.Collation = c.value
+				p.next()
+				break
+			}
+			name, err := p.parseObjectName()
+			if err != nil {
+				return err
+			}
+			col.Collation = models.QualifiedName(name.Schema, name.Name)
+		case "CHARSET":
+			p.next()
+			p.next()
+		case "CHARACTER", "CHAR":
+			// CHARACTER SET utf8mb4
+			p.next()
+			p.next()
+			p.next()
+		case "ON":
+			// MySQL ON UPDATE CURRENT_TIMESTAMP
+			p.next()
+			p.acceptKeyword("UPDATE")
+			p.parseDefault()
+		default:
+			if p.isPunct("(") {
+				p.skipBalanced()
+			} else {
+				p.next()
+			}
+		}
+		constraintName = ""
+	}
+	return nil
+}
+
+// parseGenerated 계산 컬럼의 (표현식)을 괄호를 포함한 원문 그대로 읽음
+func (p *ddlParser) parseGenerated() string {
+	start := p.pos
+	p.skipBalanced()
+	return p.rawText(start, p.pos)
+}
+
+// parseDefault 기본값 표현식을 원문 그대로 읽음
+func (p *ddlParser) parseDefault() string {
+	start := p.pos
+	if p.isPunct("(") {
+		p.skipBalanced()
+	} else {
+		p.next()
+	}
+
+	for !p.atClauseEnd() && !p.isColumnStopWord() {
+		if p.isPunct("(") {
+			p.skipBalanced()
+		} else {
+			p.next()
+		}
+	}
+	return p.rawText(start, p.pos)
+}
+
+func (p *ddlParser) parseTableConstraint() (*TableConstraint, error) {
+	c := &TableConstraint{Pos: p.cur().pos}
+
+	if p.acceptKeyword("CONSTRAINT") {
+		name, err := p.parseIdent("제약조건 이름")
+		if err != nil {
+			return nil, err
+		}
+		c.Name = name
+	}
+
+	var err error
+	switch kw := p.cur().upper(); kw {
+	case "PRIMARY":
+		p.next()
+		if err := p.expectKeywords("KEY"); err != nil {
+			return nil, err
+		}
+		c.Kind = ConstraintPrimaryKey
+		p.skipIndexModifiers()
+		c.Columns, err = p.parseIndexColumns()
+	case "UNIQUE":
+		p.next()
+		if !p.acceptKeyword("KEY") {
+			p.acceptKeyword("INDEX")
+		}
+		c.Kind = ConstraintUnique
+		p.skipIndexModifiers()
+		if name := p.optionalIndexName(); name != "" {
+			c.Name = name
+		}
+		p.skipIndexModifiers()
+		c.Columns, err = p.parseIndexColumns()
+	case "FOREIGN":
+		p.next()
+		if err := p.expectKeywords("KEY"); err != nil {
+			return nil, err
+		}
+		c.Kind = ConstraintForeignKey
+		if name := p.optionalIndexName(); name != "" && c.Name == "" {
+			c.Name = name
+		}
+		if c.Columns, err = p.parseIdentList(); err != nil {
+			return nil, err
+		}
+		err = p.parseReferences(c)
+	case "CHECK":
+		p.next()
+		c.Kind = ConstraintCheck
+		if err := p.expectPunctAhead("("); err != nil {
+			return nil, err
+		}
+		start := p.pos
+		p.skipBalanced()
+		c.Expr = p.rawText(start, p.pos)
+	case "INDEX", "KEY", "FULLTEXT", "SPATIAL":
+		p.next()
+		c.Kind = ConstraintIndex
+		c.IndexType = "BTREE"
+		if kw == "FULLTEXT" || kw == "SPATIAL" {
+			c.IndexType = kw
+			if !p.acceptKeyword("INDEX") {
+				p.acceptKeyword("KEY")
+			}
+		}
+		if name := p.optionalIndexName(); name != "" {
+			c.Name = name
+		}
+		p.skipIndexModifiers()
+		c.Columns, err = p.parseIndexColumns()
+	case "DEFAULT":
+		// SQL Server: CONSTRAINT DF_x DEFAULT (0) FOR col
+		p.next()
+		c.Kind = ConstraintDefault
+		start := p.pos
+		for !p.atClauseEnd() && !p.isKeyword("FOR") {
+			if p.isPunct("(") {
+				p.skipBalanced()
+			} else {
+				p.next()
+			}
+		}
+		c.Expr = p.rawText(start, p.pos)
+		if err := p.expectKeywords("FOR"); err != nil {
+			return nil, err
+		}
+		var col string
+		col, err = p.parseIdent("컬럼 이름")
+		c.Columns = []string{col}
+	default:
+		return nil, p.errorf("제약조건 정의가 필요합니다")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// USING INDEX, WITH (...), ON [PRIMARY], ENABLE, DEFERRABLE 등 옵션
+	p.skipToClauseEnd()
+	return c, nil
+}
+
+// skipIndexModifiers CLUSTERED/NONCLUSTERED, USING BTREE 등 건너뛰기
+func (p *ddlParser) skipIndexModifiers() {
+	for {
+		switch p.cur().upper() {
+		case "CLUSTERED", "NONCLUSTERED":
+			p.next()
+		case "USING":
+			p.next()
+			p.next()
+		default:
+			return
+		}
+	}
+}
+
+// optionalIndexName 컬럼 목록 앞의 선택적 인덱스 이름
+func (p *ddlParser) optionalIndexName() string {
+	tok := p.cur()
+	if tok.kind == tokQuotedIdent || (tok.kind == tokIdent && tok.upper() != "USING") {
+		p.next()
+		return tok.value
+	}
+	return ""
+}
+
+// parseReferences REFERENCES table (cols) [ON DELETE ...] [ON UPDATE ...]
+func (p *ddlParser) parseReferences(c *TableConstraint) error {
+	if err := p.expectKeywords("REFERENCES"); err != nil {
+		return err
+	}
+
+	ref, err := p.parseObjectName()
+	if err != nil {
+		return err
+	}
+	c.RefTable = ref
+
+	if p.isPunct("(") {
+		if c.RefColumns, err = p.parseIdentList(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		switch {
+		case p.isKeyword("ON") && (p.peekToken(1).upper() == "DELETE" || p.peekToken(1).upper() == "UPDATE"):
+			p.next()
+			event := p.next().upper()
+			action := p.parseReferentialAction()
+			if event == "DELETE" {
+				c.OnDelete = action
+			} else {
+				c.OnUpdate = action
+			}
+		case p.isKeyword("MATCH"):
+			p.next()
+			p.next()
+		default:
+			return nil
+		}
+	}
+}
+
+func (p *ddlParser) parseReferentialAction() string {
+	switch p.cur().upper() {
+	case "NO", "SET":
+		first := p.next().upper()
+		return first + " " + p.next().upper()
+	default:
+		return p.next().upper()
+	}
+}
+
+// parseIndexColumns 인덱스/키 컬럼 목록. ASC/DESC, 접두 길이, 연산자 클래스는 제거하고
+// 표현식 인덱스는 원문을 그대로 사용
+func (p *ddlParser) parseIndexColumns() ([]string, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	var cols []string
+	for {
+		start := p.pos
+		for !p.atEOF() && !p.isPunct(",") && !p.isPunct(")") && !p.isPunct(";") {
+			if p.isPunct("(") {
+				p.skipBalanced()
+			} else {
+				p.next()
+			}
+		}
+		if p.pos == start {
+			return nil, p.errorf("컬럼 이름이 필요합니다")
+		}
+		cols = append(cols, p.indexColumnName(start, p.pos))
+
+		if p.acceptPunct(",") {
+			continue
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return cols, nil
+	}
+}
+
+func (p *ddlParser) indexColumnName(start, end int) string {
+	first := p.tokens[start]
+	if first.kind != tokIdent && first.kind != tokQuotedIdent {
+		return p.rawText(start, end)
+	}
+	if end-start == 1 {
+		return first.value
+	}
+
+	second := p.tokens[start+1]
+	// MySQL 접두 인덱스: name(10)
+	if second.kind == tokPunct && second.value == "(" {
+		if end-start >= 4 && p.tokens[start+2].kind == tokNumber && p.tokens[start+3].value == ")" {
+			return first.value
+		}
+		return p.rawText(start, end)
+	}
+	// name ASC, name DESC NULLS LAST, name varchar_pattern_ops
+	if second.kind == tokIdent {
+		return first.value
+	}
+	return p.rawText(start, end)
+}
+
+// ─── CREATE INDEX ───────────────────────────────────────────
+
+func (p *ddlParser) parseCreateIndex() (Statement, error) {
+	stmt := &CreateIndexStmt{Pos: p.cur().pos, Type: "BTREE"}
+	p.next() // CREATE
+
+	for !p.acceptKeyword("INDEX") {
+		switch kw := p.next().upper(); kw {
+		case "UNIQUE":
+			stmt.Unique = true
+		case "FULLTEXT", "SPATIAL", "BITMAP":
+			stmt.Type = kw
+		}
+	}
+
+	p.acceptKeyword("CONCURRENTLY")
+	if p.acceptKeyword("IF") {
+		if err := p.expectKeywords("NOT", "EXISTS"); err != nil {
+			return nil, err
+		}
+		stmt.IfNotExists = true
+	}
+
+	if !p.isKeyword("ON") {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Name = name.Name
+	}
+
+	// MySQL: CREATE INDEX idx USING BTREE ON t (...)
+	if p.acceptKeyword("USING") {
+		stmt.Type = strings.ToUpper(p.next().value)
+	}
+
+	if err := p.expectKeywords("ON"); err != nil {
+		return nil, err
+	}
+	p.acceptKeyword("ONLY")
+
+	table, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Table = table
+
+	if p.acceptKeyword("USING") {
+		stmt.Type = strings.ToUpper(p.next().value)
+	}
+
+	if stmt.Columns, err = p.parseIndexColumns(); err != nil {
+		return nil, err
+	}
+
+	// INCLUDE (...), WHERE ..., WITH (...), TABLESPACE 등
+	p.skipStatement()
+	return stmt, nil
+}
+
+// ─── ALTER TABLE ────────────────────────────────────────────
+
+func (p *ddlParser) parseAlterTable() (Statement, error) {
+	stmt := &AlterTableStmt{Pos: p.cur().pos}
+	p.next() // ALTER
+	p.next() // TABLE
+
+	if p.acceptKeyword("IF") {
+		if err := p.expectKeywords("EXISTS"); err != nil {
+			return nil, err
+		}
+		stmt.IfExists = true
+	}
+	p.acceptKeyword("ONLY")
+
+	table, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Table = table
+
+	// SQL Server: ALTER TABLE t WITH CHECK ADD CONSTRAINT ...
+	if p.acceptKeyword("WITH") {
+		if !p.acceptKeyword("CHECK") {
+			p.acceptKeyword("NOCHECK")
+		}
+	}
+
+	// 동작 위치의 DROP INDEX 등은 다음 문장이 아니므로 atStatementEnd 대신 직접 확인
+	var last AlterKind = AlterOther
+	for !p.atEOF() && !p.isPunct(";") {
+		actions, err := p.parseAlterAction(last)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Actions = append(stmt.Actions, actions...)
+		if len(actions) > 0 {
+			last = actions[len(actions)-1].Kind
+		}
+
+		p.skipToClauseEnd()
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+
+	p.skipStatement()
+	return stmt, nil
+}
+
+// parseAlterAction ALTER TABLE 동작 하나를 파싱
+// last는 직전 동작으로, SQL Server의 "ADD a INT, b INT" 처럼 키워드가 생략된 목록에 사용
+func (p *ddlParser) parseAlterAction(last AlterKind) ([]AlterAction, error) {
+	tok := p.cur()
+	action := AlterAction{Pos: tok.pos}
+
+	switch tok.upper() {
+	case "ADD":
+		p.next()
+		return p.parseAlterAdd(action)
+
+	case "DROP":
+		p.next()
+		return p.parseAlterDrop(action)
+
+	case "RENAME":
+		p.next()
+		switch {
+		case p.acceptKeyword("COLUMN"):
+			return p.parseRenameColumn(action)
+		case p.isKeyword("TO", "AS"):
+			p.next()
+			name, err := p.parseObjectName()
+			if err != nil {
+				return nil, err
+			}
+			action.Kind = AlterRenameTable
+			action.NewName = name.Name
+		case p.isKeyword("CONSTRAINT", "INDEX", "KEY"):
+			action.Kind = AlterOther
+		case p.peekToken(1).upper() == "TO":
+			return p.parseRenameColumn(action)
+		default:
+			name, err := p.parseObjectName()
+			if err != nil {
+				return nil, err
+			}
+			action.Kind = AlterRenameTable
+			action.NewName = name.Name
+		}
+		return []AlterAction{action}, nil
+
+	case "MODIFY":
+		p.next()
+		p.acceptKeyword("COLUMN")
+		return p.parseColumnDefList(action, AlterModifyColumn)
+
+	case "CHANGE":
+		// MySQL: CHANGE [COLUMN] old new_definition
+		p.next()
+		p.acceptKeyword("COLUMN")
+		old, err := p.parseIdent("컬럼 이름")
+		if err != nil {
+			return nil, err
+		}
+		col, err := p.parseColumnDef()
+		if err != nil {
+			return nil, err
+		}
+		action.Kind = AlterModifyColumn
+		action.Name = old
+		action.Column = col
+		return []AlterAction{action}, nil
+
+	case "ALTER":
+		p.next()
+		p.acceptKeyword("COLUMN")
+		return p.parseAlterColumn(action)
+	}
+
+	// 키워드 없이 이어지는 목록 (SQL Server ADD a INT, b INT / DROP COLUMN a, b)
+	if (tok.kind == tokIdent && !p.isAlterKeyword()) || tok.kind == tokQuotedIdent {
+		switch last {
+		case AlterAddColumn:
+			col, err := p.parseColumnDef()
+			if err != nil {
+				return nil, err
+			}
+			action.Kind = AlterAddColumn
+			action.Column = col
+			return []AlterAction{action}, nil
+		case AlterDropColumn:
+			action.Kind = AlterDropColumn
+			action.Name = p.next().value
+			return []AlterAction{action}, nil
+		}
+	}
+
+	action.Kind = AlterOther
+	return []AlterAction{action}, nil
+}
+
+func (p *ddlParser) isAlterKeyword() bool {
+	switch p.cur().upper() {
+	case "ADD", "DROP", "RENAME", "MODIFY", "CHANGE", "ALTER", "OWNER", "SET",
+		"ENABLE", "DISABLE", "CHECK", "NOCHECK", "ENGINE", "AUTO_INCREMENT":
+		return true
+	}
+	return false
+}
+
+func (p *ddlParser) parseAlterAdd(action AlterAction) ([]AlterAction, error) {
+	switch {
+	case p.acceptKeyword("COLUMN"):
+		action.IfNotExists = p.skipIfNotExists()
+		col, err := p.parseColumnDef()
+		if err != nil {
+			return nil, err
+		}
+		action.Kind = AlterAddColumn
+		action.Column = col
+		return []AlterAction{action}, nil
+
+	case p.isTableConstraintStart():
+		c, err := p.parseTableConstraint()
+		if err != nil {
+			return nil, err
+		}
+		action.Kind = AlterAddConstraint
+		action.Constraint = c
+		return []AlterAction{action}, nil
+
+	case p.isPunct("("):
+		// Oracle: ADD (col1 ..., col2 ...)
+		return p.parseColumnDefList(action, AlterAddColumn)
+	}
+
+	action.IfNotExists = p.skipIfNotExists()
+	col, err := p.parseColumnDef()
+	if err != nil {
+		return nil, err
+	}
+	action.Kind = AlterAddColumn
+	action.Column = col
+	return []AlterAction{action}, nil
+}
+
+func (p *ddlParser) parseAlterDrop(action AlterAction) ([]AlterAction, error) {
+	switch p.cur().upper() {
+	case "COLUMN":
+		p.next()
+		action.IfExists = p.skipIfExists()
+		action.Kind = AlterDropColumn
+	case "CONSTRAINT", "CHECK":
+		p.next()
+		action.IfExists = p.skipIfExists()
+		action.Kind = AlterDropConstraint
+	case "FOREIGN":
+		p.next()
+		if err := p.expectKeywords("KEY"); err != nil {
+			return nil, err
+		}
+		action.Kind = AlterDropConstraint
+	case "PRIMARY":
+		p.next()
+		if err := p.expectKeywords("KEY"); err != nil {
+			return nil, err
+		}
+		action.Kind = AlterDropPrimaryKey
+		return []AlterAction{action}, nil
+	case "INDEX", "KEY":
+		p.next()
+		action.IfExists = p.skipIfExists()
+		action.Kind = AlterDropIndex
+	case "UNIQUE", "DEFAULT", "PERIOD", "SYSTEM":
+		action.Kind = AlterOther
+		return []AlterAction{action}, nil
+	default:
+		if p.isPunct("(") {
+			// Oracle: DROP (col1, col2)
+			names, err := p.parseIdentList()
+			if err != nil {
+				return nil, err
+			}
+			var actions []AlterAction
+			for _, name := range names {
+				actions = append(actions, AlterAction{Pos: action.Pos, Kind: AlterDropColumn, Name: name})
+			}
+			return actions, nil
+		}
+		action.IfExists = p.skipIfExists()
+		action.Kind = AlterDropColumn
+	}
+
+	name, err := p.parseIdent("이름")
+	if err != nil {
+		return nil, err
+	}
+	action.Name = name
+	return []AlterAction{action}, nil
+}
+
+func (p *ddlParser) parseRenameColumn(action AlterAction) ([]AlterAction, error) {
+	old, err := p.parseIdent("컬럼 이름")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeywords("TO"); err != nil {
+		return nil, err
+	}
+	newName, err := p.parseIdent("새 컬럼 이름")
+	if err != nil {
+		return nil, err
+	}
+	action.Kind = AlterRenameColumn
+	action.Name = old
+	action.NewName = newName
+	return []AlterAction{action}, nil
+}
+
+// parseColumnDefList 단일 컬럼 정의 또는 Oracle 스타일 괄호 목록
+func (p *ddlParser) parseColumnDefList(action AlterAction, kind AlterKind) ([]AlterAction, error) {
+	if !p.acceptPunct("(") {
+		col, err := p.parseColumnDef()
+		if err != nil {
+			return nil, err
+		}
+		action.Kind = kind
+		action.Column = col
+		return []AlterAction{action}, nil
+	}
+
+	var actions []AlterAction
+	for {
+		col, err := p.parseColumnDef()
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, AlterAction{Pos: col.Pos, Kind: kind, Column: col})
+
+		if p.acceptPunct(",") {
+			continue
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return actions, nil
+	}
+}
+
+// parseAlterColumn ALTER COLUMN 동작 (PostgreSQL/MySQL 부분 변경, SQL Server 전체 재정의)
+func (p *ddlParser) parseAlterColumn(action AlterAction) ([]AlterAction, error) {
+	name, err := p.parseIdent("컬럼 이름")
+	if err != nil {
+		return nil, err
+	}
+	action.Kind = AlterModifyColumn
+	action.Column = &ColumnDef{Pos: action.Pos, Name: name}
+
+	switch {
+	case p.isKeyword("TYPE"):
+		p.next()
+		action.Column.Type = p.parseType()
+	case p.isKeyword("SET") && p.peekToken(1).upper() == "DATA":
+		p.next()
+		p.next()
+		if err := p.expectKeywords("TYPE"); err != nil {
+			return nil, err
+		}
+		action.Column.Type = p.parseType()
+	case p.isKeyword("SET") && p.peekToken(1).upper() == "NOT":
+		p.next()
+		p.next()
+		if err := p.expectKeywords("NULL"); err != nil {
+			return nil, err
+		}
+		action.SetNotNull = true
+	case p.isKeyword("DROP") && p.peekToken(1).upper() == "NOT":
+		p.next()
+		p.next()
+		if err := p.expectKeywords("NULL"); err != nil {
+			return nil, err
+		}
+		action.DropNotNull = true
+	case p.isKeyword("SET") && p.peekToken(1).upper() == "DEFAULT":
+		p.next()
+		p.next()
+		action.Column.Default = p.parseDefault()
+		action.Column.HasDefault = true
+	case p.isKeyword("DROP") && p.peekToken(1).upper() == "DEFAULT":
+		p.next()
+		p.next()
+		action.DropDefault = true
+	case p.isKeyword("SET", "DROP", "ADD", "RESTART", "OPTIONS"):
+		action.Kind = AlterOther
+	default:
+		// SQL Server: ALTER COLUMN name type [NULL | NOT NULL]
+		if !p.atClauseEnd() && !p.isColumnStopWord() {
+			action.Column.Type = p.parseType()
+		}
+		if err := p.parseColumnConstraints(action.Column); err != nil {
+			return nil, err
+		}
+	}
+	return []AlterAction{action}, nil
+}
+
+// ─── DROP TABLE / DROP INDEX ────────────────────────────────
+
+func (p *ddlParser) parseDropTable() (Statement, error) {
+	stmt := &DropTableStmt{Pos: p.cur().pos}
+	p.next() // DROP
+	p.next() // TABLE
+
+	if p.isKeyword("IF") {
+		p.skipIfExists()
+		stmt.IfExists = true
+	}
+
+	for {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Names = append(stmt.Names, name)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+
+	// CASCADE, CASCADE CONSTRAINTS, PURGE 등
+	p.skipStatement()
+	return stmt, nil
+}
+
+func (p *ddlParser) parseDropIndex() (Statement, error) {
+	stmt := &DropIndexStmt{Pos: p.cur().pos}
+	p.next() // DROP
+	p.next() // INDEX
+
+	p.acceptKeyword("CONCURRENTLY")
+	if p.isKeyword("IF") {
+		p.skipIfExists()
+		stmt.IfExists = true
+	}
+
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = name.Name
+
+	if p.acceptKeyword("ON") {
+		if stmt.Table, err = p.parseObjectName(); err != nil {
+			return nil, err
+		}
+	} else if p.dbType == models.SQLServer && name.Schema != "" {
+		// SQL Server 구문: DROP INDEX table.index
+		stmt.Table = ObjectName{Name: name.Schema}
+	} else {
+		stmt.Schema = name.Schema
+	}
+
+	p.skipStatement()
+	return stmt, nil
+}
+
+// skipIfExists IF EXISTS를 건너뛰고 있었는지 반환
+func (p *ddlParser) skipIfExists() bool {
+	if p.isKeyword("IF") && p.peekToken(1).upper() == "EXISTS" {
+		p.next()
+		p.next()
+		return true
+	}
+	return false
+}
+
+// skipIfNotExists IF NOT EXISTS를 건너뛰고 있었는지 반환
+func (p *ddlParser) skipIfNotExists() bool {
+	if p.isKeyword("IF") && p.peekToken(1).upper() == "NOT" {
+		p.next()
+		p.next()
+		p.next()
+		return true
+	}
+	return false
+}
+
+// ─── CREATE TYPE / DOMAIN (PostgreSQL) ──────────────────────
+
+// parseCreateType CREATE TYPE name AS ENUM (...) (복합 타입 등 다른 형태는 건너뜀)
+func (p *ddlParser) parseCreateType() (Statement, error) {
+	stmt := &CreateTypeStmt{Pos: p.cur().pos}
+	for !p.acceptKeyword("TYPE") {
+		p.next()
+	}
+
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = name
+
+	if !p.acceptKeyword("AS") || !p.acceptKeyword("ENUM") {
+		p.skipStatement()
+		return nil, nil
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	for !p.isPunct(")") {
+		value, err := p.parseString("enum 값")
+		if err != nil {
+			return nil, err
+		}
+		stmt.Values = append(stmt.Values, value)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	p.skipStatement()
+	return stmt, nil
+}
+
+// parseCreateDomain CREATE DOMAIN name [AS] type [COLLATE ..] [DEFAULT ..] [NOT NULL] [CHECK (..)]
+func (p *ddlParser) parseCreateDomain() (Statement, error) {
+	stmt := &CreateDomainStmt{Pos: p.cur().pos}
+	for !p.acceptKeyword("DOMAIN") {
+		p.next()
+	}
+
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = name
+
+	p.acceptKeyword("AS")
+	stmt.Def = ColumnDef{Pos: p.cur().pos, Name: "VALUE"}
+	if !p.atClauseEnd() && !p.isColumnStopWord() {
+		stmt.Def.Type = p.parseType()
+	}
+	if err := p.parseColumnConstraints(&stmt.Def); err != nil {
+		return nil, err
+	}
+
+	p.skipStatement()
+	return stmt, nil
+}
+
+// parseAlterType ALTER TYPE name ADD VALUE / RENAME VALUE (다른 동작은 건너뜀)
+func (p *ddlParser) parseAlterType() (Statement, error) {
+	stmt := &AlterTypeStmt{Pos: p.cur().pos}
+	p.next() // ALTER
+	p.next() // TYPE
+
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = name
+
+	switch {
+	case p.isKeyword("ADD") && p.peekToken(1).upper() == "VALUE":
+		p.next()
+		p.next()
+		p.skipIfNotExists()
+		if stmt.Value, err = p.parseString("enum 값"); err != nil {
+			return nil, err
+		}
+		if p.acceptKeyword("BEFORE") {
+			stmt.Before, err = p.parseString("enum 값")
+		} else if p.acceptKeyword("AFTER") {
+			stmt.After, err = p.parseString("enum 값")
+		}
+		if err != nil {
+			return nil, err
+		}
+	case p.isKeyword("RENAME") && p.peekToken(1).upper() == "VALUE":
+		p.next()
+		p.next()
+		if stmt.Value, err = p.parseString("enum 값"); err != nil {
+			return nil, err
+		}
+		if err := p.expectKeywords("TO"); err != nil {
+			return nil, err
+		}
+		if stmt.NewValue, err = p.parseString("새 enum 값"); err != nil {
+			return nil, err
+		}
+	default:
+		p.skipStatement()
+		return nil, nil
+	}
+
+	p.skipStatement()
+	return stmt, nil
+}
+
+func (p *ddlParser) parseDropType() (Statement, error) {
+	stmt := &DropTypeStmt{Pos: p.cur().pos}
+	p.next() // DROP
+	p.next() // TYPE / DOMAIN
+
+	if p.isKeyword("IF") {
+		p.skipIfExists()
+		stmt.IfExists = true
+	}
+
+	for {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Names = append(stmt.Names, name)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+
+	// CASCADE, RESTRICT
+	p.skipStatement()
+	return stmt, nil
+}
+
+// ─── 공통 헬퍼 ──────────────────────────────────────────────
+
+func (p *ddlParser) cur() token {
+	return p.tokens[p.pos]
+}
+
+func (p *ddlParser) peekToken(n int) token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+// next 현재 토큰을 반환하고 다음으로 이동 (EOF에서는 멈춤)
+func (p *ddlParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *ddlParser) atEOF() bool {
+	return p.cur().kind == tokEOF
+}
+
+func (p *ddlParser) isKeyword(keywords ...string) bool {
+	kw := p.cur().upper()
+	for _, k := range keywords {
+		if kw == k {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ddlParser) acceptKeyword(keyword string) bool {
+	if p.isKeyword(keyword) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *ddlParser) expectKeywords(keywords ...string) error {
+	for _, kw := range keywords {
+		if !p.acceptKeyword(kw) {
+			return p.errorf("%s 키워드가 필요합니다", kw)
+		}
+	}
+	return nil
+}
+
+func (p *ddlParser) isPunct(value string) bool {
+	tok := p.cur()
+	return tok.kind == tokPunct && tok.value == value
+}
+
+func (p *ddlParser) acceptPunct(value string) bool {
+	if p.isPunct(value) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *ddlParser) expectPunct(value string) error {
+	if !p.acceptPunct(value) {
+		return p.errorf("'%s'가 필요합니다", value)
+	}
+	return nil
+}
+
+// expectPunctAhead 소비하지 않고 현재 토큰이 기호인지 확인
+func (p *ddlParser) expectPunctAhead(value string) error {
+	if !p.isPunct(value) {
+		return p.errorf("'%s'가 필요합니다", value)
+	}
+	return nil
+}
+
+// errorf 현재 토큰 위치의 파싱 오류
+func (p *ddlParser) errorf(format string, args ...interface{}) error {
+	tok := p.cur()
+	msg := fmt.Sprintf(format, args...)
+	if tok.kind == tokEOF {
+		msg += " (입력 끝)"
+	} else {
+		msg += fmt.Sprintf(" ('%s' 부근)", p.rawText(p.pos, p.pos+1))
+	}
+	return &ParseError{Pos: tok.pos, Msg: msg}
+}
+
+func (p *ddlParser) parseIdent(what string) (string, error) {
+	tok := p.cur()
+	if tok.kind != tokIdent && tok.kind != tokQuotedIdent {
+		return "", p.errorf("%s이 필요합니다", what)
+	}
+	p.next()
+	return tok.value, nil
+}
+
+// parseObjectName [db.][schema.]name 형태의 이름
+func (p *ddlParser) parseString(what string) (string, error) {
+	tok := p.cur()
+	if tok.kind != tokString {
+		return "", p.errorf("%s이 필요합니다", what)
+	}
+	p.next()
+	return tok.value, nil
+}
+
+func (p *ddlParser) parseObjectName() (ObjectName, error) {
+	first, err := p.parseIdent("객체 이름")
+	if err != nil {
+		return ObjectName{}, err
+	}
+
+	parts := []string{first}
+	for p.isPunct(".") {
+		p.next()
+		part, err := p.parseIdent("객체 이름")
+		if err != nil {
+			return ObjectName{}, err
+		}
+		parts = append(parts, part)
+	}
+
+	name := ObjectName{Name: parts[len(parts)-1]}
+	if len(parts) > 1 {
+		name.Schema = parts[len(parts)-2]
+	}
+	return name, nil
+}
+
+// parseIdentList ( ident, ident, ... )
+func (p *ddlParser) parseIdentList() ([]string, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for {
+		name, err := p.parseIdent("컬럼 이름")
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+
+		if p.acceptPunct(",") {
+			continue
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return names, nil
+	}
+}
+
+// skipBalanced 현재 '('부터 짝이 맞는 ')'까지 건너뛰기
+func (p *ddlParser) skipBalanced() {
+	depth := 0
+	for !p.atEOF() {
+		tok := p.next()
+		if tok.kind != tokPunct {
+			continue
+		}
+		switch tok.value {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// atStatementEnd 문장 끝 (';', EOF, 또는 세미콜론 없이 시작된 다음 문장)
+func (p *ddlParser) atStatementEnd() bool {
+	return p.atEOF() || p.isPunct(";") || p.isStatementStart()
+}
+
+// atClauseEnd 컬럼/제약조건/ALTER 동작의 끝
+func (p *ddlParser) atClauseEnd() bool {
+	return p.isPunct(",") || p.isPunct(")") || p.atStatementEnd()
+}
+
+// isStatementStart 세미콜론이 빠진 스크립트에서 다음 문장의 시작을 감지
+func (p *ddlParser) isStatementStart() bool {
+	switch p.cur().upper() {
+	case "CREATE":
+		return true
+	case "ALTER", "DROP":
+		next := p.peekToken(1).upper()
+		return next == "TABLE" || next == "INDEX" || next == "VIEW" || next == "SEQUENCE"
+	}
+	return false
+}
+
+// skipToClauseEnd 괄호 깊이 0의 ',' ')' 또는 문장 끝까지 건너뛰기
+func (p *ddlParser) skipToClauseEnd() {
+	for !p.atClauseEnd() {
+		if p.isPunct("(") {
+			p.skipBalanced()
+		} else {
+			p.next()
+		}
+	}
+}
+
+// skipStatement 현재 문장의 나머지를 건너뛰고 ';'를 소비
+func (p *ddlParser) skipStatement() {
+	for !p.atStatementEnd() {
+		if p.isPunct("(") {
+			p.skipBalanced()
+		} else {
+			p.next()
+		}
+	}
+	p.acceptPunct(";")
+}
+
+// rawText 토큰 범위 [start, end)의 원문 (공백은 하나로 정리)
+func (p *ddlParser) rawText(start, end int) string {
+	if start >= end || start >= len(p.tokens) {
+		return ""
+	}
+	from := p.tokens[start].pos.Offset
+	to := p.tokens[end-1].end
+	return strings.Join(strings.Fields(string(p.src[from:to])), " ")
+}
@@ -0,0 +1,312 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"sql-genius/pkg/models"
+	"testing"
+)
+
+// parseCreateTable 첫 문장이 CREATE TABLE인 스크립트 파싱
+func parseCreateTable(t *testing.T, src string, dbType models.DBType) *CreateTableStmt {
+	t.Helper()
+	stmts, err := NewParser().ParseStatements(src, dbType)
+	if err != nil {
+		t.Fatalf("ParseStatements() error = %v", err)
+	}
+	if len(stmts) == 0 {
+		t.Fatal("ParseStatements() returned no statements")
+	}
+	stmt, ok := stmts[0].(*CreateTableStmt)
+	if !ok {
+		t.Fatalf("stmts[0] = %T, want *CreateTableStmt", stmts[0])
+	}
+	return stmt
+}
+
+func TestParseCreateTable(t *testing.T) {
+	type column struct {
+		Name, Type, Default string
+		NotNull, AutoIncr   bool
+	}
+	type constraint struct {
+		Kind       ConstraintKind
+		Name       string
+		Columns    []string
+		RefTable   ObjectName
+		RefColumns []string
+	}
+
+	tests := []struct {
+		name        string
+		dbType      models.DBType
+		src         string
+		table       ObjectName
+		columns     []column
+		constraints []constraint
+	}{
+		{
+			name:   "PostgreSQL 인용 식별자와 캐스트",
+			dbType: models.PostgreSQL,
+			src: `CREATE TABLE IF NOT EXISTS "Sales"."Orders" (
+  id serial PRIMARY KEY,
+  total numeric(12,2) DEFAULT 0::numeric CHECK (total >= 0),
+  tags text[] DEFAULT '{}'::text[],
+  UNIQUE (total, id)
+);`,
+			table: ObjectName{Schema: "Sales", Name: "Orders"},
+			columns: []column{
+				{Name: "id", Type: "serial"},
+				{Name: "total", Type: "numeric(12,2)", Default: "0::numeric"},
+				{Name: "tags", Type: "text[]", Default: "'{}'::text[]"},
+			},
+			constraints: []constraint{{Kind: ConstraintUnique, Columns: []string{"total", "id"}}},
+		},
+		{
+			name:   "PostgreSQL 중첩 괄호",
+			dbType: models.PostgreSQL,
+			src:    `CREATE TABLE t (a DECIMAL(10, 2) DEFAULT (round(1.5, 0)) CHECK (a > (1 + 2)), b VARCHAR(20) /* (주석 */ NOT NULL)`,
+			table:  ObjectName{Name: "t"},
+			columns: []column{
+				{Name: "a", Type: "DECIMAL(10, 2)", Default: "(round(1.5, 0))"},
+				{Name: "b", Type: "VARCHAR(20)", NotNull: true},
+			},
+		},
+		{
+			name:   "MySQL 백틱, 실행 주석, 복합 외래키",
+			dbType: models.MySQL,
+			src: "CREATE TABLE `shop`.`order items` (\n" +
+				"  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,\n" +
+				"  `price` DECIMAL(10,2) NOT NULL DEFAULT '0.00' COMMENT 'a, (b)',\n" +
+				"  status ENUM('a','b') NOT NULL, -- 상태\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_price` (`price`) USING BTREE,\n" +
+				"  CONSTRAINT `fk_o` FOREIGN KEY (`a`, `b`) REFERENCES `orders` (`x`, `y`) ON DELETE CASCADE\n" +
+				") /*!50100 ENGINE=InnoDB */ DEFAULT CHARSET=utf8mb4; # 끝",
+			table: ObjectName{Schema: "shop", Name: "order items"},
+			columns: []column{
+				{Name: "id", Type: "BIGINT UNSIGNED", NotNull: true, AutoIncr: true},
+				{Name: "price", Type: "DECIMAL(10,2)", Default: "'0.00'", NotNull: true},
+				{Name: "status", Type: "ENUM('a','b')", NotNull: true},
+			},
+			constraints: []constraint{
+				{Kind: ConstraintPrimaryKey, Columns: []string{"id"}},
+				{Kind: ConstraintIndex, Name: "idx_price", Columns: []string{"price"}},
+				{Kind: ConstraintForeignKey, Name: "fk_o", Columns: []string{"a", "b"},
+					RefTable: ObjectName{Name: "orders"}, RefColumns: []string{"x", "y"}},
+			},
+		},
+		{
+			name:   "SQL Server 대괄호와 IDENTITY",
+			dbType: models.SQLServer,
+			src: "CREATE TABLE [dbo].[Order Items] (\n" +
+				"  [Id] INT IDENTITY(1,1) NOT NULL,\n" +
+				"  [Amount] DECIMAL(18, 4) NULL CONSTRAINT [DF_a] DEFAULT ((0)),\n" +
+				"  CONSTRAINT [PK_x] PRIMARY KEY CLUSTERED ([Id] ASC)\n" +
+				")\nGO",
+			table: ObjectName{Schema: "dbo", Name: "Order Items"},
+			columns: []column{
+				{Name: "Id", Type: "INT", NotNull: true, AutoIncr: true},
+				{Name: "Amount", Type: "DECIMAL(18, 4)", Default: "((0))"},
+			},
+			constraints: []constraint{{Kind: ConstraintPrimaryKey, Name: "PK_x", Columns: []string{"Id"}}},
+		},
+		{
+			name:   "Oracle 복합 외래키와 슬래시",
+			dbType: models.Oracle,
+			src: "CREATE TABLE hr.emp (\n" +
+				"  id NUMBER(10,0) GENERATED ALWAYS AS IDENTITY,\n" +
+				"  name VARCHAR2(20 CHAR) NOT NULL,\n" +
+				"  dept_id NUMBER, loc_id NUMBER,\n" +
+				"  CONSTRAINT fk_dept FOREIGN KEY (dept_id, loc_id) REFERENCES hr.dept (id, loc_id)\n" +
+				")\n/",
+			table: ObjectName{Schema: "hr", Name: "emp"},
+			columns: []column{
+				{Name: "id", Type: "NUMBER(10,0)", AutoIncr: true},
+				{Name: "name", Type: "VARCHAR2(20 CHAR)", NotNull: true},
+				{Name: "dept_id", Type: "NUMBER"},
+				{Name: "loc_id", Type: "NUMBER"},
+			},
+			constraints: []constraint{
+				{Kind: ConstraintForeignKey, Name: "fk_dept", Columns: []string{"dept_id", "loc_id"},
+					RefTable: ObjectName{Schema: "hr", Name: "dept"}, RefColumns: []string{"id", "loc_id"}},
+			},
+		},
+		{
+			name:   "SQLite AUTOINCREMENT와 대괄호",
+			dbType: models.SQLite,
+			src:    "CREATE TABLE t (id INTEGER PRIMARY KEY AUTOINCREMENT, [x y] TEXT)",
+			table:  ObjectName{Name: "t"},
+			columns: []column{
+				{Name: "id", Type: "INTEGER", AutoIncr: true},
+				{Name: "x y", Type: "TEXT"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := parseCreateTable(t, tt.src, tt.dbType)
+			if stmt.Name != tt.table {
+				t.Errorf("Name = %+v, want %+v", stmt.Name, tt.table)
+			}
+
+			var columns []column
+			for _, c := range stmt.Columns {
+				columns = append(columns, column{Name: c.Name, Type: c.Type, Default: c.Default, NotNull: c.NotNull, AutoIncr: c.AutoIncr})
+			}
+			if !reflect.DeepEqual(columns, tt.columns) {
+				t.Errorf("Columns = %+v, want %+v", columns, tt.columns)
+			}
+
+			var constraints []constraint
+			for _, c := range stmt.Constraints {
+				constraints = append(constraints, constraint{Kind: c.Kind, Name: c.Name, Columns: c.Columns, RefTable: c.RefTable, RefColumns: c.RefColumns})
+			}
+			if !reflect.DeepEqual(constraints, tt.constraints) {
+				t.Errorf("Constraints = %+v, want %+v", constraints, tt.constraints)
+			}
+		})
+	}
+}
+
+func TestParseColumnConstraints(t *testing.T) {
+	stmt := parseCreateTable(t, `CREATE TABLE t (
+  p INT REFERENCES parent(id) ON DELETE SET NULL,
+  q INT CHECK (q > (1 + 2))
+)`, models.SQLite)
+
+	ref := stmt.Columns[0].References
+	if ref == nil || ref.RefTable.Name != "parent" || !reflect.DeepEqual(ref.RefColumns, []string{"id"}) || ref.OnDelete != "SET NULL" {
+		t.Errorf("References = %+v", ref)
+	}
+	checks := stmt.Columns[1].Checks
+	if len(checks) != 1 || checks[0].Expr != "(q > (1 + 2))" || !reflect.DeepEqual(checks[0].Columns, []string{"q"}) {
+		t.Errorf("Checks = %+v", checks)
+	}
+}
+
+func TestParseStatementKinds(t *testing.T) {
+	src := `CREATE TABLE sales.t (id INT);
+CREATE UNIQUE INDEX CONCURRENTLY ix ON sales.t USING btree (lower(name::text), id);
+ALTER TABLE sales.t ADD COLUMN name TEXT, DROP COLUMN old;
+DROP INDEX IF EXISTS sales.ix;
+DROP TABLE IF EXISTS a, sales.b CASCADE;
+INSERT INTO t VALUES (1);
+COMMENT ON TABLE t IS 'x';`
+
+	stmts, err := NewParser().ParseStatements(src, models.PostgreSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 5 {
+		t.Fatalf("len(stmts) = %d, want 5 (INSERT, COMMENT는 건너뜀)", len(stmts))
+	}
+
+	idx := stmts[1].(*CreateIndexStmt)
+	if idx.Name != "ix" || idx.Table != (ObjectName{Schema: "sales", Name: "t"}) || !idx.Unique ||
+		!reflect.DeepEqual(idx.Columns, []string{"lower(name::text)", "id"}) {
+		t.Errorf("CreateIndexStmt = %+v", idx)
+	}
+
+	alter := stmts[2].(*AlterTableStmt)
+	if len(alter.Actions) != 2 || alter.Actions[0].Kind != AlterAddColumn || alter.Actions[0].Column.Name != "name" ||
+		alter.Actions[1].Kind != AlterDropColumn || alter.Actions[1].Name != "old" {
+		t.Errorf("AlterTableStmt = %+v", alter)
+	}
+
+	drop := stmts[3].(*DropIndexStmt)
+	if drop.Schema != "sales" || drop.Name != "ix" || drop.Table.Name != "" || !drop.IfExists {
+		t.Errorf("DropIndexStmt = %+v", drop)
+	}
+
+	dropTable := stmts[4].(*DropTableStmt)
+	if !reflect.DeepEqual(dropTable.Names, []ObjectName{{Name: "a"}, {Schema: "sales", Name: "b"}}) || !dropTable.IfExists {
+		t.Errorf("DropTableStmt = %+v", dropTable)
+	}
+}
+
+func TestParseDropIndexTable(t *testing.T) {
+	tests := []struct {
+		dbType models.DBType
+		src    string
+		want   DropIndexStmt
+	}{
+		{models.MySQL, "DROP INDEX ix ON shop.t", DropIndexStmt{Name: "ix", Table: ObjectName{Schema: "shop", Name: "t"}}},
+		{models.SQLServer, "DROP INDEX t.ix", DropIndexStmt{Name: "ix", Table: ObjectName{Name: "t"}}},
+		{models.Oracle, "DROP INDEX hr.ix", DropIndexStmt{Schema: "hr", Name: "ix"}},
+	}
+
+	for _, tt := range tests {
+		stmts, err := NewParser().ParseStatements(tt.src, tt.dbType)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.src, err)
+		}
+		got := *stmts[0].(*DropIndexStmt)
+		got.Pos = Pos{}
+		if got != tt.want {
+			t.Errorf("%s: %+v, want %+v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParseIfExists(t *testing.T) {
+	stmts, err := NewParser().ParseStatements(`ALTER TABLE IF EXISTS t
+  ADD COLUMN IF NOT EXISTS a INT,
+  ADD b INT,
+  DROP COLUMN IF EXISTS c,
+  DROP CONSTRAINT IF EXISTS fk,
+  DROP d;
+CREATE INDEX IF NOT EXISTS ix ON t (a);
+CREATE INDEX iy ON t (b);`, models.PostgreSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alter := stmts[0].(*AlterTableStmt)
+	if !alter.IfExists {
+		t.Error("AlterTableStmt.IfExists = false")
+	}
+	want := []struct{ ifNotExists, ifExists bool }{{true, false}, {false, false}, {false, true}, {false, true}, {false, false}}
+	if len(alter.Actions) != len(want) {
+		t.Fatalf("actions = %d, want %d", len(alter.Actions), len(want))
+	}
+	for i, w := range want {
+		a := alter.Actions[i]
+		if a.IfNotExists != w.ifNotExists || a.IfExists != w.ifExists {
+			t.Errorf("Actions[%d] = IfNotExists %v, IfExists %v, want %v, %v", i, a.IfNotExists, a.IfExists, w.ifNotExists, w.ifExists)
+		}
+	}
+	if !stmts[1].(*CreateIndexStmt).IfNotExists || stmts[2].(*CreateIndexStmt).IfNotExists {
+		t.Error("CreateIndexStmt.IfNotExists not set from IF NOT EXISTS")
+	}
+}
+
+func TestParseStatementsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		src    string
+		pos    string
+	}{
+		{"닫는 괄호 없음", models.PostgreSQL, "CREATE TABLE t (a INT", "1:22"},
+		{"빈 컬럼 정의", models.PostgreSQL, "CREATE TABLE t (a INT,\n  )", "2:3"},
+		{"테이블 이름 없음", models.MySQL, "CREATE TABLE t (a INT);\nCREATE TABLE (b INT)", "2:14"},
+		{"제약조건 이름 없음", models.SQLServer, "CREATE TABLE t (a INT)\nGO\nALTER TABLE t ADD CONSTRAINT", "3:29"},
+		{"닫히지 않은 문자열", models.Oracle, "CREATE TABLE t (\n  a VARCHAR2(10) DEFAULT 'x\n)", "2:26"},
+		{"닫히지 않은 실행 주석", models.MySQL, "CREATE TABLE t (a INT) /*!50100 ENGINE=InnoDB", "1:24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser().ParseStatements(tt.src, tt.dbType)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseStatements() error = %v, want *ParseError", err)
+			}
+			if perr.Pos.String() != tt.pos {
+				t.Errorf("error position = %s (%v), want %s", perr.Pos, err, tt.pos)
+			}
+		})
+	}
+}
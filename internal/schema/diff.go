@@ -0,0 +1,393 @@
+package schema
+
+import (
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// SchemaDiff 두 스키마(From → To)의 구조적 차이
+type SchemaDiff struct {
+	AddedTables   []models.Table `json:"added_tables,omitempty"`
+	DroppedTables []models.Table `json:"dropped_tables,omitempty"`
+	ChangedTables []TableDiff    `json:"changed_tables,omitempty"`
+}
+
+// TableDiff 양쪽에 모두 존재하는 테이블의 차이
+type TableDiff struct {
+	Schema             string          `json:"schema,omitempty"`
+	Name               string          `json:"name"`
+	AddedColumns       []models.Column `json:"added_columns,omitempty"`
+	DroppedColumns     []models.Column `json:"dropped_columns,omitempty"`
+	ChangedColumns     []ColumnChange  `json:"changed_columns,omitempty"`
+	PrimaryKey         *KeyChange      `json:"primary_key,omitempty"`
+	AddedForeignKeys   []ForeignKey    `json:"added_foreign_keys,omitempty"`
+	DroppedForeignKeys []ForeignKey    `json:"dropped_foreign_keys,omitempty"`
+	AddedIndexes       []models.Index  `json:"added_indexes,omitempty"`
+	DroppedIndexes     []models.Index  `json:"dropped_indexes,omitempty"`
+}
+
+// QualifiedName 스키마가 있으면 schema.table 형태의 이름
+func (td *TableDiff) QualifiedName() string {
+	return models.QualifiedName(td.Schema, td.Name)
+}
+
+// ColumnChange 컬럼 속성 변경
+type ColumnChange struct {
+	Name    string        `json:"name"`
+	From    models.Column `json:"from"`
+	To      models.Column `json:"to"`
+	Changes []string      `json:"changes"` // type, nullable, default, auto_increment
+}
+
+// KeyChange 기본키 컬럼 변경
+type KeyChange struct {
+	From []string `json:"from"`
+	To   []string `json:"to"`
+}
+
+// ForeignKey 컬럼 쌍을 하나로 묶은 외래키 제약조건
+type ForeignKey struct {
+	Name       string   `json:"name"`
+	Columns    []string `json:"columns"`
+	RefSchema  string   `json:"ref_schema,omitempty"`
+	RefTable   string   `json:"ref_table"`
+	RefColumns []string `json:"ref_columns"`
+	OnDelete   string   `json:"on_delete,omitempty"`
+	OnUpdate   string   `json:"on_update,omitempty"`
+}
+
+// IsEmpty 차이가 없는지 확인
+func (d *SchemaDiff) IsEmpty() bool {
+	return len(d.AddedTables) == 0 && len(d.DroppedTables) == 0 && len(d.ChangedTables) == 0
+}
+
+// Reverse To → From 방향의 차이 (롤백용)
+func (d *SchemaDiff) Reverse() *SchemaDiff {
+	rev := &SchemaDiff{
+		AddedTables:   d.DroppedTables,
+		DroppedTables: d.AddedTables,
+	}
+	for _, td := range d.ChangedTables {
+		r := TableDiff{
+			Schema:             td.Schema,
+			Name:               td.Name,
+			AddedColumns:       td.DroppedColumns,
+			DroppedColumns:     td.AddedColumns,
+			AddedForeignKeys:   td.DroppedForeignKeys,
+			DroppedForeignKeys: td.AddedForeignKeys,
+			AddedIndexes:       td.DroppedIndexes,
+			DroppedIndexes:     td.AddedIndexes,
+		}
+		for _, cc := range td.ChangedColumns {
+			r.ChangedColumns = append(r.ChangedColumns, ColumnChange{
+				Name:    cc.Name,
+				From:    cc.To,
+				To:      cc.From,
+				Changes: cc.Changes,
+			})
+		}
+		if td.PrimaryKey != nil {
+			r.PrimaryKey = &KeyChange{From: td.PrimaryKey.To, To: td.PrimaryKey.From}
+		}
+		rev.ChangedTables = append(rev.ChangedTables, r)
+	}
+	return rev
+}
+
+// Diff 두 스키마를 비교해 from을 to로 바꾸는 데 필요한 차이를 계산
+// 이름은 대소문자를 구분하지 않고 비교합니다 (Oracle 카탈로그는 대문자로 반환).
+func (p *Parser) Diff(from, to *models.Schema) *SchemaDiff {
+	diff := &SchemaDiff{}
+
+	for _, ft := range from.Tables {
+		if lookupTable(to, ft.Schema, ft.Name) == nil {
+			diff.DroppedTables = append(diff.DroppedTables, ft)
+		}
+	}
+
+	for _, tt := range to.Tables {
+		ft := lookupTable(from, tt.Schema, tt.Name)
+		if ft == nil {
+			diff.AddedTables = append(diff.AddedTables, tt)
+			continue
+		}
+		if td := diffTable(ft, &tt); td != nil {
+			diff.ChangedTables = append(diff.ChangedTables, *td)
+		}
+	}
+
+	return diff
+}
+
+// diffTable 테이블 하나의 차이 (변경이 없으면 nil)
+func diffTable(from, to *models.Table) *TableDiff {
+	td := &TableDiff{Schema: from.Schema, Name: from.Name}
+
+	// 컬럼
+	for _, fc := range from.Columns {
+		if findColumn(to, fc.Name) == nil {
+			td.DroppedColumns = append(td.DroppedColumns, fc)
+		}
+	}
+	for _, tc := range to.Columns {
+		fc := findColumn(from, tc.Name)
+		if fc == nil {
+			td.AddedColumns = append(td.AddedColumns, tc)
+			continue
+		}
+		if changes := columnChanges(fc, &tc); len(changes) > 0 {
+			td.ChangedColumns = append(td.ChangedColumns, ColumnChange{
+				Name:    fc.Name,
+				From:    *fc,
+				To:      tc,
+				Changes: changes,
+			})
+		}
+	}
+
+	// 기본키
+	if !sameNames(from.PrimaryKey, to.PrimaryKey) {
+		td.PrimaryKey = &KeyChange{From: from.PrimaryKey, To: to.PrimaryKey}
+	}
+
+	// 외래키: 컬럼/참조 대상이 같으면 같은 제약조건으로 보고, 동작이 다르면 재생성
+	fromFKs := GroupForeignKeys(from.ForeignKeys)
+	toFKs := GroupForeignKeys(to.ForeignKeys)
+	for _, ffk := range fromFKs {
+		tfk := findForeignKey(toFKs, ffk)
+		if tfk == nil || !sameFKActions(ffk, *tfk) {
+			td.DroppedForeignKeys = append(td.DroppedForeignKeys, ffk)
+		}
+	}
+	for _, tfk := range toFKs {
+		ffk := findForeignKey(fromFKs, tfk)
+		if ffk == nil || !sameFKActions(*ffk, tfk) {
+			td.AddedForeignKeys = append(td.AddedForeignKeys, tfk)
+		}
+	}
+
+	// 인덱스: 이름 대신 컬럼 구성과 유일성으로 비교 (카탈로그가 붙이는 이름이 DDL과 다를 수 있음)
+	fromIdx := secondaryIndexes(from)
+	toIdx := secondaryIndexes(to)
+	for _, fi := range fromIdx {
+		if findIndex(toIdx, fi) == nil {
+			td.DroppedIndexes = append(td.DroppedIndexes, fi)
+		}
+	}
+	for _, ti := range toIdx {
+		if findIndex(fromIdx, ti) == nil {
+			td.AddedIndexes = append(td.AddedIndexes, ti)
+		}
+	}
+
+	if len(td.AddedColumns) == 0 && len(td.DroppedColumns) == 0 && len(td.ChangedColumns) == 0 &&
+		td.PrimaryKey == nil && len(td.AddedForeignKeys) == 0 && len(td.DroppedForeignKeys) == 0 &&
+		len(td.AddedIndexes) == 0 && len(td.DroppedIndexes) == 0 {
+		return nil
+	}
+	return td
+}
+
+// columnChanges 컬럼 속성 중 달라진 항목
+func columnChanges(from, to *models.Column) []string {
+	var changes []string
+	if !sameType(from.Type, to.Type) {
+		changes = append(changes, "type")
+	}
+	if from.Nullable != to.Nullable {
+		changes = append(changes, "nullable")
+	}
+	// 자동 증가 컬럼의 기본값은 시퀀스 표현식이라 비교하지 않음
+	if !from.IsAutoIncr && !to.IsAutoIncr && normalizeDefault(from.Default) != normalizeDefault(to.Default) {
+		changes = append(changes, "default")
+	}
+	if from.IsAutoIncr != to.IsAutoIncr {
+		changes = append(changes, "auto_increment")
+	}
+	return changes
+}
+
+// typeAliases 같은 타입의 다른 표기
+var typeAliases = map[string]string{
+	"integer":                     "int",
+	"int4":                        "int",
+	"serial":                      "int",
+	"serial4":                     "int",
+	"int8":                        "bigint",
+	"bigserial":                   "bigint",
+	"serial8":                     "bigint",
+	"int2":                        "smallint",
+	"smallserial":                 "smallint",
+	"bool":                        "boolean",
+	"character varying":           "varchar",
+	"character":                   "char",
+	"decimal":                     "numeric",
+	"float8":                      "double precision",
+	"double":                      "double precision",
+	"float4":                      "real",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+}
+
+// sameType 타입 비교
+// 카탈로그가 길이/정밀도 없이 기본 타입만 돌려주는 경우(MySQL DATA_TYPE 등)가 있어
+// 한쪽에 인자가 없으면 기본 타입만 비교합니다.
+func sameType(a, b string) bool {
+	baseA, argsA := splitType(a)
+	baseB, argsB := splitType(b)
+	if baseA != baseB {
+		return false
+	}
+	return argsA == "" || argsB == "" || argsA == argsB
+}
+
+// splitType 타입을 정규화된 기본 타입과 인자 부분으로 분리
+func splitType(t string) (string, string) {
+	t = strings.ToLower(strings.Join(strings.Fields(t), " "))
+	base, args := t, ""
+	if i := strings.Index(t, "("); i >= 0 {
+		base = strings.TrimSpace(t[:i])
+		rest := t[i:]
+		if j := strings.Index(rest, ")"); j >= 0 {
+			args = strings.ReplaceAll(rest[:j+1], " ", "")
+			// varchar(10) character set ... 처럼 괄호 뒤에 이어지는 수식어 유지
+			if tail := strings.TrimSpace(rest[j+1:]); tail != "" {
+				base += " " + tail
+			}
+		}
+	}
+	if alias, ok := typeAliases[base]; ok {
+		base = alias
+	}
+	return base, args
+}
+
+// normalizeDefault 기본값 비교용 정규화
+// SQL Server의 ((0)), PostgreSQL의 'a'::text, MySQL의 따옴표 없는 문자열 값을 같은 형태로 맞춥니다.
+func normalizeDefault(v string) string {
+	v = strings.TrimSpace(v)
+	for {
+		prev := v
+		if strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") && balancedParens(v[1:len(v)-1]) {
+			v = strings.TrimSpace(v[1 : len(v)-1])
+		}
+		if i := strings.LastIndex(v, "::"); i > 0 && !strings.Contains(v[i:], "'") {
+			v = strings.TrimSpace(v[:i])
+		}
+		if v == prev {
+			break
+		}
+	}
+	if len(v) >= 2 && strings.HasPrefix(v, "'") && strings.HasSuffix(v, "'") {
+		return strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+	}
+	if strings.EqualFold(v, "NULL") {
+		return ""
+	}
+	return strings.TrimSuffix(strings.ToLower(v), "()")
+}
+
+func balancedParens(s string) bool {
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// GroupForeignKeys 컬럼 쌍 단위로 기록된 외래키를 제약조건 이름별로 묶음
+func GroupForeignKeys(fks []models.FK) []ForeignKey {
+	var groups []ForeignKey
+	index := make(map[string]int)
+	for _, fk := range fks {
+		key := strings.ToLower(fk.Name)
+		if i, ok := index[key]; ok && fk.Name != "" {
+			groups[i].Columns = append(groups[i].Columns, fk.Column)
+			groups[i].RefColumns = append(groups[i].RefColumns, fk.RefColumn)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, ForeignKey{
+			Name:       fk.Name,
+			Columns:    []string{fk.Column},
+			RefSchema:  fk.RefSchema,
+			RefTable:   fk.RefTable,
+			RefColumns: []string{fk.RefColumn},
+			OnDelete:   fk.OnDelete,
+			OnUpdate:   fk.OnUpdate,
+		})
+	}
+	return groups
+}
+
+func findForeignKey(fks []ForeignKey, target ForeignKey) *ForeignKey {
+	for i := range fks {
+		fk := &fks[i]
+		if strings.EqualFold(fk.RefTable, target.RefTable) &&
+			sameNames(fk.Columns, target.Columns) &&
+			sameNames(fk.RefColumns, target.RefColumns) {
+			return fk
+		}
+	}
+	return nil
+}
+
+func sameFKActions(a, b ForeignKey) bool {
+	return normalizeAction(a.OnDelete) == normalizeAction(b.OnDelete) &&
+		normalizeAction(a.OnUpdate) == normalizeAction(b.OnUpdate)
+}
+
+// normalizeAction 생략, NO ACTION, RESTRICT는 모두 기본 동작으로 취급
+func normalizeAction(action string) string {
+	action = strings.ToUpper(strings.Join(strings.Fields(action), " "))
+	if action == "" || action == "RESTRICT" {
+		return "NO ACTION"
+	}
+	return action
+}
+
+// secondaryIndexes 기본키를 위한 인덱스를 제외한 인덱스 목록
+func secondaryIndexes(table *models.Table) []models.Index {
+	var indexes []models.Index
+	for _, idx := range table.Indexes {
+		if idx.Name == "PRIMARY" || idx.Type == "PRIMARY" {
+			continue
+		}
+		if idx.IsUnique && len(table.PrimaryKey) > 0 && sameNames(idx.Columns, table.PrimaryKey) {
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes
+}
+
+func findIndex(indexes []models.Index, target models.Index) *models.Index {
+	for i := range indexes {
+		if indexes[i].IsUnique == target.IsUnique && sameNames(indexes[i].Columns, target.Columns) {
+			return &indexes[i]
+		}
+	}
+	return nil
+}
+
+// sameNames 순서를 포함해 이름 목록이 같은지 (대소문자 무시)
+func sameNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
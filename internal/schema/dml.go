@@ -0,0 +1,339 @@
+package schema
+
+import (
+	"fmt"
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// DMLTarget DML 문에서 영향을 받는 행을 미리 조회하기 위한 정보
+type DMLTarget struct {
+	Statement string // INSERT, UPDATE, DELETE, MERGE
+	Schema    string // 대상 테이블의 스키마 한정자 (따옴표 제거, 없으면 빈 문자열)
+	Table     string // 대상 테이블 이름 (따옴표 제거, 스키마 제외)
+	TableRef  string // 원문 그대로의 대상 테이블 참조 (스키마 포함)
+	Target    string // 미리보기 SELECT 목록에 쓸 대상 이름 (별칭 또는 테이블)
+	From      string // 미리보기 FROM 절 본문
+	Joined    bool   // FROM 절에 다른 테이블이 함께 있음 (SELECT target.* 사용)
+	Filter    string // WHERE 이후 원문 (ORDER BY, LIMIT 포함)
+	Source    string // INSERT ... SELECT의 SELECT 부분
+}
+
+// PreviewQuery 대상 행을 조회하는 SELECT (INSERT는 원본 SELECT, 미리볼 수 없으면 빈 문자열)
+func (t *DMLTarget) PreviewQuery() string {
+	switch t.Statement {
+	case "INSERT":
+		return t.Source
+	case "UPDATE", "DELETE":
+		if t.From == "" {
+			return ""
+		}
+		columns := "*"
+		if t.Joined {
+			columns = t.Target + ".*"
+		}
+		query := fmt.Sprintf("SELECT %s FROM %s", columns, t.From)
+		if t.Filter != "" {
+			query += " " + t.Filter
+		}
+		return query
+	}
+	return ""
+}
+
+// dmlModifiers DML 키워드 뒤에 올 수 있는 수식어
+var dmlModifiers = toSet(`LOW_PRIORITY QUICK IGNORE DELAYED HIGH_PRIORITY ONLY`)
+
+// ParseDML INSERT/UPDATE/DELETE/MERGE 문에서 대상 테이블과 조건절을 추출
+func (p *Parser) ParseDML(query string, dbType models.DBType) (*DMLTarget, error) {
+	tokens, err := tokenize(query, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("SQL 분석 실패: %w", err)
+	}
+	stmts := splitTokenStatements(tokens)
+	if len(stmts) != 1 {
+		return nil, fmt.Errorf("한 문장만 사용할 수 있습니다 (%d개 문장)", len(stmts))
+	}
+
+	d := &dmlParser{toks: stmts[0], src: []rune(query), dbType: dbType}
+	switch stmts[0][0].upper() {
+	case "UPDATE":
+		return d.parseUpdate()
+	case "DELETE":
+		return d.parseDelete()
+	case "INSERT", "REPLACE":
+		return d.parseInsert()
+	case "MERGE":
+		return &DMLTarget{Statement: "MERGE"}, nil
+	}
+	return nil, fmt.Errorf("INSERT, UPDATE, DELETE, MERGE 문이 아닙니다")
+}
+
+// dmlParser 한 DML 문의 최상위 절을 원문 단위로 나눔
+type dmlParser struct {
+	toks   []token
+	src    []rune
+	dbType models.DBType
+}
+
+func (d *dmlParser) tok(i int) token {
+	if i < 0 || i >= len(d.toks) {
+		return token{kind: tokEOF}
+	}
+	return d.toks[i]
+}
+
+func (d *dmlParser) isPunct(i int, value string) bool {
+	t := d.tok(i)
+	return t.kind == tokPunct && t.value == value
+}
+
+// skipModifiers LOW_PRIORITY, IGNORE, TOP (n) 등 건너뛰기
+func (d *dmlParser) skipModifiers(i int) int {
+	for {
+		switch {
+		case dmlModifiers[d.tok(i).upper()]:
+			i++
+		case d.tok(i).upper() == "TOP":
+			i++
+			if d.isPunct(i, "(") {
+				i = d.skipParens(i)
+			} else {
+				i++
+			}
+			if d.tok(i).upper() == "PERCENT" {
+				i++
+			}
+		default:
+			return i
+		}
+	}
+}
+
+func (d *dmlParser) skipParens(i int) int {
+	depth := 0
+	for ; i < len(d.toks); i++ {
+		if d.isPunct(i, "(") {
+			depth++
+		} else if d.isPunct(i, ")") {
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+// findTop i부터 괄호 밖에서 keywords 중 하나가 처음 나오는 위치 (없으면 len)
+func (d *dmlParser) findTop(i int, keywords ...string) int {
+	depth := 0
+	for ; i < len(d.toks); i++ {
+		if d.isPunct(i, "(") {
+			depth++
+			continue
+		}
+		if d.isPunct(i, ")") {
+			depth--
+			continue
+		}
+		if depth != 0 {
+			continue
+		}
+		up := d.tok(i).upper()
+		for _, kw := range keywords {
+			if up == kw {
+				return i
+			}
+		}
+	}
+	return len(d.toks)
+}
+
+// text 토큰 [start, end) 구간의 원문
+func (d *dmlParser) text(start, end int) string {
+	if start >= end || start >= len(d.toks) {
+		return ""
+	}
+	return strings.TrimSpace(string(d.src[d.toks[start].pos.Offset:d.toks[end-1].end]))
+}
+
+// tableRef start부터 테이블 이름과 별칭을 읽음
+func (d *dmlParser) tableRef(start, end int) (schema, name, ref, alias string) {
+	i := start
+	if i >= end || !(d.tok(i).kind == tokIdent || d.tok(i).kind == tokQuotedIdent) {
+		return "", "", "", ""
+	}
+	name = d.tok(i).value
+	i++
+	for i+1 < end && d.isPunct(i, ".") {
+		schema, name = name, d.tok(i+1).value
+		i += 2
+	}
+	ref = d.text(start, i)
+
+	if d.tok(i).upper() == "AS" {
+		i++
+	}
+	if i < end && (d.tok(i).kind == tokQuotedIdent || (d.tok(i).kind == tokIdent && !aliasStops[d.tok(i).upper()])) {
+		alias = d.tok(i).value
+	}
+	return schema, name, ref, alias
+}
+
+// hasJoin 구간에 JOIN이나 ','가 있는지 (여러 테이블)
+func (d *dmlParser) hasJoin(start, end int) bool {
+	depth := 0
+	for i := start; i < end; i++ {
+		switch {
+		case d.isPunct(i, "("):
+			depth++
+		case d.isPunct(i, ")"):
+			depth--
+		case depth == 0 && (d.tok(i).upper() == "JOIN" || d.isPunct(i, ",")):
+			return true
+		}
+	}
+	return false
+}
+
+// filter WHERE부터 RETURNING 전까지의 원문
+func (d *dmlParser) filter(start int) string {
+	where := d.findTop(start, "WHERE")
+	if where == len(d.toks) {
+		return ""
+	}
+	end := d.findTop(where, "RETURNING", "OUTPUT", "OPTION")
+	return d.text(where, end)
+}
+
+// parseUpdate UPDATE t [alias] SET ... [FROM ...] [WHERE ...]
+func (d *dmlParser) parseUpdate() (*DMLTarget, error) {
+	start := d.skipModifiers(1)
+	set := d.findTop(start, "SET")
+	if set == len(d.toks) {
+		return nil, fmt.Errorf("UPDATE 문에 SET 절이 없습니다")
+	}
+
+	t := &DMLTarget{Statement: "UPDATE"}
+	var alias string
+	t.Schema, t.Table, t.TableRef, alias = d.tableRef(start, set)
+	if t.Table == "" {
+		return nil, fmt.Errorf("UPDATE 대상 테이블을 찾을 수 없습니다")
+	}
+	t.Target = t.TableRef
+	if alias != "" {
+		t.Target = alias
+	}
+
+	t.From = d.text(start, set)
+	t.Joined = d.hasJoin(start, set)
+
+	// PostgreSQL/SQLite UPDATE ... FROM, SQL Server UPDATE alias SET ... FROM t alias JOIN ...
+	from := d.findTop(set, "FROM")
+	if from < d.findTop(set, "WHERE") {
+		end := d.findTop(from, "WHERE", "RETURNING", "OUTPUT", "OPTION")
+		fromText := d.text(from+1, end)
+		if d.dbType == models.SQLServer {
+			t.From = fromText
+		} else {
+			t.From += ", " + fromText
+		}
+		t.Joined = true
+	}
+
+	t.Filter = d.filter(set)
+	return t, nil
+}
+
+// parseDelete DELETE [targets] FROM t [USING ...] [WHERE ...], Oracle DELETE t WHERE ...
+func (d *dmlParser) parseDelete() (*DMLTarget, error) {
+	start := d.skipModifiers(1)
+	from := d.findTop(start, "FROM")
+
+	t := &DMLTarget{Statement: "DELETE"}
+	var alias string
+
+	if from == len(d.toks) {
+		// Oracle: FROM 생략
+		end := d.findTop(start, "WHERE", "RETURNING")
+		t.Schema, t.Table, t.TableRef, alias = d.tableRef(start, end)
+		t.From = d.text(start, end)
+	} else {
+		end := d.findTop(from, "WHERE", "USING", "RETURNING", "OUTPUT", "OPTION", "ORDER", "LIMIT")
+		t.Schema, t.Table, t.TableRef, alias = d.tableRef(from+1, end)
+		t.From = d.text(from+1, end)
+		t.Joined = d.hasJoin(from+1, end)
+
+		if start < from {
+			// MySQL/SQL Server: DELETE t1 FROM t1 JOIN t2 ...
+			t.Target = d.text(start, from)
+			t.Joined = true
+		}
+
+		if d.tok(end).upper() == "USING" {
+			usingEnd := d.findTop(end, "WHERE", "RETURNING", "ORDER", "LIMIT")
+			usingText := d.text(end+1, usingEnd)
+			if d.dbType == models.MySQL {
+				t.From = usingText // DELETE FROM t1 USING t1 JOIN t2
+			} else {
+				t.From += ", " + usingText
+			}
+			t.Joined = true
+		}
+	}
+
+	if t.Table == "" {
+		return nil, fmt.Errorf("DELETE 대상 테이블을 찾을 수 없습니다")
+	}
+	if t.Target == "" {
+		t.Target = t.TableRef
+		if alias != "" {
+			t.Target = alias
+		}
+	}
+
+	t.Filter = d.filter(start)
+	return t, nil
+}
+
+// parseInsert INSERT INTO t [(cols)] {VALUES ... | SELECT ...}
+func (d *dmlParser) parseInsert() (*DMLTarget, error) {
+	start := d.skipModifiers(1)
+	if d.tok(start).upper() == "INTO" {
+		start++
+	}
+
+	t := &DMLTarget{Statement: "INSERT"}
+	t.Schema, t.Table, t.TableRef, _ = d.tableRef(start, len(d.toks))
+	if t.Table == "" {
+		return nil, fmt.Errorf("INSERT 대상 테이블을 찾을 수 없습니다")
+	}
+	t.Target = t.TableRef
+
+	i := start
+	for i < len(d.toks) && !d.isPunct(i, "(") && d.tok(i).upper() != "SELECT" && d.tok(i).upper() != "WITH" && d.tok(i).upper() != "VALUES" {
+		i++
+	}
+	if d.isPunct(i, "(") {
+		// 컬럼 목록 또는 (SELECT ...)
+		if up := d.tok(i + 1).upper(); up != "SELECT" && up != "WITH" {
+			i = d.skipParens(i)
+		}
+	}
+
+	source := d.findTop(i, "SELECT", "WITH")
+	values := d.findTop(i, "VALUES")
+	if source < len(d.toks) && source < values {
+		end := d.findTop(source, "RETURNING")
+		// ON DUPLICATE KEY UPDATE, ON CONFLICT (JOIN ... ON과 구분)
+		for on := d.findTop(source, "ON"); on < end; on = d.findTop(on+1, "ON") {
+			if up := d.tok(on + 1).upper(); up == "DUPLICATE" || up == "CONFLICT" {
+				end = on
+				break
+			}
+		}
+		t.Source = d.text(source, end)
+	}
+	return t, nil
+}
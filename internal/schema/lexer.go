@@ -0,0 +1,437 @@
+package schema
+
+import (
+	"fmt"
+	"sql-genius/pkg/models"
+	"strings"
+	"unicode"
+)
+
+// tokenKind 토큰 종류
+type tokenKind int
+
+const (
+	tokEOF         tokenKind = iota
+	tokIdent                 // 따옴표 없는 식별자 또는 키워드
+	tokQuotedIdent           // "name", `name`, [name]
+	tokString                // 'text', N'text', $$text$$
+	tokNumber                // 123, 1.5, 1e10
+	tokPunct                 // ( ) , ; . 등 기호
+)
+
+// Pos 소스 내 위치 (1부터 시작)
+type Pos struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// token 렉서가 생성하는 토큰
+type token struct {
+	kind  tokenKind
+	value string // 식별자/문자열은 따옴표를 제거한 값
+	pos   Pos
+	end   int // 원본에서 토큰이 끝나는 오프셋
+}
+
+// upper 키워드 비교용 대문자 값 (따옴표 없는 식별자만)
+func (t token) upper() string {
+	if t.kind != tokIdent {
+		return ""
+	}
+	return strings.ToUpper(t.value)
+}
+
+// ParseError 위치 정보를 포함한 파싱 오류
+type ParseError struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d행 %d열: %s", e.Pos.Line, e.Pos.Column, e.Msg)
+}
+
+// lexer SQL 토크나이저
+type lexer struct {
+	src    []rune
+	dbType models.DBType
+	offset int
+	line   int
+	col    int
+
+	// 줄의 첫 토큰인지 (GO, / 배치 구분자 판별용)
+	lineStart bool
+
+	// MySQL 실행 주석(/*! ... */, /*+ ... */) 안인지 (본문은 코드로 읽고 닫는 */만 건너뜀)
+	execComment    bool
+	execCommentPos Pos
+}
+
+// tokenize 입력 전체를 토큰으로 분리
+func tokenize(src string, dbType models.DBType) ([]token, error) {
+	l := &lexer{
+		src:       []rune(src),
+		dbType:    dbType,
+		line:      1,
+		col:       1,
+		lineStart: true,
+	}
+
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.kind == tokEOF {
+			if l.execComment {
+				return nil, l.errorf(l.execCommentPos, "닫히지 않은 주석")
+			}
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{Offset: l.offset, Line: l.line, Column: l.col}
+}
+
+func (l *lexer) peek(n int) rune {
+	if l.offset+n >= len(l.src) {
+		return 0
+	}
+	return l.src[l.offset+n]
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.offset]
+	l.offset++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+		l.lineStart = true
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) errorf(pos Pos, format string, args ...interface{}) error {
+	return &ParseError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// skipSpaceAndComments 공백과 주석 건너뛰기
+func (l *lexer) skipSpaceAndComments() error {
+	for l.offset < len(l.src) {
+		r := l.peek(0)
+		switch {
+		case unicode.IsSpace(r):
+			l.advance()
+		case r == '-' && l.peek(1) == '-' && l.isLineComment():
+			for l.offset < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '#' && l.dbType == models.MySQL:
+			for l.offset < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case l.execComment && r == '*' && l.peek(1) == '/':
+			l.advance()
+			l.advance()
+			l.execComment = false
+		case r == '/' && l.peek(1) == '*' && l.dbType == models.MySQL && (l.peek(2) == '!' || l.peek(2) == '+'):
+			// MySQL은 /*! ... */ 본문을 실행하고 /*+ ... */는 옵티마이저 힌트로 읽으므로 주석이 아님
+			if l.execComment {
+				return l.errorf(l.pos(), "실행 주석 안에 다시 실행 주석을 쓸 수 없습니다")
+			}
+			l.execCommentPos = l.pos()
+			l.execComment = true
+			bang := l.peek(2) == '!'
+			l.advance()
+			l.advance()
+			l.advance()
+			// /*!50100 처럼 붙은 버전 번호
+			for bang && l.offset < len(l.src) && l.peek(0) >= '0' && l.peek(0) <= '9' {
+				l.advance()
+			}
+		case r == '/' && l.peek(1) == '*':
+			start := l.pos()
+			lineStart := l.lineStart
+			l.advance()
+			l.advance()
+			depth := 1
+			for depth > 0 {
+				if l.offset >= len(l.src) {
+					return l.errorf(start, "닫히지 않은 주석")
+				}
+				// PostgreSQL은 중첩 주석 허용
+				if l.dbType == models.PostgreSQL && l.peek(0) == '/' && l.peek(1) == '*' {
+					l.advance()
+					l.advance()
+					depth++
+					continue
+				}
+				if l.peek(0) == '*' && l.peek(1) == '/' {
+					l.advance()
+					l.advance()
+					depth--
+					continue
+				}
+				l.advance()
+			}
+			// 주석은 줄의 시작 여부를 바꾸지 않음
+			if !l.lineStart {
+				l.lineStart = lineStart
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// isLineComment -- 뒤가 줄 주석인지 (MySQL은 -- 다음에 공백이나 제어 문자가 있어야 주석, 없으면 빼기 두 번)
+func (l *lexer) isLineComment() bool {
+	if l.dbType != models.MySQL {
+		return true
+	}
+	next := l.peek(2)
+	return l.offset+2 >= len(l.src) || unicode.IsSpace(next) || unicode.IsControl(next)
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipSpaceAndComments(); err != nil {
+		return token{}, err
+	}
+
+	start := l.pos()
+	atLineStart := l.lineStart
+	l.lineStart = false
+
+	if l.offset >= len(l.src) {
+		return token{kind: tokEOF, pos: start, end: l.offset}, nil
+	}
+
+	r := l.peek(0)
+	switch {
+	case r == '\'':
+		return l.readString(start, '\'')
+	case (r == 'N' || r == 'n' || r == 'E' || r == 'e' || r == 'X' || r == 'x' || r == 'B' || r == 'b') && l.peek(1) == '\'':
+		// N'...', E'...', X'...', B'...' 접두 문자열
+		l.advance()
+		return l.readString(start, '\'')
+	case (r == 'Q' || r == 'q') && l.peek(1) == '\'' && l.dbType == models.Oracle:
+		return l.readOracleQuote(start)
+	case r == '$' && l.dbType == models.PostgreSQL && l.isDollarQuote():
+		return l.readDollarString(start)
+	case r == '"':
+		return l.readQuotedIdent(start, '"', '"')
+	case r == '`':
+		return l.readQuotedIdent(start, '`', '`')
+	case r == '[' && (l.dbType == models.SQLServer || l.dbType == models.SQLite):
+		return l.readQuotedIdent(start, '[', ']')
+	case unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(l.peek(1))):
+		return l.readNumber(start), nil
+	case isIdentStart(r):
+		tok := l.readIdent(start)
+		// SQL Server 배치 구분자 GO는 문장 종료로 취급
+		if l.dbType == models.SQLServer && atLineStart && strings.EqualFold(tok.value, "GO") {
+			tok.kind = tokPunct
+			tok.value = ";"
+		}
+		return tok, nil
+	case r == '/' && l.dbType == models.Oracle && atLineStart && l.restOfLineBlank(1):
+		// SQL*Plus 스크립트의 단독 '/'는 문장 종료
+		l.advance()
+		return token{kind: tokPunct, value: ";", pos: start, end: l.offset}, nil
+	}
+
+	// 두 글자 연산자
+	two := string([]rune{r, l.peek(1)})
+	switch two {
+	case "::", "<>", "!=", "<=", ">=", "||", "=>":
+		l.advance()
+		l.advance()
+		return token{kind: tokPunct, value: two, pos: start, end: l.offset}, nil
+	}
+
+	l.advance()
+	return token{kind: tokPunct, value: string(r), pos: start, end: l.offset}, nil
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func (l *lexer) isIdentPart(r rune) bool {
+	if r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return true
+	}
+	// MySQL에서 #은 주석 시작
+	return r == '#' && l.dbType != models.MySQL
+}
+
+func (l *lexer) readIdent(start Pos) token {
+	var sb strings.Builder
+	for l.offset < len(l.src) && l.isIdentPart(l.peek(0)) {
+		sb.WriteRune(l.advance())
+	}
+	return token{kind: tokIdent, value: sb.String(), pos: start, end: l.offset}
+}
+
+func (l *lexer) readNumber(start Pos) token {
+	var sb strings.Builder
+	for l.offset < len(l.src) {
+		r := l.peek(0)
+		if unicode.IsDigit(r) || r == '.' {
+			sb.WriteRune(l.advance())
+			continue
+		}
+		if (r == 'e' || r == 'E') && (unicode.IsDigit(l.peek(1)) || ((l.peek(1) == '+' || l.peek(1) == '-') && unicode.IsDigit(l.peek(2)))) {
+			sb.WriteRune(l.advance())
+			sb.WriteRune(l.advance())
+			continue
+		}
+		break
+	}
+	return token{kind: tokNumber, value: sb.String(), pos: start, end: l.offset}
+}
+
+func (l *lexer) readString(start Pos, quote rune) (token, error) {
+	l.advance() // 여는 따옴표
+	var sb strings.Builder
+	for {
+		if l.offset >= len(l.src) {
+			return token{}, l.errorf(start, "닫히지 않은 문자열")
+		}
+		r := l.advance()
+		if r == '\\' && l.dbType == models.MySQL && l.offset < len(l.src) {
+			sb.WriteRune(l.advance())
+			continue
+		}
+		if r == quote {
+			// '' 는 이스케이프된 따옴표
+			if l.peek(0) == quote {
+				sb.WriteRune(l.advance())
+				continue
+			}
+			break
+		}
+		sb.WriteRune(r)
+	}
+	return token{kind: tokString, value: sb.String(), pos: start, end: l.offset}, nil
+}
+
+func (l *lexer) readQuotedIdent(start Pos, open, close rune) (token, error) {
+	l.advance()
+	var sb strings.Builder
+	for {
+		if l.offset >= len(l.src) {
+			return token{}, l.errorf(start, "닫히지 않은 식별자 %c", open)
+		}
+		r := l.advance()
+		if r == close {
+			if l.peek(0) == close {
+				sb.WriteRune(l.advance())
+				continue
+			}
+			break
+		}
+		sb.WriteRune(r)
+	}
+	return token{kind: tokQuotedIdent, value: sb.String(), pos: start, end: l.offset}, nil
+}
+
+// readOracleQuote Oracle q'[...]' 대체 인용 문자열
+func (l *lexer) readOracleQuote(start Pos) (token, error) {
+	l.advance() // q
+	l.advance() // '
+	if l.offset >= len(l.src) {
+		return token{}, l.errorf(start, "닫히지 않은 문자열")
+	}
+	open := l.advance()
+	close := open
+	switch open {
+	case '[':
+		close = ']'
+	case '(':
+		close = ')'
+	case '{':
+		close = '}'
+	case '<':
+		close = '>'
+	}
+
+	var sb strings.Builder
+	for {
+		if l.offset >= len(l.src) {
+			return token{}, l.errorf(start, "닫히지 않은 문자열")
+		}
+		r := l.advance()
+		if r == close && l.peek(0) == '\'' {
+			l.advance()
+			break
+		}
+		sb.WriteRune(r)
+	}
+	return token{kind: tokString, value: sb.String(), pos: start, end: l.offset}, nil
+}
+
+// isDollarQuote $tag$ 형태인지 확인
+func (l *lexer) isDollarQuote() bool {
+	for i := 1; l.offset+i < len(l.src); i++ {
+		r := l.peek(i)
+		if r == '$' {
+			return true
+		}
+		if !(r == '_' || unicode.IsLetter(r) || (i > 1 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return false
+}
+
+// readDollarString PostgreSQL $tag$...$tag$ 문자열
+func (l *lexer) readDollarString(start Pos) (token, error) {
+	var tag strings.Builder
+	tag.WriteRune(l.advance())
+	for l.peek(0) != '$' {
+		tag.WriteRune(l.advance())
+	}
+	tag.WriteRune(l.advance())
+	delim := tag.String()
+
+	var sb strings.Builder
+	for {
+		if l.offset >= len(l.src) {
+			return token{}, l.errorf(start, "닫히지 않은 문자열 %s", delim)
+		}
+		if l.peek(0) == '$' && strings.HasPrefix(string(l.src[l.offset:]), delim) {
+			for range []rune(delim) {
+				l.advance()
+			}
+			break
+		}
+		sb.WriteRune(l.advance())
+	}
+	return token{kind: tokString, value: sb.String(), pos: start, end: l.offset}, nil
+}
+
+func (l *lexer) restOfLineBlank(from int) bool {
+	for i := from; l.offset+i < len(l.src); i++ {
+		r := l.peek(i)
+		if r == '\n' {
+			return true
+		}
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"sql-genius/pkg/models"
+	"testing"
+)
+
+// tokenValues EOF를 뺀 토큰 값 목록
+func tokenValues(tokens []token) []string {
+	var values []string
+	for _, tok := range tokens {
+		if tok.kind != tokEOF {
+			values = append(values, tok.value)
+		}
+	}
+	return values
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		src    string
+		want   []string
+	}{
+		{"PostgreSQL 캐스트", models.PostgreSQL, "SELECT a::int, b::text[] FROM t",
+			[]string{"SELECT", "a", "::", "int", ",", "b", "::", "text", "[", "]", "FROM", "t"}},
+		{"PostgreSQL 중첩 주석", models.PostgreSQL, "SELECT /* a /* b */ c */ 1",
+			[]string{"SELECT", "1"}},
+		{"PostgreSQL 달러 인용", models.PostgreSQL, "SELECT $$a; 'b'$$, $fn$x$fn$",
+			[]string{"SELECT", "a; 'b'", ",", "x"}},
+		{"PostgreSQL 위치 파라미터는 달러 인용이 아님", models.PostgreSQL, "SELECT $1",
+			[]string{"SELECT", "$", "1"}},
+		{"큰따옴표 식별자", models.PostgreSQL, `SELECT "My ""Col""" FROM "t"`,
+			[]string{"SELECT", `My "Col"`, "FROM", "t"}},
+		{"작은따옴표 이스케이프", models.PostgreSQL, "SELECT 'it''s', E'x'",
+			[]string{"SELECT", "it's", ",", "x"}},
+		{"줄 주석", models.PostgreSQL, "SELECT 1 -- DROP TABLE t\n, 2",
+			[]string{"SELECT", "1", ",", "2"}},
+		{"PostgreSQL --는 공백 없이도 주석", models.PostgreSQL, "SELECT 1 --1\n",
+			[]string{"SELECT", "1"}},
+		{"MySQL 백틱과 역슬래시", models.MySQL, "SELECT `a``b`, 'x\\'y' FROM t",
+			[]string{"SELECT", "a`b", ",", "x'y", "FROM", "t"}},
+		{"MySQL # 주석", models.MySQL, "SELECT 1 # 주석\n, 2",
+			[]string{"SELECT", "1", ",", "2"}},
+		{"MySQL -- 뒤 공백은 주석", models.MySQL, "SELECT 1 -- x\n",
+			[]string{"SELECT", "1"}},
+		{"MySQL -- 뒤 탭은 주석", models.MySQL, "SELECT 1 --\tx",
+			[]string{"SELECT", "1"}},
+		{"MySQL 입력 끝의 --는 주석", models.MySQL, "SELECT 1 --",
+			[]string{"SELECT", "1"}},
+		{"MySQL --1은 빼기 두 번", models.MySQL, "SELECT 1 --1; DROP TABLE t",
+			[]string{"SELECT", "1", "-", "-", "1", ";", "DROP", "TABLE", "t"}},
+		{"MySQL 실행 주석 본문은 코드", models.MySQL, "SELECT 1 /*!; DROP TABLE t */",
+			[]string{"SELECT", "1", ";", "DROP", "TABLE", "t"}},
+		{"MySQL 버전 실행 주석", models.MySQL, "CREATE TABLE t (a INT) /*!50100 ENGINE=InnoDB */",
+			[]string{"CREATE", "TABLE", "t", "(", "a", "INT", ")", "ENGINE", "=", "InnoDB"}},
+		{"MySQL 옵티마이저 힌트", models.MySQL, "SELECT /*+ BKA(t) */ a FROM t",
+			[]string{"SELECT", "BKA", "(", "t", ")", "a", "FROM", "t"}},
+		{"MySQL 실행 주석 안의 일반 주석", models.MySQL, "SELECT /*! 1 /* c */ */",
+			[]string{"SELECT", "1"}},
+		{"MySQL 일반 주석", models.MySQL, "SELECT /* ; DROP */ 1",
+			[]string{"SELECT", "1"}},
+		{"PostgreSQL에서 /*!는 일반 주석", models.PostgreSQL, "SELECT 1 /*!; DROP TABLE t */",
+			[]string{"SELECT", "1"}},
+		{"SQL Server 대괄호와 GO", models.SQLServer, "SELECT [a b], [x]]y]\nGO\nSELECT go",
+			[]string{"SELECT", "a b", ",", "x]y", ";", "SELECT", "go"}},
+		{"SQLite 대괄호", models.SQLite, "SELECT [a] FROM t",
+			[]string{"SELECT", "a", "FROM", "t"}},
+		{"Oracle q 인용과 슬래시", models.Oracle, "SELECT q'[it's]' FROM dual\n/\nSELECT 4 / 2 FROM dual",
+			[]string{"SELECT", "it's", "FROM", "dual", ";", "SELECT", "4", "/", "2", "FROM", "dual"}},
+		{"숫자", models.PostgreSQL, "SELECT 1.5, .5, 1e10, 2E-3",
+			[]string{"SELECT", "1.5", ",", ".5", ",", "1e10", ",", "2E-3"}},
+		{"두 글자 연산자", models.PostgreSQL, "a <> b != c <= d >= e || f => g",
+			[]string{"a", "<>", "b", "!=", "c", "<=", "d", ">=", "e", "||", "f", "=>", "g"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := tokenize(tt.src, tt.dbType)
+			if err != nil {
+				t.Fatalf("tokenize() error = %v", err)
+			}
+			if got := tokenValues(tokens); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeKinds(t *testing.T) {
+	tokens, err := tokenize(`SELECT "a", 'b', 1, c;`, models.PostgreSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []tokenKind{tokIdent, tokQuotedIdent, tokPunct, tokString, tokPunct, tokNumber, tokPunct, tokIdent, tokPunct, tokEOF}
+	var got []tokenKind
+	for _, tok := range tokens {
+		got = append(got, tok.kind)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("kinds = %v, want %v", got, want)
+	}
+	if tokens[0].upper() != "SELECT" || tokens[1].upper() != "" {
+		t.Errorf("upper() = %q, %q (따옴표 식별자는 키워드가 아님)", tokens[0].upper(), tokens[1].upper())
+	}
+}
+
+func TestTokenizePositions(t *testing.T) {
+	tokens, err := tokenize("SELECT\n  a, /* x */ 'b'", models.PostgreSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Pos{
+		{Offset: 0, Line: 1, Column: 1},
+		{Offset: 9, Line: 2, Column: 3},
+		{Offset: 10, Line: 2, Column: 4},
+		{Offset: 20, Line: 2, Column: 14},
+	}
+	for i, pos := range want {
+		if tokens[i].pos != pos {
+			t.Errorf("tokens[%d].pos = %+v, want %+v", i, tokens[i].pos, pos)
+		}
+	}
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		src    string
+		pos    string
+		msg    string
+	}{
+		{"닫히지 않은 문자열", models.PostgreSQL, "SELECT\n  'abc", "2:3", "닫히지 않은 문자열"},
+		{"닫히지 않은 식별자", models.PostgreSQL, `SELECT "abc`, "1:8", `닫히지 않은 식별자 "`},
+		{"닫히지 않은 대괄호", models.SQLServer, "SELECT [abc", "1:8", "닫히지 않은 식별자 ["},
+		{"닫히지 않은 주석", models.MySQL, "SELECT 1 /* x", "1:10", "닫히지 않은 주석"},
+		{"닫히지 않은 중첩 주석", models.PostgreSQL, "SELECT /* a /* b */", "1:8", "닫히지 않은 주석"},
+		{"닫히지 않은 달러 인용", models.PostgreSQL, "SELECT $$abc", "1:8", "닫히지 않은 문자열 $$"},
+		{"닫히지 않은 q 인용", models.Oracle, "SELECT q'[abc", "1:8", "닫히지 않은 문자열"},
+		{"닫히지 않은 MySQL 실행 주석", models.MySQL, "SELECT 1 /*! 2", "1:10", "닫히지 않은 주석"},
+		{"중첩된 MySQL 실행 주석", models.MySQL, "SELECT /*! 1 /*! 2 */ */", "1:14", "실행 주석 안에 다시 실행 주석을 쓸 수 없습니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tokenize(tt.src, tt.dbType)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("tokenize() error = %v, want *ParseError", err)
+			}
+			if perr.Pos.String() != tt.pos || perr.Msg != tt.msg {
+				t.Errorf("error = %s %q, want %s %q", perr.Pos, perr.Msg, tt.pos, tt.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package schema
+
+import (
+	"fmt"
+	"sql-genius/pkg/models"
+	"strings"
+	"unicode"
+)
+
+// Migration 스키마 차이를 적용/되돌리는 DDL 스크립트
+type Migration struct {
+	Forward  string `json:"forward"`
+	Rollback string `json:"rollback"`
+}
+
+// GenerateMigration 차이에서 DB별 마이그레이션 DDL(정방향/롤백) 생성
+func (p *Parser) GenerateMigration(diff *SchemaDiff, dbType models.DBType) (*Migration, error) {
+	switch dbType {
+	case models.MySQL, models.PostgreSQL, models.Oracle, models.SQLServer:
+	default:
+		return nil, fmt.Errorf("마이그레이션 DDL을 지원하지 않는 데이터베이스: %s", dbType)
+	}
+
+	return &Migration{
+		Forward:  p.migrationScript(diff, dbType),
+		Rollback: p.migrationScript(diff.Reverse(), dbType),
+	}, nil
+}
+
+// migrationScript 의존 관계를 고려한 순서로 DDL 나열
+//  1. 외래키/인덱스/기본키 삭제 → 2. 테이블 삭제 → 3. 테이블 생성(외래키 제외)
+//  4. 컬럼 추가/변경/삭제 → 5. 기본키/인덱스 생성 → 6. 외래키 생성
+func (p *Parser) migrationScript(diff *SchemaDiff, dbType models.DBType) string {
+	var stmts []string
+	add := func(s ...string) { stmts = append(stmts, s...) }
+
+	// 1. 삭제될 제약조건
+	for _, t := range diff.DroppedTables {
+		for _, fk := range GroupForeignKeys(t.ForeignKeys) {
+			add(p.dropForeignKeySQL(t.Schema, t.Name, fk, dbType))
+		}
+	}
+	for _, td := range diff.ChangedTables {
+		for _, fk := range td.DroppedForeignKeys {
+			add(p.dropForeignKeySQL(td.Schema, td.Name, fk, dbType))
+		}
+		for _, idx := range td.DroppedIndexes {
+			add(p.dropIndexSQL(td.Schema, td.Name, idx, dbType)...)
+		}
+		if td.PrimaryKey != nil && len(td.PrimaryKey.From) > 0 {
+			add(p.dropPrimaryKeySQL(td.Schema, td.Name, dbType))
+		}
+	}
+
+	// 2. 테이블 삭제
+	for _, t := range diff.DroppedTables {
+		add(fmt.Sprintf("DROP TABLE %s;", p.quoteTable(t.Schema, t.Name, dbType)))
+	}
+
+	// 3. 테이블 생성 (외래키는 모든 테이블이 생긴 뒤 추가)
+	for _, t := range diff.AddedTables {
+		t.ForeignKeys = nil
+		ddl := p.GenerateDDL(&models.Schema{DBType: dbType, Tables: []models.Table{t}})
+		add(strings.TrimSpace(ddl))
+	}
+
+	// 4. 컬럼
+	for _, td := range diff.ChangedTables {
+		for _, col := range td.AddedColumns {
+			add(p.addColumnSQL(td.Schema, td.Name, col, dbType))
+		}
+		for _, cc := range td.ChangedColumns {
+			add(p.modifyColumnSQL(td.Schema, td.Name, cc, dbType)...)
+		}
+		for _, col := range td.DroppedColumns {
+			if dbType == models.SQLServer && col.Default != "" {
+				add(p.dropDefaultSQL(td.Schema, td.Name, col.Name))
+			}
+			add(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", p.quoteTable(td.Schema, td.Name, dbType), p.quote(col.Name, dbType)))
+		}
+	}
+
+	// 5. 기본키와 인덱스
+	for _, td := range diff.ChangedTables {
+		if td.PrimaryKey != nil && len(td.PrimaryKey.To) > 0 {
+			add(p.addPrimaryKeySQL(td.Schema, td.Name, td.PrimaryKey.To, dbType))
+		}
+		for _, idx := range td.AddedIndexes {
+			add(p.createIndexSQL(p.quoteTable(td.Schema, td.Name, dbType), idx, dbType))
+		}
+	}
+
+	// 6. 외래키
+	for _, t := range diff.AddedTables {
+		for _, fk := range GroupForeignKeys(t.ForeignKeys) {
+			add(p.addForeignKeySQL(t.Schema, t.Name, fk, dbType))
+		}
+	}
+	for _, td := range diff.ChangedTables {
+		for _, fk := range td.AddedForeignKeys {
+			add(p.addForeignKeySQL(td.Schema, td.Name, fk, dbType))
+		}
+	}
+
+	if len(stmts) == 0 {
+		return ""
+	}
+	return strings.Join(stmts, "\n") + "\n"
+}
+
+func (p *Parser) addColumnSQL(schema, table string, col models.Column, dbType models.DBType) string {
+	t := p.quoteTable(schema, table, dbType)
+	def := p.columnDefinition(col, dbType)
+	switch dbType {
+	case models.Oracle:
+		return fmt.Sprintf("ALTER TABLE %s ADD (%s);", t, def)
+	case models.SQLServer:
+		return fmt.Sprintf("ALTER TABLE %s ADD %s;", t, def)
+	default:
+		return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", t, def)
+	}
+}
+
+// modifyColumnSQL 컬럼 변경 DDL
+// MySQL은 컬럼 정의 전체를 다시 쓰고, 나머지는 바뀐 속성만 변경합니다.
+func (p *Parser) modifyColumnSQL(schema, table string, cc ColumnChange, dbType models.DBType) []string {
+	t := p.quoteTable(schema, table, dbType)
+	c := p.quote(cc.To.Name, dbType)
+	changed := func(what string) bool {
+		for _, ch := range cc.Changes {
+			if ch == what {
+				return true
+			}
+		}
+		return false
+	}
+
+	if dbType == models.MySQL {
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", t, p.columnDefinition(cc.To, dbType))}
+	}
+
+	var stmts []string
+	switch dbType {
+	case models.PostgreSQL:
+		if changed("type") {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", t, c, cc.To.Type))
+		}
+		if changed("nullable") {
+			if cc.To.Nullable {
+				stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", t, c))
+			} else {
+				stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", t, c))
+			}
+		}
+		if changed("default") {
+			if cc.To.Default == "" {
+				stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", t, c))
+			} else {
+				stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", t, c, cc.To.Default))
+			}
+		}
+
+	case models.Oracle:
+		// 이미 NOT NULL인 컬럼에 NOT NULL을 다시 지정하면 오류가 나므로 바뀐 속성만 나열
+		var parts []string
+		if changed("type") {
+			parts = append(parts, cc.To.Type)
+		}
+		if changed("default") {
+			def := cc.To.Default
+			if def == "" {
+				def = "NULL"
+			}
+			parts = append(parts, "DEFAULT "+def)
+		}
+		if changed("nullable") {
+			if cc.To.Nullable {
+				parts = append(parts, "NULL")
+			} else {
+				parts = append(parts, "NOT NULL")
+			}
+		}
+		if len(parts) > 0 {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s);", t, c, strings.Join(parts, " ")))
+		}
+
+	case models.SQLServer:
+		if changed("type") || changed("nullable") {
+			null := "NULL"
+			if !cc.To.Nullable {
+				null = "NOT NULL"
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s;", t, c, cc.To.Type, null))
+		}
+		if changed("default") {
+			// 기본값은 이름 있는 제약조건이라 기존 것을 지우고 새로 추가
+			if cc.From.Default != "" {
+				stmts = append(stmts, p.dropDefaultSQL(schema, table, cc.From.Name))
+			}
+			if cc.To.Default != "" {
+				stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD DEFAULT %s FOR %s;", t, cc.To.Default, c))
+			}
+		}
+	}
+
+	if changed("auto_increment") {
+		stmts = append(stmts, fmt.Sprintf("-- %s.%s: 자동 증가 속성 변경은 수동으로 처리해야 합니다", models.QualifiedName(schema, table), cc.To.Name))
+	}
+	return stmts
+}
+
+// dropDefaultSQL SQL Server 기본값 제약조건 삭제 (제약조건 이름을 카탈로그에서 조회)
+func (p *Parser) dropDefaultSQL(schema, table, column string) string {
+	t := p.quoteTable(schema, table, models.SQLServer)
+	v := sqlVariable("df", schema, table, column)
+	return fmt.Sprintf(`DECLARE %s sysname = (SELECT dc.name FROM sys.default_constraints dc `+
+		`JOIN sys.columns c ON dc.parent_object_id = c.object_id AND dc.parent_column_id = c.column_id `+
+		`WHERE dc.parent_object_id = OBJECT_ID('%s') AND c.name = '%s'); `+
+		`IF %s IS NOT NULL EXEC('ALTER TABLE %s DROP CONSTRAINT [' + %s + ']');`,
+		v, sqlString(t), sqlString(column), v, sqlString(t), v)
+}
+
+// dropPrimaryKeySQL 기본키 삭제 (제약조건 이름은 스키마 없는 테이블 이름 기준)
+func (p *Parser) dropPrimaryKeySQL(schema, table string, dbType models.DBType) string {
+	t := p.quoteTable(schema, table, dbType)
+	switch dbType {
+	case models.PostgreSQL:
+		// 이름 없이 만든 기본키는 <테이블>_pkey
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", t, p.quote(table+"_pkey", dbType))
+	case models.SQLServer:
+		v := sqlVariable("pk", schema, table)
+		return fmt.Sprintf(`DECLARE %s sysname = (SELECT name FROM sys.key_constraints `+
+			`WHERE parent_object_id = OBJECT_ID('%s') AND type = 'PK'); `+
+			`IF %s IS NOT NULL EXEC('ALTER TABLE %s DROP CONSTRAINT [' + %s + ']');`,
+			v, sqlString(t), v, sqlString(t), v)
+	default:
+		return fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", t)
+	}
+}
+
+func (p *Parser) addPrimaryKeySQL(schema, table string, columns []string, dbType models.DBType) string {
+	t := p.quoteTable(schema, table, dbType)
+	switch dbType {
+	case models.PostgreSQL:
+		return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s);",
+			t, p.quote(table+"_pkey", dbType), p.quoteList(columns, dbType))
+	case models.SQLServer:
+		return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s);",
+			t, p.quote("PK_"+table, dbType), p.quoteList(columns, dbType))
+	default:
+		return fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s);", t, p.quoteList(columns, dbType))
+	}
+}
+
+// dropIndexSQL 인덱스 삭제 (PostgreSQL/Oracle 인덱스는 테이블의 스키마에 속하므로 인덱스 이름을 스키마로 한정)
+func (p *Parser) dropIndexSQL(schema, table string, idx models.Index, dbType models.DBType) []string {
+	t := p.quoteTable(schema, table, dbType)
+	switch dbType {
+	case models.MySQL, models.SQLServer:
+		return []string{fmt.Sprintf("DROP INDEX %s ON %s;", p.quote(idx.Name, dbType), t)}
+	case models.PostgreSQL:
+		// UNIQUE 제약조건으로 만든 인덱스는 DROP INDEX로 지울 수 없음
+		if idx.IsUnique {
+			return []string{
+				fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", t, p.quote(idx.Name, dbType)),
+				fmt.Sprintf("DROP INDEX IF EXISTS %s;", p.quoteTable(schema, idx.Name, dbType)),
+			}
+		}
+		return []string{fmt.Sprintf("DROP INDEX %s;", p.quoteTable(schema, idx.Name, dbType))}
+	default:
+		return []string{fmt.Sprintf("DROP INDEX %s;", p.quoteTable(schema, idx.Name, dbType))}
+	}
+}
+
+func (p *Parser) addForeignKeySQL(schema, table string, fk ForeignKey, dbType models.DBType) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", p.quoteTable(schema, table, dbType), p.foreignKeyClause(table, fk, dbType))
+}
+
+func (p *Parser) dropForeignKeySQL(schema, table string, fk ForeignKey, dbType models.DBType) string {
+	t := p.quoteTable(schema, table, dbType)
+	name := fk.Name
+	if name == "" {
+		name = fmt.Sprintf("fk_%s_%s", table, strings.Join(fk.Columns, "_"))
+	}
+	if dbType == models.MySQL {
+		return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", t, p.quote(name, dbType))
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", t, p.quote(name, dbType))
+}
+
+// sqlVariable T-SQL 변수 이름 (한 배치에서 중복 선언되지 않도록 대상 이름을 포함)
+func sqlVariable(prefix string, names ...string) string {
+	var sb strings.Builder
+	sb.WriteString("@" + prefix)
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		sb.WriteString("_")
+		for _, r := range name {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				sb.WriteRune(r)
+			} else {
+				sb.WriteRune('_')
+			}
+		}
+	}
+	return sb.String()
+}
+
+// sqlString 작은따옴표 문자열 리터럴 이스케이프
+func sqlString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"fmt"
+	"sql-genius/pkg/models"
+)
+
+// RowNumColumn ROWNUM으로 감싼 페이지 쿼리가 결과 마지막에 덧붙이는 행 번호 컬럼
+const RowNumColumn = "SG_PAGE_RN"
+
+// pageBlockers 이미 행 수·잠금·출력 대상을 정하는 최상위 절 (범위를 덧붙이면 문법 오류나 다른 결과가 됨)
+var pageBlockers = []string{"LIMIT", "OFFSET", "FETCH", "TOP", "FOR", "INTO", "OPTION", "PROCEDURE", "LOCK"}
+
+// PageQuery 조회 쿼리가 offset개 행을 건너뛴 뒤 limit행만 반환하도록 방언의 문법으로 범위를 넣음
+// PostgreSQL, MySQL, SQLite는 LIMIT/OFFSET, SQL Server와 Oracle 12c 이상은 OFFSET ... FETCH NEXT,
+// rowNum이면 (Oracle 11g 이하) ROWNUM으로 감싸며 결과 마지막에 RowNumColumn 컬럼이 붙습니다.
+// SELECT/WITH 한 문장이 아니거나 LIMIT, FETCH, TOP, FOR UPDATE 같은 절이 이미 있으면 ok가 false입니다.
+func (p *Parser) PageQuery(query string, dbType models.DBType, offset, limit int, rowNum bool) (paged string, ok bool, err error) {
+	tokens, err := tokenize(query, dbType)
+	if err != nil {
+		return "", false, fmt.Errorf("SQL 분석 실패: %w", err)
+	}
+	stmts := splitTokenStatements(tokens)
+	if len(stmts) != 1 {
+		return "", false, nil
+	}
+
+	d := &dmlParser{toks: stmts[0], src: []rune(query), dbType: dbType}
+	switch d.tok(0).upper() {
+	case "SELECT":
+	case "WITH":
+		// 데이터 변경 CTE (WITH ... INSERT/UPDATE/DELETE)
+		if d.findTop(0, "INSERT", "UPDATE", "DELETE", "MERGE") < len(d.toks) {
+			return "", false, nil
+		}
+	default:
+		return "", false, nil
+	}
+	if d.findTop(0, pageBlockers...) < len(d.toks) {
+		return "", false, nil
+	}
+
+	// 마지막 토큰까지의 원문 (끝의 주석과 세미콜론 제외)
+	body := d.text(0, len(d.toks))
+	switch {
+	case rowNum:
+		return fmt.Sprintf("SELECT * FROM (SELECT sg_page.*, ROWNUM AS %s FROM (%s) sg_page WHERE ROWNUM <= %d) WHERE %s > %d",
+			RowNumColumn, body, offset+limit, RowNumColumn, offset), true, nil
+	case dbType == models.SQLServer:
+		// OFFSET ... FETCH는 ORDER BY가 있어야 함 (정렬이 없으면 순서를 바꾸지 않는 식으로)
+		if d.findTop(0, "ORDER") == len(d.toks) {
+			body += " ORDER BY (SELECT NULL)"
+		}
+		return fmt.Sprintf("%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", body, offset, limit), true, nil
+	case dbType == models.Oracle:
+		return fmt.Sprintf("%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", body, offset, limit), true, nil
+	}
+	return fmt.Sprintf("%s LIMIT %d OFFSET %d", body, limit, offset), true, nil
+}
@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"sql-genius/pkg/models"
+	"testing"
+)
+
+func TestPageQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType models.DBType
+		query  string
+		rowNum bool
+		want   string // 빈 문자열이면 범위를 넣을 수 없음
+	}{
+		{"PostgreSQL", models.PostgreSQL, "SELECT * FROM t ORDER BY id", false, "SELECT * FROM t ORDER BY id LIMIT 11 OFFSET 20"},
+		{"끝의 세미콜론과 주석", models.SQLite, "SELECT * FROM t; -- 끝\n", false, "SELECT * FROM t LIMIT 11 OFFSET 20"},
+		{"CTE", models.MySQL, "WITH x AS (SELECT 1 AS a LIMIT 5) SELECT a FROM x", false, "WITH x AS (SELECT 1 AS a LIMIT 5) SELECT a FROM x LIMIT 11 OFFSET 20"},
+		{"SQL Server 정렬 있음", models.SQLServer, "SELECT * FROM t ORDER BY id", false, "SELECT * FROM t ORDER BY id OFFSET 20 ROWS FETCH NEXT 11 ROWS ONLY"},
+		{"SQL Server 정렬 없음", models.SQLServer, "SELECT * FROM t", false, "SELECT * FROM t ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 11 ROWS ONLY"},
+		{"Oracle 12c", models.Oracle, "SELECT * FROM t WHERE a = :a", false, "SELECT * FROM t WHERE a = :a OFFSET 20 ROWS FETCH NEXT 11 ROWS ONLY"},
+		{"Oracle ROWNUM", models.Oracle, "SELECT * FROM t ORDER BY id", true, "SELECT * FROM (SELECT sg_page.*, ROWNUM AS SG_PAGE_RN FROM (SELECT * FROM t ORDER BY id) sg_page WHERE ROWNUM <= 31) WHERE SG_PAGE_RN > 20"},
+		{"이미 LIMIT", models.PostgreSQL, "SELECT * FROM t LIMIT 5", false, ""},
+		{"이미 FETCH", models.Oracle, "SELECT * FROM t FETCH FIRST 5 ROWS ONLY", false, ""},
+		{"TOP", models.SQLServer, "SELECT TOP 5 * FROM t", false, ""},
+		{"FOR UPDATE", models.MySQL, "SELECT * FROM t FOR UPDATE", false, ""},
+		{"SHOW", models.MySQL, "SHOW TABLES", false, ""},
+		{"여러 문장", models.PostgreSQL, "SELECT 1; SELECT 2", false, ""},
+		{"CTE INSERT", models.SQLServer, "WITH x AS (SELECT 1 AS a) INSERT INTO t SELECT a FROM x", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := NewParser().PageQuery(tt.query, tt.dbType, 20, 11, tt.rowNum)
+			if err != nil {
+				t.Fatalf("PageQuery() error = %v", err)
+			}
+			if ok != (tt.want != "") || got != tt.want {
+				t.Errorf("PageQuery() = %q, %v, want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
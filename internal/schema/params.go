@@ -0,0 +1,135 @@
+package schema
+
+import (
+	"fmt"
+	"sql-genius/pkg/models"
+	"strconv"
+	"strings"
+)
+
+// Placeholder 쿼리 안의 바인드 파라미터
+type Placeholder struct {
+	Name  string // 이름 있는 파라미터 (:name, @name)
+	Index int    // 위치 파라미터 번호 (1부터, ?는 등장 순서)
+	Text  string // 원문 (:name, $1, ? 등)
+	Start int    // 원문 rune 오프셋
+	End   int
+}
+
+// Positional 위치 파라미터인지
+func (ph Placeholder) Positional() bool {
+	return ph.Name == ""
+}
+
+// Placeholders 쿼리에서 바인드 파라미터를 찾음 (문자열, 주석, PostgreSQL :: 캐스트는 제외)
+// 인식하는 형식: :name (전체), ? (PostgreSQL 제외), $1 (PostgreSQL), :1 (Oracle), @name·@p1 (SQL Server)
+func (p *Parser) Placeholders(query string, dbType models.DBType) ([]Placeholder, error) {
+	tokens, err := tokenize(query, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("SQL 분석 실패: %w", err)
+	}
+	src := []rune(query)
+
+	var result []Placeholder
+	question := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.kind != tokPunct {
+			continue
+		}
+
+		var next token
+		adjacent := false
+		if i+1 < len(tokens) {
+			next = tokens[i+1]
+			adjacent = next.kind != tokEOF && next.pos.Offset == t.end
+		}
+		// @@ROWCOUNT 같은 시스템 변수의 두 번째 @ 제외
+		afterAt := i > 0 && tokens[i-1].kind == tokPunct && tokens[i-1].value == "@" && tokens[i-1].end == t.pos.Offset
+
+		ph := Placeholder{Start: t.pos.Offset}
+		switch {
+		case t.value == "?" && dbType != models.PostgreSQL:
+			question++
+			ph.Index = question
+			ph.End = t.end
+		case t.value == "$" && dbType == models.PostgreSQL && adjacent && next.kind == tokNumber:
+			n, err := strconv.Atoi(next.value)
+			if err != nil || n < 1 {
+				continue
+			}
+			ph.Index = n
+		case t.value == ":" && adjacent && next.kind == tokIdent:
+			ph.Name = next.value
+		case t.value == ":" && adjacent && next.kind == tokNumber && dbType == models.Oracle:
+			n, err := strconv.Atoi(next.value)
+			if err != nil || n < 1 {
+				continue
+			}
+			ph.Index = n
+		case t.value == "@" && dbType == models.SQLServer && adjacent && next.kind == tokIdent && !afterAt:
+			if n, ok := sqlServerPositional(next.value); ok {
+				ph.Index = n
+			} else {
+				ph.Name = next.value
+			}
+		default:
+			continue
+		}
+		if ph.End == 0 {
+			ph.End = next.end
+			i++
+		}
+		ph.Text = string(src[ph.Start:ph.End])
+		result = append(result, ph)
+	}
+	return result, nil
+}
+
+// sqlServerPositional @p1 형태면 번호 반환
+func sqlServerPositional(name string) (int, bool) {
+	if len(name) < 2 || (name[0] != 'p' && name[0] != 'P') {
+		return 0, false
+	}
+	n, err := strconv.Atoi(name[1:])
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// RewritePlaceholders 바인드 파라미터를 replace 결과로 바꾼 쿼리와 찾은 파라미터 목록
+func (p *Parser) RewritePlaceholders(query string, dbType models.DBType, replace func(Placeholder) string) (string, []Placeholder, error) {
+	placeholders, err := p.Placeholders(query, dbType)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(placeholders) == 0 {
+		return query, nil, nil
+	}
+
+	src := []rune(query)
+	var sb strings.Builder
+	last := 0
+	for _, ph := range placeholders {
+		sb.WriteString(string(src[last:ph.Start]))
+		sb.WriteString(replace(ph))
+		last = ph.End
+	}
+	sb.WriteString(string(src[last:]))
+	return sb.String(), placeholders, nil
+}
+
+// ParamNames 이름 있는 파라미터 이름 (처음 나온 순서, 중복 제거)
+func ParamNames(placeholders []Placeholder) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, ph := range placeholders {
+		if ph.Name == "" || seen[ph.Name] {
+			continue
+		}
+		seen[ph.Name] = true
+		names = append(names, ph.Name)
+	}
+	return names
+}
@@ -3,7 +3,6 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"sql-genius/pkg/models"
 	"strings"
 )
@@ -25,198 +24,259 @@ func (p *Parser) ParseJSON(data []byte) (*models.Schema, error) {
 	return &schema, nil
 }
 
-// ParseDDL DDL (CREATE TABLE) 문에서 스키마 파싱
+// ParseDDL DDL 문에서 스키마 파싱
+// ALTER/DROP 문이 포함되어 있으면 순서대로 적용한 최종 상태를 반환합니다.
 func (p *Parser) ParseDDL(ddl string, dbType models.DBType) (*models.Schema, error) {
 	schema := &models.Schema{
 		DBType: dbType,
 		Tables: []models.Table{},
 	}
 
-	// CREATE TABLE 문 찾기
-	tablePattern := regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(([\s\S]*?)\)`)
-	matches := tablePattern.FindAllStringSubmatch(ddl, -1)
-
-	for _, match := range matches {
-		if len(match) < 3 {
-			continue
-		}
+	if err := p.ApplyDDL(schema, ddl); err != nil {
+		return nil, fmt.Errorf("DDL 파싱 실패: %w", err)
+	}
+	return schema, nil
+}
 
-		tableName := match[1]
-		columnsDef := match[2]
+// buildTable CREATE TABLE 구문 트리를 테이블 모델로 변환
+func (p *Parser) buildTable(stmt *CreateTableStmt, dbType models.DBType) models.Table {
+	table := models.Table{
+		Schema:  stmt.Name.Schema,
+		Name:    stmt.Name.Name,
+		Columns: []models.Column{},
+	}
 
-		table := models.Table{
-			Name:    tableName,
-			Columns: []models.Column{},
+	for _, def := range stmt.Columns {
+		table.Columns = append(table.Columns, columnFromDef(def))
+		if def.PrimaryKey {
+			table.PrimaryKey = append(table.PrimaryKey, def.Name)
+		}
+		if def.References != nil {
+			addConstraint(&table, *def.References, dbType)
+		}
+		for _, c := range def.Checks {
+			addConstraint(&table, c, dbType)
 		}
-
-		// 컬럼 파싱
-		columns := p.parseColumns(columnsDef, dbType)
-		table.Columns = columns
-
-		// PRIMARY KEY 파싱
-		pks := p.parsePrimaryKey(columnsDef)
-		table.PrimaryKey = pks
-
-		// FOREIGN KEY 파싱
-		fks := p.parseForeignKeys(columnsDef)
-		table.ForeignKeys = fks
-
-		schema.Tables = append(schema.Tables, table)
 	}
 
-	// CREATE INDEX 문 파싱
-	p.parseIndexes(ddl, schema)
+	for _, c := range stmt.Constraints {
+		addConstraint(&table, c, dbType)
+	}
 
-	return schema, nil
+	return table
 }
 
-func (p *Parser) parseColumns(columnsDef string, dbType models.DBType) []models.Column {
-	var columns []models.Column
-
-	// 줄 단위로 분리
-	lines := strings.Split(columnsDef, ",")
-
-	columnPattern := regexp.MustCompile(`^\s*[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+(\w+(?:\([^)]+\))?)\s*(.*)$`)
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+// columnFromDef 컬럼 정의를 컬럼 모델로 변환
+func columnFromDef(def ColumnDef) models.Column {
+	col := models.Column{
+		Name:     def.Name,
+		Type:     def.Type,
+		Nullable: !def.NotNull && !def.PrimaryKey,
+		Default:  def.Default,
+		Comment:  def.Comment,
+		IsPK:     def.PrimaryKey,
+		IsUnique: def.Unique,
+		// AUTO_INCREMENT / SERIAL / IDENTITY
+		IsAutoIncr: def.AutoIncr || strings.Contains(strings.ToUpper(def.Type), "SERIAL"),
+		EnumValues: EnumTypeValues(def.Type),
+		Collation:  def.Collation,
+		Generated:  TrimParens(def.Generated),
+		Stored:     def.Stored,
+	}
+	if def.References != nil {
+		col.IsFK = true
+	}
+	return col
+}
 
-		// 제약조건 라인 스킵
-		upperLine := strings.ToUpper(line)
-		if strings.HasPrefix(upperLine, "PRIMARY KEY") ||
-			strings.HasPrefix(upperLine, "FOREIGN KEY") ||
-			strings.HasPrefix(upperLine, "CONSTRAINT") ||
-			strings.HasPrefix(upperLine, "INDEX") ||
-			strings.HasPrefix(upperLine, "KEY") ||
-			strings.HasPrefix(upperLine, "UNIQUE") {
-			continue
+// addConstraint 테이블 제약조건을 테이블 모델에 반영
+func addConstraint(table *models.Table, c TableConstraint, dbType models.DBType) {
+	switch c.Kind {
+	case ConstraintPrimaryKey:
+		table.PrimaryKey = append([]string{}, c.Columns...)
+		for _, name := range c.Columns {
+			if col := findColumn(table, name); col != nil {
+				col.IsPK = true
+				col.Nullable = false
+			}
 		}
 
-		match := columnPattern.FindStringSubmatch(line)
-		if len(match) < 3 {
-			continue
+	case ConstraintForeignKey:
+		name := c.Name
+		if name == "" && len(c.Columns) > 0 {
+			name = fmt.Sprintf("fk_%s_%s", c.Columns[0], c.RefTable.Name)
 		}
+		// 복합 외래키는 커넥터와 동일하게 컬럼 쌍마다 같은 이름으로 기록
+		for i, colName := range c.Columns {
+			fk := models.FK{
+				Name:      name,
+				Column:    colName,
+				RefSchema: c.RefTable.Schema,
+				RefTable:  c.RefTable.Name,
+				OnDelete:  c.OnDelete,
+				OnUpdate:  c.OnUpdate,
+			}
+			if i < len(c.RefColumns) {
+				fk.RefColumn = c.RefColumns[i]
+			}
+			table.ForeignKeys = append(table.ForeignKeys, fk)
 
-		col := models.Column{
-			Name: match[1],
-			Type: match[2],
+			if col := findColumn(table, colName); col != nil {
+				col.IsFK = true
+			}
 		}
 
-		constraints := strings.ToUpper(match[3])
-
-		// NOT NULL 확인
-		col.Nullable = !strings.Contains(constraints, "NOT NULL")
-
-		// PRIMARY KEY 확인
-		col.IsPK = strings.Contains(constraints, "PRIMARY KEY")
-
-		// UNIQUE 확인
-		col.IsUnique = strings.Contains(constraints, "UNIQUE")
-
-		// AUTO_INCREMENT / SERIAL / IDENTITY 확인
-		col.IsAutoIncr = strings.Contains(constraints, "AUTO_INCREMENT") ||
-			strings.Contains(strings.ToUpper(col.Type), "SERIAL") ||
-			strings.Contains(constraints, "IDENTITY")
-
-		// DEFAULT 값 파싱
-		defaultPattern := regexp.MustCompile(`(?i)DEFAULT\s+([^\s,]+)`)
-		if defaultMatch := defaultPattern.FindStringSubmatch(match[3]); len(defaultMatch) > 1 {
-			col.Default = defaultMatch[1]
+	case ConstraintUnique, ConstraintIndex:
+		name := c.Name
+		if name == "" {
+			prefix := "uq"
+			if c.Kind == ConstraintIndex {
+				prefix = "idx"
+			}
+			name = fmt.Sprintf("%s_%s_%s", prefix, table.Name, strings.Join(c.Columns, "_"))
+		}
+		indexType := c.IndexType
+		if indexType == "" {
+			indexType = "BTREE"
+		}
+		table.Indexes = append(table.Indexes, models.Index{
+			Name:     name,
+			Columns:  c.Columns,
+			IsUnique: c.Kind == ConstraintUnique,
+			Type:     indexType,
+		})
+
+		if c.Kind == ConstraintUnique && len(c.Columns) == 1 {
+			if col := findColumn(table, c.Columns[0]); col != nil {
+				col.IsUnique = true
+			}
 		}
 
-		// COMMENT 파싱 (MySQL)
-		commentPattern := regexp.MustCompile(`(?i)COMMENT\s+'([^']*)'`)
-		if commentMatch := commentPattern.FindStringSubmatch(match[3]); len(commentMatch) > 1 {
-			col.Comment = commentMatch[1]
+	case ConstraintDefault:
+		if len(c.Columns) == 1 {
+			if col := findColumn(table, c.Columns[0]); col != nil {
+				col.Default = c.Expr
+			}
 		}
 
-		columns = append(columns, col)
+	case ConstraintCheck:
+		// 컬럼 수준 CHECK는 그 컬럼, 테이블 수준은 표현식에서 참조 컬럼을 찾음
+		AddCheck(table, models.Check{Name: c.Name, Columns: c.Columns, Expression: c.Expr}, dbType)
 	}
+}
 
-	return columns
+// resolveReferences 참조 컬럼이 생략된 외래키를 참조 테이블의 기본키로 채움
+func resolveReferences(schema *models.Schema) {
+	for i := range schema.Tables {
+		for j := range schema.Tables[i].ForeignKeys {
+			fk := &schema.Tables[i].ForeignKeys[j]
+			if fk.RefColumn != "" {
+				continue
+			}
+			if ref := lookupTable(schema, fk.RefSchema, fk.RefTable); ref != nil && len(ref.PrimaryKey) > 0 {
+				fk.RefColumn = ref.PrimaryKey[0]
+			}
+		}
+	}
 }
 
-func (p *Parser) parsePrimaryKey(columnsDef string) []string {
-	pkPattern := regexp.MustCompile(`(?i)PRIMARY\s+KEY\s*\(([^)]+)\)`)
-	match := pkPattern.FindStringSubmatch(columnsDef)
-	if len(match) < 2 {
-		return nil
+// ResolveTypes enum 타입과 도메인을 쓰는 컬럼에 값 목록과 기본 타입을 채움
+// 도메인 컬럼은 Type을 도메인의 기본 타입으로, Domain을 도메인 이름으로 바꿉니다.
+func ResolveTypes(schema *models.Schema) {
+	if len(schema.Types) == 0 {
+		return
 	}
+	for i := range schema.Tables {
+		table := &schema.Tables[i]
+		for j := range table.Columns {
+			col := &table.Columns[j]
+			name := col.Type
+			if col.Domain != "" {
+				name = col.Domain
+			}
+			t := lookupType(schema, name)
+			if t == nil {
+				continue
+			}
 
-	cols := strings.Split(match[1], ",")
-	var pks []string
-	for _, col := range cols {
-		col = strings.TrimSpace(col)
-		col = strings.Trim(col, "`\"'[]")
-		if col != "" {
-			pks = append(pks, col)
+			switch t.Kind {
+			case models.TypeEnum:
+				col.EnumValues = append([]string(nil), t.Values...)
+			case models.TypeDomain:
+				col.Domain = name
+				col.Type = t.BaseType
+				if values := CheckValues(t.Check, "VALUE", schema.DBType); values != nil {
+					col.EnumValues = values
+				}
+			}
 		}
 	}
-	return pks
 }
 
-func (p *Parser) parseForeignKeys(columnsDef string) []models.FK {
-	fkPattern := regexp.MustCompile(`(?i)(?:CONSTRAINT\s+(\w+)\s+)?FOREIGN\s+KEY\s*\([` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\)\s*REFERENCES\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\([` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\)`)
-	matches := fkPattern.FindAllStringSubmatch(columnsDef, -1)
-
-	var fks []models.FK
-	for _, match := range matches {
-		fk := models.FK{
-			Column:    match[2],
-			RefTable:  match[3],
-			RefColumn: match[4],
+// lookupType 타입 이름(schema.name 가능, 따옴표 허용)으로 사용자 정의 타입 찾기
+func lookupType(schema *models.Schema, typeName string) *models.UserType {
+	schemaName, name := "", strings.ReplaceAll(typeName, `"`, "")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		schemaName, name = name[:i], name[i+1:]
+	}
+	for i := range schema.Types {
+		t := &schema.Types[i]
+		if !strings.EqualFold(t.Name, name) {
+			continue
 		}
-		if match[1] != "" {
-			fk.Name = match[1]
-		} else {
-			fk.Name = fmt.Sprintf("fk_%s_%s", match[2], match[3])
+		if schemaName != "" && t.Schema != "" && !strings.EqualFold(t.Schema, schemaName) {
+			continue
 		}
-		fks = append(fks, fk)
+		return t
 	}
-	return fks
+	return nil
 }
 
-func (p *Parser) parseIndexes(ddl string, schema *models.Schema) {
-	indexPattern := regexp.MustCompile(`(?i)CREATE\s+(UNIQUE\s+)?INDEX\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+ON\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(([^)]+)\)`)
-	matches := indexPattern.FindAllStringSubmatch(ddl, -1)
-
-	for _, match := range matches {
-		if len(match) < 5 {
-			continue
+func findTable(schema *models.Schema, name string) *models.Table {
+	for i := range schema.Tables {
+		if strings.EqualFold(schema.Tables[i].Name, name) {
+			return &schema.Tables[i]
 		}
+	}
+	return nil
+}
 
-		isUnique := strings.TrimSpace(match[1]) != ""
-		indexName := match[2]
-		tableName := match[3]
-		columnStr := match[4]
-
-		cols := strings.Split(columnStr, ",")
-		var columns []string
-		for _, col := range cols {
-			col = strings.TrimSpace(col)
-			col = strings.Trim(col, "`\"'[]")
-			// ASC/DESC 제거
-			col = strings.Split(col, " ")[0]
-			if col != "" {
-				columns = append(columns, col)
-			}
+// lookupTable 스키마로 한정한 테이블 찾기
+// 양쪽 모두 스키마가 있을 때만 스키마를 비교하고, 없으면 이름만으로 찾습니다.
+func lookupTable(s *models.Schema, schemaName, name string) *models.Table {
+	if schemaName == "" {
+		return findTable(s, name)
+	}
+	for i := range s.Tables {
+		t := &s.Tables[i]
+		if strings.EqualFold(t.Name, name) && (t.Schema == "" || strings.EqualFold(t.Schema, schemaName)) {
+			return t
 		}
+	}
+	return nil
+}
 
-		idx := models.Index{
-			Name:     indexName,
-			Columns:  columns,
-			IsUnique: isUnique,
-			Type:     "BTREE",
+// lookupView 뷰를 컬럼 확인용 테이블로 찾기 (스키마 비교는 lookupTable과 같음)
+func lookupView(s *models.Schema, schemaName, name string) *models.Table {
+	for _, v := range s.Views {
+		if !strings.EqualFold(v.Name, name) {
+			continue
 		}
+		if schemaName != "" && v.Schema != "" && !strings.EqualFold(v.Schema, schemaName) {
+			continue
+		}
+		return &models.Table{Schema: v.Schema, Name: v.Name, Columns: v.Columns}
+	}
+	return nil
+}
 
-		// 해당 테이블에 인덱스 추가
-		for i := range schema.Tables {
-			if strings.EqualFold(schema.Tables[i].Name, tableName) {
-				schema.Tables[i].Indexes = append(schema.Tables[i].Indexes, idx)
-				break
-			}
+func findColumn(table *models.Table, name string) *models.Column {
+	for i := range table.Columns {
+		if strings.EqualFold(table.Columns[i].Name, name) {
+			return &table.Columns[i]
 		}
 	}
+	return nil
 }
 
 // ToJSON 스키마를 JSON으로 변환
@@ -225,51 +285,43 @@ func (p *Parser) ToJSON(schema *models.Schema) ([]byte, error) {
 }
 
 // GenerateDDL 스키마에서 DDL 생성
+// 사용자 정의 타입, 시퀀스, 테이블, 함수/프로시저, 뷰, 트리거 순서로 씁니다.
 func (p *Parser) GenerateDDL(schema *models.Schema) string {
 	var sb strings.Builder
 
+	for _, t := range schema.Types {
+		if stmt := p.createTypeSQL(t, schema.DBType); stmt != "" {
+			sb.WriteString(stmt + "\n\n")
+		}
+	}
+
+	for _, seq := range schema.Sequences {
+		if stmt := p.createSequenceSQL(seq, schema.DBType); stmt != "" {
+			sb.WriteString(stmt + "\n\n")
+		}
+	}
+
 	for _, table := range schema.Tables {
-		sb.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", p.quote(table.Name, schema.DBType)))
+		sb.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", p.quoteTable(table.Schema, table.Name, schema.DBType)))
 
 		var columnDefs []string
 		for _, col := range table.Columns {
-			colDef := fmt.Sprintf("  %s %s", p.quote(col.Name, schema.DBType), col.Type)
-
-			if !col.Nullable {
-				colDef += " NOT NULL"
-			}
-			if col.IsAutoIncr {
-				switch schema.DBType {
-				case models.MySQL:
-					colDef += " AUTO_INCREMENT"
-				case models.SQLServer:
-					colDef += " IDENTITY(1,1)"
-				}
-			}
-			if col.Default != "" {
-				colDef += " DEFAULT " + col.Default
-			}
-
-			columnDefs = append(columnDefs, colDef)
+			columnDefs = append(columnDefs, "  "+p.columnDefinition(col, schema.DBType))
 		}
 
 		// PRIMARY KEY
 		if len(table.PrimaryKey) > 0 {
-			pkCols := make([]string, len(table.PrimaryKey))
-			for i, pk := range table.PrimaryKey {
-				pkCols[i] = p.quote(pk, schema.DBType)
-			}
-			columnDefs = append(columnDefs, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pkCols, ", ")))
+			columnDefs = append(columnDefs, fmt.Sprintf("  PRIMARY KEY (%s)", p.quoteList(table.PrimaryKey, schema.DBType)))
+		}
+
+		// FOREIGN KEYS (복합 외래키는 하나의 제약조건으로)
+		for _, fk := range GroupForeignKeys(table.ForeignKeys) {
+			columnDefs = append(columnDefs, "  "+p.foreignKeyClause(table.Name, fk, schema.DBType))
 		}
 
-		// FOREIGN KEYS
-		for _, fk := range table.ForeignKeys {
-			fkDef := fmt.Sprintf("  CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s)",
-				p.quote(fk.Name, schema.DBType),
-				p.quote(fk.Column, schema.DBType),
-				p.quote(fk.RefTable, schema.DBType),
-				p.quote(fk.RefColumn, schema.DBType))
-			columnDefs = append(columnDefs, fkDef)
+		// CHECK
+		for _, c := range table.Checks {
+			columnDefs = append(columnDefs, "  "+p.checkClause(c, schema.DBType))
 		}
 
 		sb.WriteString(strings.Join(columnDefs, ",\n"))
@@ -280,29 +332,253 @@ func (p *Parser) GenerateDDL(schema *models.Schema) string {
 			if idx.Name == "PRIMARY" {
 				continue
 			}
-			unique := ""
-			if idx.IsUnique {
-				unique = "UNIQUE "
-			}
-			idxCols := make([]string, len(idx.Columns))
-			for i, col := range idx.Columns {
-				idxCols[i] = p.quote(col, schema.DBType)
-			}
-			sb.WriteString(fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);\n",
-				unique, p.quote(idx.Name, schema.DBType),
-				p.quote(table.Name, schema.DBType),
-				strings.Join(idxCols, ", ")))
+			sb.WriteString(p.createIndexSQL(p.quoteTable(table.Schema, table.Name, schema.DBType), idx, schema.DBType) + "\n")
 		}
 	}
 
+	p.writeObjects(&sb, schema)
 	return sb.String()
 }
 
+// writeObjects 함수/프로시저, 뷰, 트리거 DDL
+// SQL Server는 각 CREATE가 배치의 첫 문장이어야 하므로 앞뒤를 GO로 나눕니다.
+func (p *Parser) writeObjects(sb *strings.Builder, schema *models.Schema) {
+	dbType := schema.DBType
+	if dbType == models.SQLServer && sb.Len() > 0 &&
+		len(schema.Routines)+len(schema.Views)+len(schema.Triggers) > 0 {
+		sb.WriteString("GO\n\n")
+	}
+
+	for _, r := range schema.Routines {
+		if r.Definition == "" {
+			sb.WriteString(fmt.Sprintf("-- %s %s: 정의를 읽을 수 없습니다\n\n", r.Kind, r.Signature()))
+			continue
+		}
+		sb.WriteString(p.moduleSQL(r.Definition, dbType) + "\n\n")
+	}
+
+	for _, v := range schema.Views {
+		name := p.quoteTable(v.Schema, v.Name, dbType)
+		if v.Definition == "" {
+			sb.WriteString(fmt.Sprintf("-- VIEW %s: 정의를 읽을 수 없습니다\n\n", v.QualifiedName()))
+			continue
+		}
+		kind := "VIEW"
+		if v.Materialized {
+			kind = "MATERIALIZED VIEW"
+		}
+		stmt := fmt.Sprintf("CREATE %s %s AS\n%s", kind, name, v.Definition)
+		if dbType == models.SQLServer {
+			stmt += "\nGO"
+		} else {
+			stmt += ";"
+		}
+		sb.WriteString(stmt + "\n\n")
+	}
+
+	for _, t := range schema.Triggers {
+		if t.Definition == "" {
+			sb.WriteString(fmt.Sprintf("-- TRIGGER %s ON %s: 정의를 읽을 수 없습니다\n\n",
+				models.QualifiedName(t.Schema, t.Name), models.QualifiedName(t.Schema, t.Table)))
+			continue
+		}
+		sb.WriteString(p.moduleSQL(t.Definition, dbType) + "\n\n")
+	}
+}
+
+// moduleSQL 본문에 ;가 들어가는 CREATE FUNCTION/PROCEDURE/TRIGGER 문을 방언에 맞게 종료
+func (p *Parser) moduleSQL(definition string, dbType models.DBType) string {
+	definition = strings.TrimSpace(definition)
+	switch dbType {
+	case models.MySQL:
+		return "DELIMITER $$\n" + definition + " $$\nDELIMITER ;"
+	case models.Oracle:
+		return strings.TrimSuffix(definition, "/") + "\n/"
+	case models.SQLServer:
+		return definition + "\nGO"
+	default:
+		if strings.HasSuffix(definition, ";") {
+			return definition
+		}
+		return definition + ";"
+	}
+}
+
+// createSequenceSQL CREATE SEQUENCE 문 (시퀀스가 없는 MySQL, SQLite는 빈 문자열)
+func (p *Parser) createSequenceSQL(seq models.Sequence, dbType models.DBType) string {
+	switch dbType {
+	case models.MySQL, models.SQLite:
+		return ""
+	}
+
+	stmt := "CREATE SEQUENCE " + p.quoteTable(seq.Schema, seq.Name, dbType)
+	if seq.DataType != "" && (dbType == models.PostgreSQL || dbType == models.SQLServer) {
+		stmt += " AS " + seq.DataType
+	}
+	if seq.Start != 0 {
+		stmt += fmt.Sprintf(" START WITH %d", seq.Start)
+	}
+	if seq.Increment != 0 {
+		stmt += fmt.Sprintf(" INCREMENT BY %d", seq.Increment)
+	}
+	if seq.Cycle {
+		stmt += " CYCLE"
+	}
+	return stmt + ";"
+}
+
+// createTypeSQL CREATE TYPE ... AS ENUM / CREATE DOMAIN 문 (PostgreSQL 외에는 빈 문자열)
+func (p *Parser) createTypeSQL(t models.UserType, dbType models.DBType) string {
+	if dbType != models.PostgreSQL {
+		return ""
+	}
+
+	name := p.quoteTable(t.Schema, t.Name, dbType)
+	if t.Kind == models.TypeEnum {
+		values := make([]string, len(t.Values))
+		for i, v := range t.Values {
+			values[i] = "'" + sqlString(v) + "'"
+		}
+		return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);", name, strings.Join(values, ", "))
+	}
+
+	stmt := fmt.Sprintf("CREATE DOMAIN %s AS %s", name, t.BaseType)
+	if t.Default != "" {
+		stmt += " DEFAULT " + t.Default
+	}
+	if t.NotNull {
+		stmt += " NOT NULL"
+	}
+	if t.Check != "" {
+		stmt += " CHECK (" + t.Check + ")"
+	}
+	return stmt + ";"
+}
+
+// columnDefinition 컬럼 정의 (이름 타입 [COLLATE ..] [계산식 | DEFAULT ..] [NOT NULL] [자동 증가])
+// Oracle은 DEFAULT가 NOT NULL보다 앞에 와야 하므로 모든 DB에서 이 순서를 사용합니다.
+// SQL Server 계산 컬럼은 타입 없이 이름 AS (식) [PERSISTED]로 씁니다.
+func (p *Parser) columnDefinition(col models.Column, dbType models.DBType) string {
+	if col.Generated != "" && dbType == models.SQLServer {
+		colDef := fmt.Sprintf("%s AS (%s)", p.quote(col.Name, dbType), col.Generated)
+		if col.Stored {
+			colDef += " PERSISTED"
+			if !col.Nullable {
+				colDef += " NOT NULL"
+			}
+		}
+		return colDef
+	}
+
+	typ := col.Type
+	if col.Domain != "" && dbType == models.PostgreSQL {
+		typ = col.Domain
+	}
+	colDef := fmt.Sprintf("%s %s", p.quote(col.Name, dbType), typ)
+
+	if col.Collation != "" {
+		colDef += " COLLATE " + p.collation(col.Collation, dbType)
+	}
+	if col.Generated != "" {
+		colDef += " GENERATED ALWAYS AS (" + col.Generated + ")"
+		// PostgreSQL은 STORED만, Oracle은 VIRTUAL만 지원
+		if dbType == models.PostgreSQL || (col.Stored && dbType != models.Oracle) {
+			colDef += " STORED"
+		} else {
+			colDef += " VIRTUAL"
+		}
+	} else if col.Default != "" {
+		colDef += " DEFAULT " + col.Default
+	}
+	if !col.Nullable {
+		colDef += " NOT NULL"
+	}
+	if col.IsAutoIncr {
+		switch dbType {
+		case models.MySQL:
+			colDef += " AUTO_INCREMENT"
+		case models.SQLServer:
+			colDef += " IDENTITY(1,1)"
+		}
+	}
+	return colDef
+}
+
+// collation COLLATE 뒤의 이름 (PostgreSQL은 인용, schema.name 가능)
+func (p *Parser) collation(name string, dbType models.DBType) string {
+	if dbType != models.PostgreSQL {
+		return name
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return p.quoteTable(name[:i], name[i+1:], dbType)
+	}
+	return p.quote(name, dbType)
+}
+
+// checkClause [CONSTRAINT ...] CHECK (...) 절
+func (p *Parser) checkClause(c models.Check, dbType models.DBType) string {
+	clause := "CHECK (" + c.Expression + ")"
+	if c.Name != "" {
+		clause = "CONSTRAINT " + p.quote(c.Name, dbType) + " " + clause
+	}
+	return clause
+}
+
+// foreignKeyClause CONSTRAINT ... FOREIGN KEY ... REFERENCES ... 절
+func (p *Parser) foreignKeyClause(table string, fk ForeignKey, dbType models.DBType) string {
+	name := fk.Name
+	if name == "" {
+		name = fmt.Sprintf("fk_%s_%s", table, strings.Join(fk.Columns, "_"))
+	}
+	clause := fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s)",
+		p.quote(name, dbType),
+		p.quoteList(fk.Columns, dbType),
+		p.quoteTable(fk.RefSchema, fk.RefTable, dbType),
+		p.quoteList(fk.RefColumns, dbType))
+
+	if action := normalizeAction(fk.OnDelete); action != "NO ACTION" {
+		clause += " ON DELETE " + action
+	}
+	// Oracle은 ON UPDATE를 지원하지 않음
+	if action := normalizeAction(fk.OnUpdate); action != "NO ACTION" && dbType != models.Oracle {
+		clause += " ON UPDATE " + action
+	}
+	return clause
+}
+
+// createIndexSQL CREATE INDEX 문 (table은 이미 인용된 테이블 이름)
+func (p *Parser) createIndexSQL(table string, idx models.Index, dbType models.DBType) string {
+	unique := ""
+	if idx.IsUnique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);",
+		unique, p.quote(idx.Name, dbType),
+		table,
+		p.quoteList(idx.Columns, dbType))
+}
+
+func (p *Parser) quoteList(names []string, dbType models.DBType) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = p.quote(name, dbType)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// quoteTable 스키마가 있으면 schema.table 형태로 인용
+func (p *Parser) quoteTable(schema, name string, dbType models.DBType) string {
+	if schema == "" {
+		return p.quote(name, dbType)
+	}
+	return p.quote(schema, dbType) + "." + p.quote(name, dbType)
+}
+
 func (p *Parser) quote(name string, dbType models.DBType) string {
 	switch dbType {
 	case models.MySQL:
 		return "`" + name + "`"
-	case models.PostgreSQL:
+	case models.PostgreSQL, models.SQLite:
 		return `"` + name + `"`
 	case models.SQLServer:
 		return "[" + name + "]"
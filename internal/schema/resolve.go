@@ -0,0 +1,684 @@
+package schema
+
+import (
+	"fmt"
+	"sql-genius/pkg/models"
+	"strings"
+)
+
+// QueryCheck 생성된 SQL의 정적 검증 결과
+type QueryCheck struct {
+	Statement  string         // 첫 문장의 시작 키워드 (SELECT, INSERT, WITH 등)
+	Statements int            // 문장 수
+	Issues     []models.Issue // 발견된 문제
+}
+
+// HasErrors error 수준 문제가 있는지 확인
+func (c *QueryCheck) HasErrors() bool {
+	for _, issue := range c.Issues {
+		if issue.Type == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// statementStarts SQL 문장을 시작할 수 있는 키워드
+var statementStarts = map[string]bool{
+	"SELECT": true, "WITH": true, "INSERT": true, "UPDATE": true, "DELETE": true,
+	"MERGE": true, "REPLACE": true, "UPSERT": true, "VALUES": true, "TABLE": true,
+	"CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true, "RENAME": true,
+	"COMMENT": true, "GRANT": true, "REVOKE": true, "CALL": true, "EXEC": true,
+	"EXECUTE": true, "BEGIN": true, "DECLARE": true, "SET": true, "SHOW": true,
+	"DESCRIBE": true, "DESC": true, "EXPLAIN": true, "PRAGMA": true,
+}
+
+// ddlStarts 식별자 확인을 건너뛰는 문장 (새 객체를 정의하므로 스키마와 비교할 수 없음)
+var ddlStarts = map[string]bool{
+	"CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true, "RENAME": true,
+	"COMMENT": true, "GRANT": true, "REVOKE": true,
+}
+
+// sqlKeywords 컬럼으로 취급하지 않는 키워드, 내장 값, 타입 이름
+var sqlKeywords = toSet(`
+	ADD ALL AND ANY AS ASC BETWEEN BY CASE CAST COLLATE CONFLICT CONSTRAINT CROSS CUBE CURRENT
+	CURRENT_DATE CURRENT_TIME CURRENT_TIMESTAMP CURRENT_USER DEFAULT DELETE DESC DISTINCT DO DUPLICATE
+	ELSE END ESCAPE EXCEPT EXISTS FALSE FETCH FILTER FIRST FOLLOWING FOR FROM FULL GROUP GROUPING HAVING
+	IGNORE ILIKE IN INNER INSERT INTERSECT INTERVAL INTO IS JOIN KEY LAST LATERAL LEFT LIKE LIMIT
+	LOCALTIME LOCALTIMESTAMP MATCHED MINUS NATURAL NEXT NO NOT NOTHING NULL NULLS OF OFFSET ON ONLY
+	OR ORDER OUTER OVER PARTITION PERCENT PRECEDING RANGE RECURSIVE REGEXP RETURNING RIGHT RLIKE ROLLUP
+	ROW ROWS SELECT SESSION_USER SET SETS SIMILAR SKIP SOME SYSDATE SYSTIMESTAMP THEN TIES TO TOP TRUE
+	UNBOUNDED UNION UNIQUE UNKNOWN UPDATE USER USING VALUES WHEN WHERE WINDOW WITH WITHIN
+	LOCK SHARE NOWAIT LOCKED MODE READ WRITE STRAIGHT_JOIN SQL_CALC_FOUND_ROWS HIGH_PRIORITY
+	PRIOR CONNECT START LEVEL ROWNUM ROWID DUAL APPLY PIVOT UNPIVOT OUTPUT TOP TIES NOLOCK
+	YEAR MONTH DAY HOUR MINUTE SECOND WEEK QUARTER EPOCH DOW DOY MICROSECOND MILLISECOND
+	YEAR_MONTH DAY_HOUR DAY_MINUTE DAY_SECOND HOUR_MINUTE HOUR_SECOND MINUTE_SECOND ZONE TIME TIMESTAMP
+	DATE DATETIME INT INTEGER BIGINT SMALLINT TINYINT DECIMAL NUMERIC NUMBER FLOAT REAL DOUBLE PRECISION
+	CHAR VARCHAR VARCHAR2 NVARCHAR TEXT BOOLEAN BOOL BLOB CLOB JSON JSONB UUID SIGNED UNSIGNED
+	BINARY VARYING CHARACTER WITHOUT LOCAL ASYMMETRIC SYMMETRIC ARRAY
+`)
+
+// opaqueSchemas 컬럼 정보를 알 수 없는 시스템 스키마
+var opaqueSchemas = toSet(`INFORMATION_SCHEMA PG_CATALOG SYS MYSQL PERFORMANCE_SCHEMA SQLITE_MASTER SQLITE_SCHEMA`)
+
+// pseudoTables 문맥에 따라 대상 테이블을 가리키는 가상 테이블 (ON CONFLICT, OUTPUT, 트리거)
+var pseudoTables = toSet(`EXCLUDED INSERTED DELETED NEW OLD`)
+
+// aliasStops 테이블 참조 뒤에 와도 별칭이 아닌 키워드
+var aliasStops = toSet(`
+	ON USING WHERE GROUP ORDER HAVING LIMIT OFFSET FETCH UNION INTERSECT EXCEPT MINUS WINDOW
+	JOIN INNER LEFT RIGHT FULL OUTER CROSS NATURAL STRAIGHT_JOIN SET VALUES SELECT DEFAULT OUTPUT
+	RETURNING FOR WITH WHEN CONNECT START PIVOT UNPIVOT APPLY PARTITION TABLESAMPLE USE FORCE IGNORE
+	LOCK INTO
+`)
+
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// CheckQuery 생성된 SQL을 토큰 단위로 분석해 문법 오류와 스키마에 없는 테이블/컬럼 참조를 찾음
+// 완전한 SQL 파서가 아니므로 확신할 수 없는 경우(파생 테이블, CTE, 테이블 함수 등)에는 문제로 보고하지 않습니다.
+func (p *Parser) CheckQuery(query string, schema *models.Schema) *QueryCheck {
+	check := &QueryCheck{}
+	dbType := models.DBType("")
+	if schema != nil {
+		dbType = schema.DBType
+	}
+
+	tokens, err := tokenize(query, dbType)
+	if err != nil {
+		check.Issues = append(check.Issues, models.Issue{
+			Type:       "error",
+			Message:    fmt.Sprintf("SQL 토큰 분석 실패: %v", err),
+			Suggestion: "따옴표와 주석이 올바르게 닫혔는지 확인하세요",
+		})
+		return check
+	}
+
+	for _, stmt := range splitTokenStatements(tokens) {
+		check.Statements++
+		first := stmt[0].upper()
+		if check.Statements == 1 {
+			check.Statement = first
+			if stmt[0].kind == tokPunct && stmt[0].value == "(" {
+				check.Statement = "SELECT"
+			}
+		}
+
+		if issue := checkParens(stmt); issue != nil {
+			check.Issues = append(check.Issues, *issue)
+			continue
+		}
+		if !statementStarts[first] && !(stmt[0].kind == tokPunct && stmt[0].value == "(") {
+			check.Issues = append(check.Issues, models.Issue{
+				Type:       "error",
+				Message:    fmt.Sprintf("%s: SQL 문으로 시작하지 않습니다: %q", stmt[0].pos, stmt[0].value),
+				Location:   stmt[0].value,
+				Suggestion: "설명 문장 없이 SQL 쿼리만 작성하세요",
+			})
+			continue
+		}
+		if ddlStarts[first] || schema == nil || len(schema.Tables) == 0 {
+			continue
+		}
+
+		r := newResolver(stmt, schema, query)
+		r.resolve()
+		check.Issues = append(check.Issues, r.issues...)
+	}
+
+	if check.Statements == 0 {
+		check.Issues = append(check.Issues, models.Issue{
+			Type:    "error",
+			Message: "SQL 쿼리가 비어 있습니다",
+		})
+	}
+
+	return check
+}
+
+// splitTokenStatements ';' 기준으로 문장 분리 (빈 문장 제외)
+func splitTokenStatements(tokens []token) [][]token {
+	var stmts [][]token
+	start := 0
+	for i, tok := range tokens {
+		if tok.kind == tokEOF || (tok.kind == tokPunct && tok.value == ";") {
+			if i > start {
+				stmts = append(stmts, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	return stmts
+}
+
+// checkParens 괄호 짝 확인
+func checkParens(stmt []token) *models.Issue {
+	var open []token
+	for _, tok := range stmt {
+		if tok.kind != tokPunct {
+			continue
+		}
+		switch tok.value {
+		case "(":
+			open = append(open, tok)
+		case ")":
+			if len(open) == 0 {
+				return &models.Issue{
+					Type:     "error",
+					Message:  fmt.Sprintf("%s: 여는 괄호 없이 ')'가 있습니다", tok.pos),
+					Location: tok.pos.String(),
+				}
+			}
+			open = open[:len(open)-1]
+		}
+	}
+	if len(open) > 0 {
+		tok := open[len(open)-1]
+		return &models.Issue{
+			Type:     "error",
+			Message:  fmt.Sprintf("%s: 닫히지 않은 괄호가 있습니다", tok.pos),
+			Location: tok.pos.String(),
+		}
+	}
+	return nil
+}
+
+// source FROM/JOIN 등으로 참조된 테이블 (table이 nil이면 컬럼을 알 수 없는 원본)
+type source struct {
+	table *models.Table
+}
+
+// resolver 한 문장의 식별자를 스키마와 대조
+type resolver struct {
+	toks   []token
+	schema *models.Schema
+	src    []rune
+
+	sources  map[string]source // 소문자 테이블명/별칭 → 원본
+	ctes     map[string]bool
+	aliases  map[string]bool // SELECT 목록 등에서 정의된 별칭
+	consumed map[int]bool    // 테이블 참조로 처리된 토큰 위치
+	opaque   bool            // 컬럼을 알 수 없는 원본이 있으면 비한정 컬럼은 확인하지 않음
+	issues   []models.Issue
+}
+
+func newResolver(toks []token, schema *models.Schema, query string) *resolver {
+	return &resolver{
+		toks:     toks,
+		schema:   schema,
+		src:      []rune(query),
+		sources:  make(map[string]source),
+		ctes:     make(map[string]bool),
+		aliases:  make(map[string]bool),
+		consumed: make(map[int]bool),
+	}
+}
+
+func (r *resolver) resolve() {
+	r.collectCTEs()
+	r.collectSources()
+	r.checkColumns()
+}
+
+func (r *resolver) tok(i int) token {
+	if i < 0 || i >= len(r.toks) {
+		return token{kind: tokEOF}
+	}
+	return r.toks[i]
+}
+
+func (r *resolver) isPunct(i int, value string) bool {
+	t := r.tok(i)
+	return t.kind == tokPunct && t.value == value
+}
+
+// isName 식별자로 쓸 수 있는 토큰인지 (MySQL의 "..."는 문자열)
+func (r *resolver) isName(i int) bool {
+	t := r.tok(i)
+	if t.kind == tokIdent {
+		return true
+	}
+	if t.kind != tokQuotedIdent {
+		return false
+	}
+	return !(r.schema.DBType == models.MySQL && t.pos.Offset < len(r.src) && r.src[t.pos.Offset] == '"')
+}
+
+// skipParens i 위치의 '('와 짝이 맞는 ')' 다음 위치 반환
+func (r *resolver) skipParens(i int) int {
+	depth := 0
+	for ; i < len(r.toks); i++ {
+		if r.isPunct(i, "(") {
+			depth++
+		} else if r.isPunct(i, ")") {
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+// collectCTEs WITH name [(cols)] AS (...) 형태의 CTE 이름 수집
+func (r *resolver) collectCTEs() {
+	for i := 0; i < len(r.toks); i++ {
+		if r.tok(i).upper() != "WITH" || r.isPunct(i+1, "(") {
+			continue
+		}
+		j := i + 1
+		if r.tok(j).upper() == "RECURSIVE" {
+			j++
+		}
+		for r.isName(j) {
+			name := strings.ToLower(r.tok(j).value)
+			k := j + 1
+			if r.isPunct(k, "(") {
+				k = r.skipParens(k)
+			}
+			if r.tok(k).upper() != "AS" {
+				break
+			}
+			k++
+			if r.tok(k).upper() == "NOT" {
+				k++
+			}
+			if r.tok(k).upper() == "MATERIALIZED" {
+				k++
+			}
+			if !r.isPunct(k, "(") {
+				break
+			}
+			r.ctes[name] = true
+			r.consumed[j] = true
+			r.opaque = true
+			k = r.skipParens(k)
+			if !r.isPunct(k, ",") {
+				break
+			}
+			j = k + 1
+		}
+	}
+}
+
+// collectSources FROM, JOIN, UPDATE, INTO, USING 뒤의 테이블 참조 수집
+func (r *resolver) collectSources() {
+	var parens []string // 열린 괄호 앞의 키워드 (EXTRACT(... FROM ...) 구분용)
+	for i := 0; i < len(r.toks); i++ {
+		t := r.tok(i)
+		if r.isPunct(i, "(") {
+			parens = append(parens, r.tok(i-1).upper())
+			continue
+		}
+		if r.isPunct(i, ")") && len(parens) > 0 {
+			parens = parens[:len(parens)-1]
+			continue
+		}
+
+		switch t.upper() {
+		case "FROM":
+			if len(parens) > 0 && fromFunctions[parens[len(parens)-1]] {
+				continue // EXTRACT(YEAR FROM d), SUBSTRING(s FROM 1), TRIM(x FROM s)
+			}
+			if r.tok(i-1).upper() == "DISTINCT" {
+				continue // IS [NOT] DISTINCT FROM
+			}
+			r.readTableRefs(i+1, true, false)
+		case "JOIN", "APPLY":
+			r.readTableRefs(i+1, false, false)
+		case "USING":
+			if r.isPunct(i+1, "(") {
+				continue // JOIN ... USING (col)
+			}
+			r.readTableRefs(i+1, true, false)
+		case "UPDATE":
+			switch r.tok(i - 1).upper() {
+			case "FOR", "KEY", "DO", "THEN":
+				continue // FOR UPDATE, ON DUPLICATE KEY UPDATE, DO UPDATE, THEN UPDATE
+			}
+			r.readTableRefs(i+1, true, false)
+		case "INTO":
+			switch r.tok(i - 1).upper() {
+			case "INSERT", "IGNORE", "REPLACE", "MERGE":
+				r.readTableRefs(i+1, false, true)
+			}
+		}
+	}
+}
+
+// fromFunctions 인자 안에 FROM을 쓰는 함수
+var fromFunctions = toSet(`EXTRACT SUBSTRING SUBSTR TRIM OVERLAY`)
+
+// readTableRefs i 위치부터 테이블 참조(와 별칭)를 읽음
+// list가 true면 ','로 이어진 목록도 읽고, into가 true면 뒤의 괄호를 컬럼 목록으로 취급
+func (r *resolver) readTableRefs(i int, list, into bool) {
+	for {
+		switch r.tok(i).upper() {
+		case "LATERAL", "ONLY":
+			i++
+		}
+
+		var tbl *models.Table
+		known := false
+		name := ""
+
+		if r.isPunct(i, "(") {
+			// 파생 테이블 또는 서브쿼리 (내부 FROM은 별도로 처리됨)
+			r.opaque = true
+			i = r.skipParens(i)
+		} else if r.isName(i) {
+			parts := []string{r.tok(i).value}
+			r.consumed[i] = true
+			i++
+			for r.isPunct(i, ".") && r.isName(i+1) {
+				parts = append(parts, r.tok(i+1).value)
+				r.consumed[i+1] = true
+				i += 2
+			}
+			name = parts[len(parts)-1]
+
+			switch {
+			case r.isPunct(i, "(") && !into:
+				// 테이블 함수 (generate_series, UNNEST, TABLE(...) 등)
+				r.opaque = true
+				i = r.skipParens(i)
+			case strings.EqualFold(name, "DUAL"):
+			case len(parts) > 1 && opaqueSchemas[strings.ToUpper(parts[len(parts)-2])],
+				opaqueSchemas[strings.ToUpper(name)]:
+				r.opaque = true
+			case r.ctes[strings.ToLower(name)]:
+			default:
+				qualifier := ""
+				if len(parts) > 1 {
+					qualifier = parts[len(parts)-2]
+				}
+				tbl = lookupTable(r.schema, qualifier, name)
+				if tbl == nil {
+					tbl = lookupView(r.schema, qualifier, name)
+				}
+				known = tbl != nil
+				if known && len(tbl.Columns) == 0 {
+					// 컬럼을 모르는 뷰
+					tbl = nil
+					r.opaque = true
+				}
+				if !known {
+					r.opaque = true
+					r.issues = append(r.issues, models.Issue{
+						Type:       "error",
+						Message:    fmt.Sprintf("%s: 스키마에 없는 테이블입니다: %s", r.tok(i-1).pos, strings.Join(parts, ".")),
+						Location:   strings.Join(parts, "."),
+						Suggestion: suggestName(name, tableNames(r.schema)),
+					})
+				}
+			}
+			if name != "" {
+				r.sources[strings.ToLower(name)] = source{table: tbl}
+			}
+		} else {
+			return
+		}
+
+		if into {
+			return // INSERT INTO t (cols): 컬럼 목록은 checkColumns에서 확인
+		}
+
+		// SQL Server 테이블 힌트 WITH (NOLOCK)
+		if r.tok(i).upper() == "WITH" && r.isPunct(i+1, "(") {
+			i = r.skipParens(i + 1)
+		}
+
+		// 별칭
+		if r.tok(i).upper() == "AS" {
+			i++
+		}
+		if r.isName(i) && !aliasStops[r.tok(i).upper()] {
+			alias := strings.ToLower(r.tok(i).value)
+			r.consumed[i] = true
+			if known {
+				r.sources[alias] = source{table: tbl}
+			} else {
+				r.sources[alias] = source{}
+			}
+			i++
+			if r.isPunct(i, "(") {
+				i = r.skipParens(i) // 별칭 컬럼 목록 t(a, b)
+			}
+		}
+
+		if !list || !r.isPunct(i, ",") {
+			return
+		}
+		i++
+	}
+}
+
+// checkColumns 한정 참조(alias.col)와 비한정 컬럼 참조 확인
+func (r *resolver) checkColumns() {
+	r.collectAliases()
+
+	for i := 0; i < len(r.toks); i++ {
+		if r.consumed[i] || !r.isName(i) {
+			continue
+		}
+		t := r.tok(i)
+
+		if r.isPunct(i+1, ".") && (r.isName(i+2) || r.isPunct(i+2, "*")) {
+			r.checkQualified(i)
+			i += 2
+			for r.isPunct(i+1, ".") {
+				i += 2
+			}
+			continue
+		}
+
+		if r.opaque || len(r.sources) == 0 || !r.isColumnPosition(i) {
+			continue
+		}
+		if t.kind == tokIdent && sqlKeywords[t.upper()] {
+			continue
+		}
+		name := strings.ToLower(t.value)
+		if r.aliases[name] || r.ctes[name] {
+			continue
+		}
+		if _, ok := r.sources[name]; ok {
+			continue
+		}
+		if r.columnInScope(t.value) {
+			continue
+		}
+
+		r.issues = append(r.issues, models.Issue{
+			Type:       "error",
+			Message:    fmt.Sprintf("%s: 참조한 테이블에 없는 컬럼입니다: %s", t.pos, t.value),
+			Location:   t.value,
+			Suggestion: suggestName(t.value, r.scopeColumns()),
+		})
+	}
+}
+
+// checkQualified i 위치의 qualifier.column 참조 확인
+func (r *resolver) checkQualified(i int) {
+	qualifier := r.tok(i)
+	last := i + 2
+	for r.isPunct(last+1, ".") && (r.isName(last+2) || r.isPunct(last+2, "*")) {
+		qualifier = r.tok(last) // schema.table.col 이면 table 기준
+		last += 2
+	}
+	column := r.tok(last)
+
+	if r.isPunct(last+1, "(") {
+		return // schema.function(...)
+	}
+	switch strings.ToUpper(column.value) {
+	case "NEXTVAL", "CURRVAL":
+		return // Oracle 시퀀스
+	}
+
+	key := strings.ToLower(qualifier.value)
+	src, ok := r.sources[key]
+	if !ok {
+		if r.ctes[key] || pseudoTables[strings.ToUpper(qualifier.value)] || opaqueSchemas[strings.ToUpper(qualifier.value)] {
+			return
+		}
+		r.issues = append(r.issues, models.Issue{
+			Type:       "error",
+			Message:    fmt.Sprintf("%s: FROM 절에 없는 테이블 또는 별칭입니다: %s", qualifier.pos, qualifier.value),
+			Location:   qualifier.value + "." + column.value,
+			Suggestion: suggestName(qualifier.value, r.sourceNames()),
+		})
+		return
+	}
+	if src.table == nil || column.kind == tokPunct {
+		return
+	}
+	if findColumn(src.table, column.value) != nil {
+		return
+	}
+
+	r.issues = append(r.issues, models.Issue{
+		Type:       "error",
+		Message:    fmt.Sprintf("%s: %s 테이블에 없는 컬럼입니다: %s", column.pos, src.table.Name, column.value),
+		Location:   qualifier.value + "." + column.value,
+		Suggestion: suggestName(column.value, columnNames(src.table)),
+	})
+}
+
+// collectAliases AS 별칭과 "식 별칭" 형태의 암묵적 별칭 수집 (ORDER BY 등에서 참조 가능)
+func (r *resolver) collectAliases() {
+	for i := 1; i < len(r.toks); i++ {
+		if !r.isName(i) || r.consumed[i] {
+			continue
+		}
+		prev := r.tok(i - 1)
+		t := r.tok(i)
+		if t.kind == tokIdent && sqlKeywords[t.upper()] {
+			continue
+		}
+		switch {
+		case prev.upper() == "AS", prev.upper() == "END", prev.upper() == "WINDOW", prev.upper() == "OVER":
+		case prev.kind == tokPunct && prev.value == ")":
+		case prev.kind == tokString || prev.kind == tokNumber:
+		case (prev.kind == tokIdent || prev.kind == tokQuotedIdent) && !sqlKeywords[prev.upper()]:
+		default:
+			continue
+		}
+		if r.isPunct(i+1, "(") {
+			continue
+		}
+		r.aliases[strings.ToLower(t.value)] = true
+	}
+}
+
+// isColumnPosition 컬럼 참조로 볼 수 있는 위치인지 (함수 호출, 변수, 타입 캐스트, 별칭 정의 제외)
+func (r *resolver) isColumnPosition(i int) bool {
+	if r.isPunct(i+1, "(") {
+		return false
+	}
+	prev := r.tok(i - 1)
+	if prev.kind == tokPunct {
+		switch prev.value {
+		case "@", ":", "::", ".", "$":
+			return false
+		}
+	}
+	switch prev.upper() {
+	case "AS", "COLLATE":
+		return false
+	}
+	return true
+}
+
+func (r *resolver) columnInScope(name string) bool {
+	for _, src := range r.sources {
+		if src.table != nil && findColumn(src.table, name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resolver) scopeColumns() []string {
+	var names []string
+	for _, src := range r.sources {
+		if src.table != nil {
+			names = append(names, columnNames(src.table)...)
+		}
+	}
+	return names
+}
+
+func (r *resolver) sourceNames() []string {
+	var names []string
+	for name := range r.sources {
+		names = append(names, name)
+	}
+	return names
+}
+
+func tableNames(schema *models.Schema) []string {
+	names := make([]string, 0, len(schema.Tables)+len(schema.Views))
+	for _, t := range schema.Tables {
+		names = append(names, t.Name)
+	}
+	for _, v := range schema.Views {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+func columnNames(table *models.Table) []string {
+	names := make([]string, len(table.Columns))
+	for i, c := range table.Columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// suggestName 가장 비슷한 이름을 제안 (편집 거리 기준, 충분히 비슷하지 않으면 빈 문자열)
+func suggestName(name string, candidates []string) string {
+	best, bestDist := "", -1
+	target := strings.ToLower(name)
+	for _, c := range candidates {
+		d := levenshtein(target, strings.ToLower(c))
+		if bestDist < 0 || d < bestDist || (d == bestDist && c < best) {
+			best, bestDist = c, d
+		}
+	}
+
+	limit := len([]rune(name)) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if best == "" || bestDist > limit {
+		return ""
+	}
+	return fmt.Sprintf("%s을(를) 의도했나요?", best)
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
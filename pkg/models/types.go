@@ -8,14 +8,25 @@ const (
 	PostgreSQL DBType = "postgresql"
 	Oracle     DBType = "oracle"
 	SQLServer  DBType = "sqlserver"
+	SQLite     DBType = "sqlite"
 )
 
 // AIProvider AI 제공자 종류
 type AIProvider string
 
 const (
-	Ollama AIProvider = "ollama"
-	Groq   AIProvider = "groq"
+	Ollama           AIProvider = "ollama"
+	Groq             AIProvider = "groq"
+	OpenAICompatible AIProvider = "openai-compatible" // vLLM, LM Studio, llama.cpp, LocalAI, Azure OpenAI 등
+)
+
+// ExecPolicy 연결별 쿼리 실행 정책
+type ExecPolicy string
+
+const (
+	PolicyReadOnly     ExecPolicy = "read-only"    // 조회만 허용 (기본값)
+	PolicyConfirmDML   ExecPolicy = "confirm-dml"  // DML은 확인 후 실행, DDL과 여러 문장은 차단
+	PolicyUnrestricted ExecPolicy = "unrestricted" // 제한 없음
 )
 
 // DBConfig 데이터베이스 연결 설정
@@ -26,15 +37,95 @@ type DBConfig struct {
 	User     string `json:"user"`
 	Password string `json:"password"`
 	Database string `json:"database"`
+
+	Policy ExecPolicy `json:"policy,omitempty"` // 비어 있으면 read-only
+
+	// TLS (비어 있으면 DB별 기존 기본값)
+	TLSMode    TLSMode `json:"tls_mode,omitempty"`
+	CACert     string  `json:"ca_cert,omitempty"`     // CA 인증서 파일 (PEM, Oracle은 wallet 디렉터리)
+	ClientCert string  `json:"client_cert,omitempty"` // 클라이언트 인증서 파일 (PEM)
+	ClientKey  string  `json:"client_key,omitempty"`  // 클라이언트 개인 키 파일 (PEM)
+
+	ApplicationName string `json:"application_name,omitempty"` // 서버의 세션 목록에 표시할 이름
+	ConnectTimeout  int    `json:"connect_timeout,omitempty"`  // 초 (0이면 기본값)
+	ReadTimeout     int    `json:"read_timeout,omitempty"`     // 초 (0이면 기본값)
+	MaxOpenConns    int    `json:"max_open_conns,omitempty"`   // 0이면 기본값 (SQLite는 1 고정)
+	MaxIdleConns    int    `json:"max_idle_conns,omitempty"`   // 0이면 기본값
+
+	// Oracle: 서비스 이름 (비어 있으면 Database) 또는 SID
+	ServiceName string `json:"service_name,omitempty"`
+	SID         string `json:"sid,omitempty"`
+
+	// Params 드라이버 DSN 추가 파라미터 (위 설정보다 우선)
+	Params map[string]string `json:"params,omitempty"`
+
+	// SSH 배스천 호스트를 거쳐 연결 (Host, Port는 배스천에서 본 DB 주소)
+	SSH *SSHConfig `json:"ssh,omitempty"`
 }
 
+// SSHConfig SSH 터널 설정
+// 인증은 키 파일, 에이전트, 비밀번호 중 지정한 것을 모두 시도합니다.
+type SSHConfig struct {
+	Host          string `json:"host"`
+	Port          int    `json:"port,omitempty"` // 0이면 22
+	User          string `json:"user"`
+	Password      string `json:"password,omitempty"`
+	KeyFile       string `json:"key_file,omitempty"`       // 개인 키 파일 (PEM, OpenSSH)
+	KeyPassphrase string `json:"key_passphrase,omitempty"` // 암호화된 개인 키의 암호
+	Agent         bool   `json:"agent,omitempty"`          // SSH_AUTH_SOCK의 ssh-agent 사용
+
+	KnownHosts            string `json:"known_hosts,omitempty"`              // 비어 있으면 ~/.ssh/known_hosts
+	InsecureIgnoreHostKey bool   `json:"insecure_ignore_host_key,omitempty"` // 호스트 키 검증 생략 (테스트용)
+}
+
+// TLSMode 연결 암호화 수준 (PostgreSQL sslmode와 같은 의미)
+type TLSMode string
+
+const (
+	TLSDisable    TLSMode = "disable"     // 암호화하지 않음
+	TLSPrefer     TLSMode = "prefer"      // 서버가 지원하면 암호화
+	TLSRequire    TLSMode = "require"     // 암호화 (인증서 검증 안 함)
+	TLSVerifyCA   TLSMode = "verify-ca"   // 암호화 + CA 검증
+	TLSVerifyFull TLSMode = "verify-full" // 암호화 + CA와 호스트 이름 검증
+)
+
 // Table 테이블 정보
 type Table struct {
+	Schema      string   `json:"schema,omitempty"` // 스키마 (MySQL은 데이터베이스, SQLite와 스키마 없는 DDL은 비어 있음)
 	Name        string   `json:"name"`
 	Columns     []Column `json:"columns"`
 	PrimaryKey  []string `json:"primary_key"`
 	ForeignKeys []FK     `json:"foreign_keys"`
 	Indexes     []Index  `json:"indexes"`
+	Checks      []Check  `json:"checks,omitempty"`
+
+	Stats *TableStats `json:"stats,omitempty"` // 카탈로그 통계 (수집한 경우만)
+}
+
+// TableStats 테이블 통계 (DB가 마지막으로 통계를 갱신한 시점의 추정치)
+type TableStats struct {
+	Rows      int64 `json:"rows"`                 // 추정 행 수 (-1이면 수집된 통계 없음)
+	SizeBytes int64 `json:"size_bytes,omitempty"` // 디스크 크기 (인덱스 포함)
+}
+
+// ColumnStats 컬럼 통계 (알 수 없는 항목은 0 또는 비어 있음)
+type ColumnStats struct {
+	Distinct     int64    `json:"distinct,omitempty"`      // 고유 값 수 (추정)
+	NullFraction float64  `json:"null_fraction,omitempty"` // NULL 비율 (0~1)
+	Histogram    []string `json:"histogram,omitempty"`     // 히스토그램 경계 값 (오름차순, 값의 대략적인 분포와 범위)
+}
+
+// QualifiedName 스키마로 한정한 이름 (schema.table, 스키마가 없으면 이름만)
+func (t Table) QualifiedName() string {
+	return QualifiedName(t.Schema, t.Name)
+}
+
+// QualifiedName schema.name (schema가 비어 있으면 name)
+func QualifiedName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
 }
 
 // Column 컬럼 정보
@@ -48,12 +139,28 @@ type Column struct {
 	IsFK       bool   `json:"is_fk"`
 	IsUnique   bool   `json:"is_unique"`
 	IsAutoIncr bool   `json:"is_auto_incr"`
+
+	EnumValues []string `json:"enum_values,omitempty"` // 허용 값 (ENUM/SET, PostgreSQL enum 타입, col IN (...) 형태의 CHECK)
+	Domain     string   `json:"domain,omitempty"`      // PostgreSQL 도메인 (Type은 도메인의 기본 타입)
+	Collation  string   `json:"collation,omitempty"`   // 테이블/DB 기본값과 다를 때만
+	Generated  string   `json:"generated,omitempty"`   // 계산 컬럼 표현식
+	Stored     bool     `json:"stored,omitempty"`      // 계산 값을 저장하는지 (false면 읽을 때 계산)
+
+	Stats *ColumnStats `json:"stats,omitempty"` // 카탈로그 통계 (수집한 경우만)
+}
+
+// Check CHECK 제약조건
+type Check struct {
+	Name       string   `json:"name,omitempty"`
+	Columns    []string `json:"columns,omitempty"` // 표현식이 참조하는 컬럼
+	Expression string   `json:"expression"`        // CHECK ( ) 안의 표현식
 }
 
 // FK 외래키 정보
 type FK struct {
 	Name            string `json:"name"`
 	Column          string `json:"column"`
+	RefSchema       string `json:"ref_schema,omitempty"` // 참조 테이블의 스키마
 	RefTable        string `json:"ref_table"`
 	RefColumn       string `json:"ref_column"`
 	OnDelete        string `json:"on_delete,omitempty"`
@@ -70,9 +177,111 @@ type Index struct {
 
 // Schema 전체 스키마 정보
 type Schema struct {
-	Database string  `json:"database"`
-	Tables   []Table `json:"tables"`
-	DBType   DBType  `json:"db_type"`
+	Database  string     `json:"database"`
+	Tables    []Table    `json:"tables"`
+	DBType    DBType     `json:"db_type"`
+	Views     []View     `json:"views,omitempty"`     // 뷰와 구체화된 뷰
+	Sequences []Sequence `json:"sequences,omitempty"` // 컬럼에 속하지 않은 시퀀스
+	Routines  []Routine  `json:"routines,omitempty"`  // 함수와 프로시저
+	Triggers  []Trigger  `json:"triggers,omitempty"`
+	Types     []UserType `json:"types,omitempty"` // PostgreSQL enum 타입과 도메인
+}
+
+// UserTypeKind 사용자 정의 타입 종류
+type UserTypeKind string
+
+const (
+	TypeEnum   UserTypeKind = "ENUM"
+	TypeDomain UserTypeKind = "DOMAIN"
+)
+
+// UserType 사용자 정의 타입
+type UserType struct {
+	Schema   string       `json:"schema,omitempty"`
+	Name     string       `json:"name"`
+	Kind     UserTypeKind `json:"kind"`
+	Values   []string     `json:"values,omitempty"`    // ENUM 값 (정의 순서)
+	BaseType string       `json:"base_type,omitempty"` // DOMAIN 기본 타입
+	NotNull  bool         `json:"not_null,omitempty"`
+	Default  string       `json:"default,omitempty"`
+	Check    string       `json:"check,omitempty"` // DOMAIN CHECK 표현식 (값은 VALUE로 참조)
+}
+
+// QualifiedName 스키마로 한정한 이름
+func (t UserType) QualifiedName() string {
+	return QualifiedName(t.Schema, t.Name)
+}
+
+// View 뷰 정보
+type View struct {
+	Schema       string   `json:"schema,omitempty"`
+	Name         string   `json:"name"`
+	Columns      []Column `json:"columns"`
+	Definition   string   `json:"definition,omitempty"`   // 뷰를 정의하는 SELECT 문
+	Materialized bool     `json:"materialized,omitempty"` // 구체화된 뷰 (PostgreSQL, Oracle)
+}
+
+// QualifiedName 스키마로 한정한 이름
+func (v View) QualifiedName() string {
+	return QualifiedName(v.Schema, v.Name)
+}
+
+// Sequence 시퀀스 정보
+type Sequence struct {
+	Schema    string `json:"schema,omitempty"`
+	Name      string `json:"name"`
+	DataType  string `json:"data_type,omitempty"`
+	Start     int64  `json:"start"` // Oracle은 시작값 대신 다음에 할당할 값
+	Increment int64  `json:"increment"`
+	Cycle     bool   `json:"cycle,omitempty"`
+}
+
+// QualifiedName 스키마로 한정한 이름
+func (s Sequence) QualifiedName() string {
+	return QualifiedName(s.Schema, s.Name)
+}
+
+// RoutineKind 루틴 종류
+type RoutineKind string
+
+const (
+	RoutineFunction  RoutineKind = "FUNCTION"
+	RoutineProcedure RoutineKind = "PROCEDURE"
+)
+
+// Routine 함수 또는 프로시저
+type Routine struct {
+	Schema     string      `json:"schema,omitempty"`
+	Name       string      `json:"name"`
+	Kind       RoutineKind `json:"kind"`
+	Arguments  string      `json:"arguments"`         // 인자 목록 (예: "p_id integer, p_name text")
+	Returns    string      `json:"returns,omitempty"` // 반환 타입 (프로시저는 비어 있음)
+	Language   string      `json:"language,omitempty"`
+	Definition string      `json:"definition,omitempty"` // CREATE 문 전체
+}
+
+// QualifiedName 스키마로 한정한 이름
+func (r Routine) QualifiedName() string {
+	return QualifiedName(r.Schema, r.Name)
+}
+
+// Signature name(arguments) [RETURNS type]
+func (r Routine) Signature() string {
+	sig := r.QualifiedName() + "(" + r.Arguments + ")"
+	if r.Returns != "" {
+		sig += " RETURNS " + r.Returns
+	}
+	return sig
+}
+
+// Trigger 트리거 정보
+type Trigger struct {
+	Schema     string   `json:"schema,omitempty"`
+	Name       string   `json:"name"`
+	Table      string   `json:"table"`                // 같은 스키마의 테이블 또는 뷰
+	Timing     string   `json:"timing"`               // BEFORE, AFTER, INSTEAD OF
+	Events     []string `json:"events"`               // INSERT, UPDATE, DELETE, TRUNCATE
+	Definition string   `json:"definition,omitempty"` // CREATE 문 전체
 }
 
 // QueryRequest 쿼리 생성 요청
@@ -81,6 +290,18 @@ type QueryRequest struct {
 	Schema     Schema `json:"schema"`      // 스키마 정보
 	QueryType  string `json:"query_type"`  // SELECT, INSERT, UPDATE, DELETE, ALTER
 	Optimize   bool   `json:"optimize"`    // 최적화 여부
+
+	// Attempts 검증에 실패한 이전 시도 (다시 생성할 때 오류를 함께 전달)
+	Attempts []QueryAttempt `json:"attempts,omitempty"`
+
+	ModelOptions // 요청별 모델 설정
+}
+
+// ModelOptions 요청별로 바꾸는 AI 모델 설정 (비어 있으면 제공자 설정을 따름)
+type ModelOptions struct {
+	Model       string   `json:"model,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"` // 0~2
+	MaxTokens   int      `json:"max_tokens,omitempty"`
 }
 
 // QueryResponse 쿼리 생성 응답
@@ -89,6 +310,27 @@ type QueryResponse struct {
 	Explanation string   `json:"explanation"`  // 쿼리 설명
 	Tips        []string `json:"tips"`         // 최적화 팁
 	ExecuteTime int64    `json:"execute_time"` // 예상 실행 시간 (ms)
+
+	Verified bool           `json:"verified"`           // 스키마/DB 검증 통과 여부
+	Attempts []QueryAttempt `json:"attempts,omitempty"` // 생성·검증 시도 기록
+
+	Params []QueryParam `json:"params,omitempty"` // 쿼리의 바인드 파라미터 (:name)
+}
+
+// QueryParam 생성된 쿼리의 바인드 파라미터
+type QueryParam struct {
+	Name        string `json:"name"`                  // 쿼리의 :name에서 콜론을 뺀 이름
+	Type        string `json:"type,omitempty"`        // 값 타입 (INT, VARCHAR, DATE 등)
+	Description string `json:"description,omitempty"` // 설명
+	Value       string `json:"value,omitempty"`       // 요청에 나온 값 (실행 시 기본값)
+}
+
+// QueryAttempt 생성된 쿼리 한 건의 검증 결과
+type QueryAttempt struct {
+	Query   string  `json:"query"`
+	Issues  []Issue `json:"issues,omitempty"`  // 스키마 대조에서 발견된 문제
+	DBError string  `json:"db_error,omitempty"` // EXPLAIN 실행 시 데이터베이스 오류
+	Valid   bool    `json:"valid"`
 }
 
 // AIConfig AI 설정
@@ -97,6 +339,11 @@ type AIConfig struct {
 	Model    string     `json:"model"`
 	Endpoint string     `json:"endpoint"` // Ollama: http://localhost:11434, Groq: https://api.groq.com
 	APIKey   string     `json:"api_key,omitempty"`
+
+	// OpenAI 호환 API 옵션
+	Headers     map[string]string `json:"headers,omitempty"`     // 추가 HTTP 헤더 (예: Azure의 api-key)
+	Temperature *float64          `json:"temperature,omitempty"` // 미지정 시 제공자 기본값
+	MaxTokens   int               `json:"max_tokens,omitempty"`  // 0이면 제공자 기본값
 }
 
 // QueryValidation 쿼리 검증 결과